@@ -1,4 +1,10 @@
 // Package ecies implements the Elliptic Curve Integrated Encryption Scheme (ECIES).
+//
+// Encrypt and Decrypt process whole messages, NewEncrypter and NewDecrypter
+// stream them in chunks, e.g. for large backups, and EncryptMulti encrypts a
+// message once to several recipients. All take Options for the associated
+// data, the HKDF info and the AEAD, whose zero value is the AES-256-GCM
+// format of Encrypt.
 package ecies
 
 import (
@@ -11,9 +17,74 @@ import (
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/util/random"
+	"golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/hkdf"
 )
 
+// AEAD identifies the authenticated encryption scheme of the symmetric part.
+type AEAD int
+
+const (
+	// AES256GCM is AES-GCM with a 256-bit key, the AEAD of Encrypt.
+	AES256GCM AEAD = iota
+	// ChaCha20Poly1305 is the ChaCha20-Poly1305 AEAD of RFC 8439.
+	ChaCha20Poly1305
+)
+
+// Options are the optional parameters of the encryption, which must be the
+// same for the decryption. A nil Options is the zero value.
+type Options struct {
+	// Hash is the hash function of HKDF, SHA256 if nil.
+	Hash func() hash.Hash
+	// Info is the HKDF info, to bind the key to a context.
+	Info []byte
+	// AssociatedData is authenticated, but not encrypted.
+	AssociatedData []byte
+	// AEAD is the authenticated encryption scheme.
+	AEAD AEAD
+}
+
+func (o *Options) hash() func() hash.Hash {
+	if o == nil || o.Hash == nil {
+		return sha256.New
+	}
+	return o.Hash
+}
+
+func (o *Options) info() []byte {
+	if o == nil {
+		return nil
+	}
+	return o.Info
+}
+
+func (o *Options) associatedData() []byte {
+	if o == nil {
+		return nil
+	}
+	return o.AssociatedData
+}
+
+func (o *Options) newAEAD(key []byte) (cipher.AEAD, error) {
+	if o == nil || o.AEAD == AES256GCM {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		return cipher.NewGCM(block)
+	}
+	if o.AEAD == ChaCha20Poly1305 {
+		return chacha20poly1305.New(key)
+	}
+	return nil, errors.New("ecies: unknown AEAD")
+}
+
+// the lengths in bytes of the keys and of the nonces of both AEADs
+const (
+	keyLen   = 32
+	nonceLen = 12
+)
+
 // Encrypt first computes a shared DH key using the given public key, then
 // HKDF-derives a symmetric key (and nonce) from that, and finally uses these
 // values to encrypt the given message via AES-GCM. If the hash input parameter
@@ -21,10 +92,16 @@ import (
 // containing the ephemeral elliptic curve point of the DH key exchange and the
 // ciphertext or an error.
 func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func() hash.Hash) ([]byte, error) {
-	if hash == nil {
-		hash = sha256.New
-	}
+	return EncryptWithOptions(group, public, message, &Options{Hash: hash})
+}
 
+// EncryptWithOptions is Encrypt with the given options, which may be nil.
+func EncryptWithOptions(group kyber.Group, public kyber.Point, message []byte, opts *Options) ([]byte, error) {
+	return encrypt(group, public, message, nil, opts)
+}
+
+// encrypt implements EncryptWithOptions with the HKDF salt.
+func encrypt(group kyber.Group, public kyber.Point, message, salt []byte, opts *Options) ([]byte, error) {
 	// Generate an ephemeral elliptic curve scalar and point
 	r := group.Scalar().Pick(random.New())
 	R := group.Point().Mul(r, nil)
@@ -36,24 +113,19 @@ func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func()
 	// ephemeral key for every ECIES encryption and thus have a fresh
 	// HKDF-derived key for AES-GCM, the nonce for AES-GCM can be an arbitrary
 	// (even static) value. We derive it here simply via HKDF as well.)
-	keyNonceLen := 32 + 12
-	buf, err := deriveKey(hash, dh, keyNonceLen)
+	buf, err := deriveKey(opts.hash(), dh, salt, opts.info(), keyLen+nonceLen)
 	if err != nil {
 		return nil, err
 	}
-	key := buf[:32]
-	nonce := buf[32:keyNonceLen]
+	key := buf[:keyLen]
+	nonce := buf[keyLen:]
 
-	// Encrypt message using AES-GCM
-	aes, err := aes.NewCipher(key)
+	// Encrypt message using the AEAD
+	aead, err := opts.newAEAD(key)
 	if err != nil {
 		return nil, err
 	}
-	aesgcm, err := cipher.NewGCM(aes)
-	if err != nil {
-		return nil, err
-	}
-	c := aesgcm.Seal(nil, nonce, message, nil)
+	c := aead.Seal(nil, nonce, message, opts.associatedData())
 
 	// Serialize ephemeral elliptic curve point and ciphertext
 	var ctx bytes.Buffer
@@ -75,10 +147,17 @@ func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func()
 // input parameter is nil then SHA256 is used as a default. Decrypt returns the
 // plaintext message or an error.
 func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() hash.Hash) ([]byte, error) {
-	if hash == nil {
-		hash = sha256.New
-	}
+	return DecryptWithOptions(group, private, ctx, &Options{Hash: hash})
+}
+
+// DecryptWithOptions is Decrypt with the options of the encryption, which
+// may be nil.
+func DecryptWithOptions(group kyber.Group, private kyber.Scalar, ctx []byte, opts *Options) ([]byte, error) {
+	return decrypt(group, private, ctx, nil, opts)
+}
 
+// decrypt implements DecryptWithOptions with the HKDF salt.
+func decrypt(group kyber.Group, private kyber.Scalar, ctx, salt []byte, opts *Options) ([]byte, error) {
 	// Reconstruct the ephemeral elliptic curve point
 	R := group.Point()
 	l := group.PointLen()
@@ -91,32 +170,27 @@ func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() ha
 
 	// Compute shared DH key and derive the symmetric key and nonce via HKDF
 	dh := group.Point().Mul(private, R)
-	keyNonceLen := 32 + 12
-	buf, err := deriveKey(hash, dh, keyNonceLen)
+	buf, err := deriveKey(opts.hash(), dh, salt, opts.info(), keyLen+nonceLen)
 	if err != nil {
 		return nil, err
 	}
-	key := buf[:32]
-	nonce := buf[32:keyNonceLen]
+	key := buf[:keyLen]
+	nonce := buf[keyLen:]
 
-	// Decrypt message using AES-GCM
-	aes, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-	aesgcm, err := cipher.NewGCM(aes)
+	// Decrypt message using the AEAD
+	aead, err := opts.newAEAD(key)
 	if err != nil {
 		return nil, err
 	}
-	return aesgcm.Open(nil, nonce, ctx[l:], nil)
+	return aead.Open(nil, nonce, ctx[l:], opts.associatedData())
 }
 
-func deriveKey(hash func() hash.Hash, dh kyber.Point, l int) ([]byte, error) {
+func deriveKey(hash func() hash.Hash, dh kyber.Point, salt, info []byte, l int) ([]byte, error) {
 	dhb, err := dh.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
-	hkdf := hkdf.New(hash, dhb, nil, nil)
+	hkdf := hkdf.New(hash, dhb, salt, info)
 	key := make([]byte, l)
 	n, err := hkdf.Read(key)
 	if err != nil {
@@ -1,7 +1,10 @@
 package ecies
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha512"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,6 +52,188 @@ func TestECIESFailCiphertext(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestECIESOptions(t *testing.T) {
+	message := []byte("Hello ECIES")
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+
+	// The zero options are the format of Encrypt
+	ciphertext, err := Encrypt(suite, public, message, nil)
+	require.NoError(t, err)
+	plaintext, err := DecryptWithOptions(suite, private, ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, message, plaintext)
+	ciphertext, err = EncryptWithOptions(suite, public, message, &Options{})
+	require.NoError(t, err)
+	plaintext, err = Decrypt(suite, private, ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, message, plaintext)
+
+	for _, aead := range []AEAD{AES256GCM, ChaCha20Poly1305} {
+		opts := &Options{Hash: sha512.New, Info: []byte("info"), AssociatedData: []byte("data"), AEAD: aead}
+		ciphertext, err := EncryptWithOptions(suite, public, message, opts)
+		require.NoError(t, err)
+		plaintext, err := DecryptWithOptions(suite, private, ciphertext, opts)
+		require.NoError(t, err)
+		require.Equal(t, message, plaintext)
+
+		for _, other := range []*Options{
+			{Hash: sha512.New, Info: []byte("info"), AEAD: aead},
+			{Hash: sha512.New, AssociatedData: []byte("data"), AEAD: aead},
+			{Hash: sha512.New, Info: []byte("info"), AssociatedData: []byte("data"), AEAD: 1 - aead},
+		} {
+			_, err = DecryptWithOptions(suite, private, ciphertext, other)
+			require.Error(t, err)
+		}
+	}
+
+	_, err = EncryptWithOptions(suite, public, message, &Options{AEAD: 42})
+	require.Error(t, err)
+}
+
+func encryptStream(t *testing.T, group kyber.Group, public kyber.Point, message []byte, opts *Options) []byte {
+	var buf bytes.Buffer
+	w, err := NewEncrypter(group, public, &buf, opts)
+	require.NoError(t, err)
+	// Written in uneven pieces
+	for len(message) > 0 {
+		n := min(len(message), 1000)
+		_, err = w.Write(message[:n])
+		require.NoError(t, err)
+		message = message[n:]
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func decryptStream(group kyber.Group, private kyber.Scalar, stream []byte, opts *Options) ([]byte, error) {
+	r, err := NewDecrypter(group, private, bytes.NewReader(stream), opts)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func TestECIESStream(t *testing.T) {
+	for _, suite := range []kyber.Group{edwards25519.NewBlakeSHA256Ed25519(), p256.NewBlakeSHA256P256()} {
+		private := suite.Scalar().Pick(random.New())
+		public := suite.Point().Mul(private, nil)
+		for _, aead := range []AEAD{AES256GCM, ChaCha20Poly1305} {
+			opts := &Options{Info: []byte("info"), AssociatedData: []byte("data"), AEAD: aead}
+			for _, size := range []int{0, 1, ChunkSize - 1, ChunkSize, ChunkSize + 1, 3 * ChunkSize} {
+				message := make([]byte, size)
+				_, _ = rand.Read(message)
+				stream := encryptStream(t, suite, public, message, opts)
+				chunks := size/ChunkSize + 1
+				if size > 0 && size%ChunkSize == 0 {
+					chunks--
+				}
+				require.Equal(t, suite.PointLen()+size+16*chunks, len(stream))
+
+				plaintext, err := decryptStream(suite, private, stream, opts)
+				require.NoError(t, err)
+				require.Equal(t, message, plaintext)
+
+				_, err = decryptStream(suite, private, stream, &Options{Info: []byte("info"), AEAD: aead})
+				require.Error(t, err)
+				_, err = decryptStream(suite, private, stream[:len(stream)-1], opts)
+				require.Error(t, err)
+			}
+		}
+	}
+}
+
+func TestECIESStreamTampering(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	message := make([]byte, 3*ChunkSize+10)
+	_, _ = rand.Read(message)
+	stream := encryptStream(t, suite, public, message, nil)
+	l := suite.PointLen()
+	chunk := ChunkSize + 16
+
+	// The stream truncated at the end of a chunk
+	_, err := decryptStream(suite, private, stream[:l+2*chunk], nil)
+	require.Error(t, err)
+
+	// The chunks swapped
+	swapped := append([]byte{}, stream[:l]...)
+	swapped = append(swapped, stream[l+chunk:l+2*chunk]...)
+	swapped = append(swapped, stream[l:l+chunk]...)
+	swapped = append(swapped, stream[l+2*chunk:]...)
+	_, err = decryptStream(suite, private, swapped, nil)
+	require.Error(t, err)
+
+	// A modified chunk, whose predecessors are still returned
+	stream[l+chunk+5] ^= 1
+	r, err := NewDecrypter(suite, private, bytes.NewReader(stream), nil)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(r)
+	require.Error(t, err)
+	require.Equal(t, message[:ChunkSize], plaintext)
+
+	// A stream without its point
+	_, err = decryptStream(suite, private, stream[:l-1], nil)
+	require.Error(t, err)
+
+	// Writing after closing
+	w, err := NewEncrypter(suite, public, io.Discard, nil)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	_, err = w.Write([]byte("message"))
+	require.Error(t, err)
+}
+
+func TestECIESMulti(t *testing.T) {
+	message := []byte("Hello ECIES")
+	for _, suite := range []kyber.Group{edwards25519.NewBlakeSHA256Ed25519(), p256.NewBlakeSHA256P256()} {
+		for _, aead := range []AEAD{AES256GCM, ChaCha20Poly1305} {
+			opts := &Options{Info: []byte("info"), AssociatedData: []byte("data"), AEAD: aead}
+			privates := make([]kyber.Scalar, 5)
+			publics := make([]kyber.Point, len(privates))
+			for i := range privates {
+				privates[i] = suite.Scalar().Pick(random.New())
+				publics[i] = suite.Point().Mul(privates[i], nil)
+			}
+			ciphertext, err := EncryptMulti(suite, publics, message, opts)
+			require.NoError(t, err)
+			require.Equal(t, 4+len(publics)*(suite.PointLen()+32+16)+len(message)+16, len(ciphertext))
+
+			for i, private := range privates {
+				plaintext, err := DecryptMulti(suite, private, i, ciphertext, opts)
+				require.NoError(t, err)
+				require.Equal(t, message, plaintext)
+				plaintext, err = DecryptMultiAnonymous(suite, private, ciphertext, opts)
+				require.NoError(t, err)
+				require.Equal(t, message, plaintext)
+
+				_, err = DecryptMulti(suite, private, (i+1)%len(privates), ciphertext, opts)
+				require.Error(t, err)
+				_, err = DecryptMulti(suite, private, i, ciphertext, &Options{Info: []byte("info"), AEAD: aead})
+				require.Error(t, err)
+			}
+
+			other := suite.Scalar().Pick(random.New())
+			_, err = DecryptMultiAnonymous(suite, other, ciphertext, opts)
+			require.Error(t, err)
+			_, err = DecryptMulti(suite, privates[0], len(privates), ciphertext, opts)
+			require.Error(t, err)
+			_, err = DecryptMulti(suite, privates[0], 0, ciphertext[:4+len(publics)*(suite.PointLen()+48)-1], opts)
+			require.Error(t, err)
+
+			// The slots aren't ciphertexts of Encrypt
+			_, err = DecryptWithOptions(suite, privates[0], ciphertext[4:4+suite.PointLen()+48], opts)
+			require.Error(t, err)
+
+			ciphertext[len(ciphertext)-1] ^= 1
+			_, err = DecryptMulti(suite, privates[0], 0, ciphertext, opts)
+			require.Error(t, err)
+		}
+	}
+}
+
 func BenchmarkECIES(b *testing.B) {
 	suites := []struct {
 		kyber.Group
@@ -0,0 +1,111 @@
+package ecies
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// The ciphertexts of EncryptMulti are the 4-byte big-endian number of
+// recipients, followed by a slot per recipient and by the payload. The slot
+// of a recipient is the encryption of the data key to its public key, as
+// with Encrypt but with an HKDF salt which separates the keys from those of
+// Encrypt, and the payload is the encryption of the message with the data
+// key. As the data key is used once, the nonce of the payload is zero.
+const multiSalt = "ecies multi"
+
+// slotLen returns the length in bytes of the slots.
+func slotLen(group kyber.Group) int {
+	// Both AEADs have 16-byte tags
+	return group.PointLen() + keyLen + 16
+}
+
+// EncryptMulti encrypts the message once, under a random data key, and
+// returns a ciphertext which each recipient of the public keys decrypts with
+// DecryptMulti, with its index in the keys, or with DecryptMultiAnonymous.
+// The slots of the data key don't reveal the public keys of the recipients,
+// whose number is public. The options may be nil.
+//
+// A recipient can replace the message for the others, so the ciphertext
+// should be signed by the sender if they need to authenticate it.
+func EncryptMulti(group kyber.Group, publics []kyber.Point, message []byte, opts *Options) ([]byte, error) {
+	if uint64(len(publics)) > math.MaxUint32 {
+		return nil, errors.New("ecies: too many recipients")
+	}
+	key := random.Bits(8*keyLen, false, random.New())
+	aead, err := opts.newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := make([]byte, 4, 4+len(publics)*slotLen(group)+len(message)+aead.Overhead())
+	binary.BigEndian.PutUint32(ctx, uint32(len(publics)))
+	for _, public := range publics {
+		slot, err := encrypt(group, public, key, []byte(multiSalt), opts)
+		if err != nil {
+			return nil, err
+		}
+		ctx = append(ctx, slot...)
+	}
+	return aead.Seal(ctx, make([]byte, nonceLen), message, opts.associatedData()), nil
+}
+
+// DecryptMulti returns the message of the ciphertext of EncryptMulti for the
+// recipient of the given index, with its private key and the options of the
+// encryption.
+func DecryptMulti(group kyber.Group, private kyber.Scalar, index int, ctx []byte, opts *Options) ([]byte, error) {
+	n, err := recipients(group, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= n {
+		return nil, errors.New("ecies: recipient index out of range")
+	}
+	return openMulti(group, private, index, n, ctx, opts)
+}
+
+// DecryptMultiAnonymous is DecryptMulti for a recipient which doesn't know
+// its index, and tries each slot in turn.
+func DecryptMultiAnonymous(group kyber.Group, private kyber.Scalar, ctx []byte, opts *Options) ([]byte, error) {
+	n, err := recipients(group, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		if message, err := openMulti(group, private, i, n, ctx, opts); err == nil {
+			return message, nil
+		}
+	}
+	return nil, errors.New("ecies: no slot for the private key")
+}
+
+// recipients returns the number of recipients of the ciphertext.
+func recipients(group kyber.Group, ctx []byte) (int, error) {
+	if len(ctx) < 4 {
+		return 0, errors.New("invalid ecies cipher")
+	}
+	n := uint64(binary.BigEndian.Uint32(ctx))
+	if uint64(len(ctx)-4) < n*uint64(slotLen(group)) {
+		return 0, errors.New("invalid ecies cipher")
+	}
+	return int(n), nil
+}
+
+// openMulti decrypts the data key of the slot i of the n ones, and then the
+// payload.
+func openMulti(group kyber.Group, private kyber.Scalar, i, n int, ctx []byte, opts *Options) ([]byte, error) {
+	l := slotLen(group)
+	slot := ctx[4+i*l : 4+(i+1)*l]
+	key, err := decrypt(group, private, slot, []byte(multiSalt), opts)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := opts.newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, make([]byte, nonceLen), ctx[4+n*l:], opts.associatedData())
+}
@@ -0,0 +1,211 @@
+package ecies
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// ChunkSize is the length in bytes of the plaintext of the chunks of the
+// streams, but the last one, which is shorter or of the same length.
+const ChunkSize = 64 * 1024
+
+// The streams are the ephemeral point followed by the chunks, encrypted with
+// the STREAM construction of Hoang, Reyhanitabar, Rogaway and Vizár: the
+// nonce of a chunk is a prefix derived with the key, the 4-byte big-endian
+// index of the chunk and a last byte set to 1 for the last chunk only. The
+// chunks can thus be neither reordered nor dropped, and the stream can't be
+// truncated. The HKDF salt separates the keys from those of Encrypt.
+const (
+	noncePrefixLen = nonceLen - 5
+	streamSalt     = "ecies stream"
+)
+
+// the state of the STREAM construction common to both directions
+type stream struct {
+	aead   cipher.AEAD
+	nonce  []byte
+	ad     []byte
+	index  uint32
+	failed error
+}
+
+func newStream(opts *Options, dh kyber.Point) (*stream, error) {
+	buf, err := deriveKey(opts.hash(), dh, []byte(streamSalt), opts.info(), keyLen+noncePrefixLen)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := opts.newAEAD(buf[:keyLen])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceLen)
+	copy(nonce, buf[keyLen:])
+	return &stream{aead: aead, nonce: nonce, ad: opts.associatedData()}, nil
+}
+
+// next returns the nonce of the current chunk and moves to the next one.
+func (s *stream) next(last bool) ([]byte, error) {
+	if s.index == math.MaxUint32 && !last {
+		return nil, errors.New("ecies: too many chunks")
+	}
+	binary.BigEndian.PutUint32(s.nonce[noncePrefixLen:], s.index)
+	s.nonce[nonceLen-1] = 0
+	if last {
+		s.nonce[nonceLen-1] = 1
+	}
+	s.index++
+	return s.nonce, nil
+}
+
+type encrypter struct {
+	*stream
+	w      io.Writer
+	buf    []byte
+	closed bool
+}
+
+// NewEncrypter returns a writer which encrypts to the public key the data
+// written to it, and writes the stream to w. The options may be nil. The
+// writer must be closed to write the last chunk, but doesn't close w.
+func NewEncrypter(group kyber.Group, public kyber.Point, w io.Writer, opts *Options) (io.WriteCloser, error) {
+	r := group.Scalar().Pick(random.New())
+	R := group.Point().Mul(r, nil)
+	s, err := newStream(opts, group.Point().Mul(r, public))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := R.MarshalTo(w); err != nil {
+		return nil, err
+	}
+	return &encrypter{stream: s, w: w, buf: make([]byte, 0, ChunkSize+s.aead.Overhead())}, nil
+}
+
+// Write encrypts p. The chunks are written once it is known that they are
+// not the last one.
+func (e *encrypter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("ecies: write to closed encrypter")
+	}
+	if e.failed != nil {
+		return 0, e.failed
+	}
+	n := 0
+	for len(p) > 0 {
+		if len(e.buf) == ChunkSize {
+			if err := e.flush(false); err != nil {
+				return n, err
+			}
+		}
+		m := min(len(p), ChunkSize-len(e.buf))
+		e.buf = append(e.buf, p[:m]...)
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// Close writes the last chunk.
+func (e *encrypter) Close() error {
+	if e.closed {
+		return nil
+	}
+	if e.failed != nil {
+		return e.failed
+	}
+	e.closed = true
+	return e.flush(true)
+}
+
+func (e *encrypter) flush(last bool) error {
+	nonce, err := e.next(last)
+	if err == nil {
+		_, err = e.w.Write(e.aead.Seal(e.buf[:0], nonce, e.buf, e.ad))
+	}
+	e.buf = e.buf[:0]
+	e.failed = err
+	return err
+}
+
+type decrypter struct {
+	*stream
+	r   *bufio.Reader
+	buf []byte
+	// plaintext of the current chunk not read yet
+	pt   []byte
+	done bool
+}
+
+// NewDecrypter returns a reader of the data of the stream of r, decrypted
+// with the private key and the options of the encryption. The data of a
+// chunk is only returned once the chunk is authenticated, and the reader
+// returns an error rather than io.EOF if the stream is truncated.
+func NewDecrypter(group kyber.Group, private kyber.Scalar, r io.Reader, opts *Options) (io.Reader, error) {
+	buf := make([]byte, group.PointLen())
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, errors.New("invalid ecies stream")
+	}
+	R := group.Point()
+	if err := R.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	s, err := newStream(opts, group.Point().Mul(private, R))
+	if err != nil {
+		return nil, err
+	}
+	return &decrypter{stream: s, r: bufio.NewReader(r), buf: make([]byte, ChunkSize+s.aead.Overhead())}, nil
+}
+
+func (d *decrypter) Read(p []byte) (int, error) {
+	for len(d.pt) == 0 {
+		if d.failed != nil {
+			return 0, d.failed
+		}
+		if d.done {
+			return 0, io.EOF
+		}
+		d.failed = d.readChunk()
+	}
+	n := copy(p, d.pt)
+	d.pt = d.pt[n:]
+	return n, nil
+}
+
+// readChunk decrypts the next chunk, which is the last one if it is shorter
+// than a full chunk or if it ends the stream.
+func (d *decrypter) readChunk() error {
+	n, err := io.ReadFull(d.r, d.buf)
+	last := false
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := d.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if n < d.aead.Overhead() {
+		return errors.New("ecies: truncated stream")
+	}
+	nonce, err := d.next(last)
+	if err != nil {
+		return err
+	}
+	pt, err := d.aead.Open(d.buf[:0], nonce, d.buf[:n], d.ad)
+	if err != nil {
+		return errors.New("ecies: invalid chunk")
+	}
+	d.pt = pt
+	d.done = last
+	return nil
+}
@@ -0,0 +1,230 @@
+// Package elgamal implements the ElGamal encryption of group elements, and of
+// byte strings embedded in group elements, with threshold decryption by the
+// holders of the shares of a distributed key, e.g. generated by the
+// share/dkg/pedersen package.
+//
+// Each holder publishes a partial decryption of a ciphertext with its share,
+// along with a proof/dleq proof that it used the share matching its public
+// share. Any t valid partial decryptions are combined by Lagrange
+// interpolation into the plaintext, and the invalid ones are discarded.
+package elgamal
+
+import (
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/proof/dleq"
+	"go.dedis.ch/kyber/v4/share"
+)
+
+// Suite is the set of functionalities needed by the package, which are those
+// of the proofs of the proof/dleq package.
+type Suite interface {
+	dleq.Suite
+}
+
+// DistKeyShare is the share of a distributed key, such as the
+// DistKeyShare of share/dkg/pedersen.
+type DistKeyShare interface {
+	PriShare() *share.PriShare
+	Commitments() []kyber.Point
+}
+
+// ErrInvalidPartial is returned for the partial decryptions whose proof
+// doesn't verify.
+var ErrInvalidPartial = errors.New("elgamal: invalid partial decryption")
+
+// Ciphertext is the ElGamal encryption (K, C) = (k*G, M + k*X) of the point
+// M to the public key X.
+type Ciphertext struct {
+	K kyber.Point
+	C kyber.Point
+}
+
+// Encrypt returns the encryption of the point M to the public key.
+func Encrypt(suite Suite, public, M kyber.Point) *Ciphertext {
+	k := suite.Scalar().Pick(suite.RandomStream())
+	S := suite.Point().Mul(k, public)
+	return &Ciphertext{K: suite.Point().Mul(k, nil), C: S.Add(S, M)}
+}
+
+// Decrypt returns the point encrypted in the ciphertext, with the private
+// key.
+func Decrypt(suite Suite, private kyber.Scalar, ct *Ciphertext) kyber.Point {
+	S := suite.Point().Mul(private, ct.K)
+	return S.Sub(ct.C, S)
+}
+
+// EncryptBytes returns the encryptions of the points embedding the
+// successive chunks of the message, of at most EmbedLen bytes each. An empty
+// message is embedded in a single point.
+func EncryptBytes(suite Suite, public kyber.Point, msg []byte) ([]*Ciphertext, error) {
+	size := suite.Point().EmbedLen()
+	if size <= 0 {
+		return nil, errors.New("elgamal: the group can't embed data in points")
+	}
+	cts := make([]*Ciphertext, 0, len(msg)/size+1)
+	for len(cts) == 0 || len(msg) > 0 {
+		chunk := msg[:min(size, len(msg))]
+		msg = msg[len(chunk):]
+		M := suite.Point().Embed(chunk, suite.RandomStream())
+		cts = append(cts, Encrypt(suite, public, M))
+	}
+	return cts, nil
+}
+
+// DecryptBytes returns the message encrypted by EncryptBytes, with the
+// private key.
+func DecryptBytes(suite Suite, private kyber.Scalar, cts []*Ciphertext) ([]byte, error) {
+	points := make([]kyber.Point, len(cts))
+	for i, ct := range cts {
+		points[i] = Decrypt(suite, private, ct)
+	}
+	return Data(points)
+}
+
+// Data returns the concatenation of the data embedded in the points, e.g. the
+// message of the points decrypted by CombineBytes.
+func Data(points []kyber.Point) ([]byte, error) {
+	var msg []byte
+	for _, M := range points {
+		data, err := M.Data()
+		if err != nil {
+			return nil, fmt.Errorf("elgamal: extracting data: %w", err)
+		}
+		msg = append(msg, data...)
+	}
+	return msg, nil
+}
+
+// PartialDecryption is the partial decryption D = x_i*K of a ciphertext by
+// the holder of the share x_i of index I, with the proof that D and the
+// public share x_i*G have the same discrete logarithm.
+type PartialDecryption struct {
+	I     uint32
+	D     kyber.Point
+	Proof *dleq.Proof
+}
+
+// PartialDecrypt returns the partial decryption of the ciphertext with the
+// share of the distributed key.
+func PartialDecrypt(suite Suite, key DistKeyShare, ct *Ciphertext) (*PartialDecryption, error) {
+	s := key.PriShare()
+	proof, _, D, err := dleq.NewDLEQProof(suite, suite.Point().Base(), ct.K, s.V)
+	if err != nil {
+		return nil, err
+	}
+	return &PartialDecryption{I: s.I, D: D, Proof: proof}, nil
+}
+
+// VerifyPartial checks the partial decryption of the ciphertext against the
+// public polynomial of the distributed key, e.g. built from the Commits of a
+// DistKeyShare with share.NewPubPoly.
+func VerifyPartial(suite Suite, public *share.PubPoly, ct *Ciphertext, pd *PartialDecryption) error {
+	if pd == nil || pd.D == nil || pd.Proof == nil || pd.Proof.C == nil || pd.Proof.R == nil ||
+		pd.Proof.VG == nil || pd.Proof.VH == nil {
+		return ErrInvalidPartial
+	}
+	X := public.Eval(pd.I).V
+
+	// The challenge of dleq.NewDLEQProof, which Proof.Verify takes as is
+	h := suite.Hash()
+	for _, P := range []kyber.Point{X, pd.D, pd.Proof.VG, pd.Proof.VH} {
+		if _, err := P.MarshalTo(h); err != nil {
+			return err
+		}
+	}
+	if !pd.Proof.C.Equal(suite.Scalar().Pick(suite.XOF(h.Sum(nil)))) {
+		return ErrInvalidPartial
+	}
+	if err := pd.Proof.Verify(suite, suite.Point().Base(), ct.K, X, pd.D); err != nil {
+		return ErrInvalidPartial
+	}
+	return nil
+}
+
+// Combine returns the point encrypted in the ciphertext, interpolated from
+// the valid partial decryptions, of which there must be at least the
+// threshold of the public polynomial. The invalid partial decryptions are
+// discarded.
+func Combine(suite Suite, public *share.PubPoly, ct *Ciphertext, partials []*PartialDecryption) (kyber.Point, error) {
+	shares := make([]*share.PubShare, 0, len(partials))
+	seen := make(map[uint32]bool)
+	for _, pd := range partials {
+		if VerifyPartial(suite, public, ct, pd) != nil || seen[pd.I] {
+			continue
+		}
+		seen[pd.I] = true
+		shares = append(shares, &share.PubShare{I: pd.I, V: pd.D})
+	}
+	return interpolate(suite, public, ct, shares, len(partials))
+}
+
+// interpolate returns the point encrypted in the ciphertext from the shares
+// x_i*K of the valid partial decryptions, out of total.
+func interpolate(suite Suite, public *share.PubPoly, ct *Ciphertext, shares []*share.PubShare, total int) (
+	kyber.Point, error) {
+	t := public.Threshold()
+	if len(shares) < t {
+		return nil, fmt.Errorf("elgamal: %d valid partial decryptions out of %d, %d needed",
+			len(shares), total, t)
+	}
+	S, err := share.RecoverCommit(suite, shares, t, len(shares))
+	if err != nil {
+		return nil, err
+	}
+	return S.Sub(ct.C, S), nil
+}
+
+// PartialDecryptBytes returns the partial decryptions of the ciphertexts of
+// EncryptBytes with the share of the distributed key.
+func PartialDecryptBytes(suite Suite, key DistKeyShare, cts []*Ciphertext) ([]*PartialDecryption, error) {
+	partials := make([]*PartialDecryption, len(cts))
+	for i, ct := range cts {
+		var err error
+		if partials[i], err = PartialDecrypt(suite, key, ct); err != nil {
+			return nil, err
+		}
+	}
+	return partials, nil
+}
+
+// CombineBytes returns the message encrypted by EncryptBytes, from the
+// partial decryptions of PartialDecryptBytes of the holders of the shares.
+// The partial decryptions of a holder are discarded if one of them is
+// invalid.
+func CombineBytes(suite Suite, public *share.PubPoly, cts []*Ciphertext,
+	partials [][]*PartialDecryption) ([]byte, error) {
+	valid := make([][]*PartialDecryption, 0, len(partials))
+	seen := make(map[uint32]bool)
+	for _, pds := range partials {
+		if len(pds) != len(cts) || len(pds) == 0 || pds[0] == nil || seen[pds[0].I] {
+			continue
+		}
+		ok := true
+		for i, pd := range pds {
+			if VerifyPartial(suite, public, cts[i], pd) != nil || pd.I != pds[0].I {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			seen[pds[0].I] = true
+			valid = append(valid, pds)
+		}
+	}
+
+	points := make([]kyber.Point, len(cts))
+	for i, ct := range cts {
+		shares := make([]*share.PubShare, len(valid))
+		for j, pds := range valid {
+			shares[j] = &share.PubShare{I: pds[i].I, V: pds[i].D}
+		}
+		var err error
+		if points[i], err = interpolate(suite, public, ct, shares, len(partials)); err != nil {
+			return nil, err
+		}
+	}
+	return Data(points)
+}
@@ -0,0 +1,161 @@
+package elgamal
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/share"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+// runDKG returns the key shares of a distributed key generation of n nodes
+// with threshold thr.
+func runDKG(t *testing.T, n, thr int) []*dkg.DistKeyShare {
+	privates := make([]kyber.Scalar, n)
+	nodes := make([]dkg.Node, n)
+	for i := range nodes {
+		privates[i] = suite.Scalar().Pick(random.New())
+		nodes[i] = dkg.Node{Index: uint32(i), Public: suite.Point().Mul(privates[i], nil)}
+	}
+	nonce := dkg.GetNonce()
+	gens := make([]*dkg.DistKeyGenerator, n)
+	var deals []*dkg.DealBundle
+	for i := range gens {
+		var err error
+		gens[i], err = dkg.NewDistKeyHandler(&dkg.Config{
+			Suite:     suite,
+			Longterm:  privates[i],
+			NewNodes:  nodes,
+			Threshold: thr,
+			Nonce:     nonce,
+			Auth:      schnorr.NewScheme(suite),
+		})
+		require.NoError(t, err)
+		deal, err := gens[i].Deals()
+		require.NoError(t, err)
+		deals = append(deals, deal)
+	}
+	var responses []*dkg.ResponseBundle
+	for _, gen := range gens {
+		resp, err := gen.ProcessDeals(deals)
+		require.NoError(t, err)
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	shares := make([]*dkg.DistKeyShare, n)
+	for _, gen := range gens {
+		res, _, err := gen.ProcessResponses(responses)
+		require.NoError(t, err)
+		shares[res.Key.Share.I] = res.Key
+	}
+	return shares
+}
+
+func TestEncrypt(t *testing.T) {
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	M := suite.Point().Pick(random.New())
+	ct := Encrypt(suite, public, M)
+	require.True(t, M.Equal(Decrypt(suite, private, ct)))
+	require.False(t, M.Equal(Decrypt(suite, suite.Scalar().Pick(random.New()), ct)))
+}
+
+func TestEncryptBytes(t *testing.T) {
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	size := suite.Point().EmbedLen()
+	for _, n := range []int{0, 1, size, size + 1, 5*size - 1} {
+		msg := make([]byte, n)
+		random.Bytes(msg, random.New())
+		cts, err := EncryptBytes(suite, public, msg)
+		require.NoError(t, err)
+		require.Len(t, cts, max(1, (n+size-1)/size))
+		decrypted, err := DecryptBytes(suite, private, cts)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(msg, decrypted))
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	n, thr := 5, 3
+	keys := runDKG(t, n, thr)
+	public := share.NewPubPoly(suite, nil, keys[0].Commits)
+	M := suite.Point().Pick(random.New())
+	ct := Encrypt(suite, keys[0].Public(), M)
+
+	partials := make([]*PartialDecryption, n)
+	for i, key := range keys {
+		var err error
+		partials[i], err = PartialDecrypt(suite, key, ct)
+		require.NoError(t, err)
+		require.NoError(t, VerifyPartial(suite, public, ct, partials[i]))
+	}
+
+	// any t partial decryptions are enough
+	decrypted, err := Combine(suite, public, ct, partials[2:])
+	require.NoError(t, err)
+	require.True(t, M.Equal(decrypted))
+	decrypted, err = Combine(suite, public, ct, []*PartialDecryption{partials[4], partials[0], partials[2]})
+	require.NoError(t, err)
+	require.True(t, M.Equal(decrypted))
+
+	// but not fewer, nor duplicates
+	_, err = Combine(suite, public, ct, partials[3:])
+	require.Error(t, err)
+	_, err = Combine(suite, public, ct, []*PartialDecryption{partials[0], partials[0], partials[1]})
+	require.Error(t, err)
+
+	// an invalid partial decryption is detected and discarded
+	bad := *partials[1]
+	bad.D = suite.Point().Pick(random.New())
+	require.ErrorIs(t, VerifyPartial(suite, public, ct, &bad), ErrInvalidPartial)
+	decrypted, err = Combine(suite, public, ct, []*PartialDecryption{partials[0], &bad, partials[2], partials[3]})
+	require.NoError(t, err)
+	require.True(t, M.Equal(decrypted))
+	_, err = Combine(suite, public, ct, []*PartialDecryption{partials[0], &bad, partials[2]})
+	require.Error(t, err)
+
+	// as well as the partial decryption of another ciphertext or share
+	other, err := PartialDecrypt(suite, keys[1], Encrypt(suite, keys[0].Public(), M))
+	require.NoError(t, err)
+	require.ErrorIs(t, VerifyPartial(suite, public, ct, other), ErrInvalidPartial)
+	moved := *partials[1]
+	moved.I = 2
+	require.ErrorIs(t, VerifyPartial(suite, public, ct, &moved), ErrInvalidPartial)
+	require.ErrorIs(t, VerifyPartial(suite, public, ct, &PartialDecryption{}), ErrInvalidPartial)
+}
+
+func TestThresholdBytes(t *testing.T) {
+	n, thr := 4, 3
+	keys := runDKG(t, n, thr)
+	public := share.NewPubPoly(suite, nil, keys[0].Commits)
+	msg := []byte("The quick brown fox jumps over the lazy dog, twice or more")
+	cts, err := EncryptBytes(suite, keys[0].Public(), msg)
+	require.NoError(t, err)
+	require.Greater(t, len(cts), 1)
+
+	partials := make([][]*PartialDecryption, n)
+	for i, key := range keys {
+		partials[i], err = PartialDecryptBytes(suite, key, cts)
+		require.NoError(t, err)
+	}
+	decrypted, err := CombineBytes(suite, public, cts, partials[1:])
+	require.NoError(t, err)
+	require.Equal(t, msg, decrypted)
+
+	// a holder with an invalid partial decryption is discarded entirely
+	partials[1][1] = partials[2][1]
+	decrypted, err = CombineBytes(suite, public, cts, partials)
+	require.NoError(t, err)
+	require.Equal(t, msg, decrypted)
+	_, err = CombineBytes(suite, public, cts, partials[1:])
+	require.Error(t, err)
+}
@@ -0,0 +1,362 @@
+// Package hpke implements the Hybrid Public Key Encryption of RFC 9180, with
+// the Base, PSK, Auth and AuthPSK modes and the secret export interface.
+//
+// The KEMs are the DHKEMs over P-256, on the group of the p256 package, and
+// over X25519, on the birationally equivalent group of the edwards25519
+// package, so that the keys are kyber scalars and points. The KDFs are
+// HKDF-SHA256 and HKDF-SHA512, and the AEADs are AES-128-GCM, AES-256-GCM
+// and ChaCha20-Poly1305. Unlike the ecies package, the ciphertexts can be
+// decrypted by any other implementation of the RFC, and conversely.
+package hpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"math"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// KDFID identifies a key derivation function.
+type KDFID uint16
+
+// The KDFs of RFC 9180, section 7.2.
+const (
+	HKDFSHA256 KDFID = 0x0001
+	HKDFSHA512 KDFID = 0x0003
+)
+
+// AEADID identifies an authenticated encryption scheme.
+type AEADID uint16
+
+// The AEADs of RFC 9180, section 7.3. With ExportOnly, the contexts can only
+// export secrets.
+const (
+	AES128GCM        AEADID = 0x0001
+	AES256GCM        AEADID = 0x0002
+	ChaCha20Poly1305 AEADID = 0x0003
+	ExportOnly       AEADID = 0xffff
+)
+
+// The modes of RFC 9180, section 5.
+const (
+	modeBase    byte = 0x00
+	modePSK     byte = 0x01
+	modeAuth    byte = 0x02
+	modeAuthPSK byte = 0x03
+)
+
+var (
+	// ErrOpen is returned when a ciphertext can't be decrypted.
+	ErrOpen = errors.New("hpke: decryption failed")
+	// ErrMessageLimit is returned when the sequence number of a context
+	// overflows.
+	ErrMessageLimit = errors.New("hpke: message limit reached")
+	// ErrInvalidPSK is returned when only one of the pre-shared key and its
+	// identifier is given.
+	ErrInvalidPSK = errors.New("hpke: inconsistent PSK inputs")
+)
+
+// kdf implements the labeled HKDF functions of RFC 9180, section 4.
+type kdf struct {
+	newHash func() hash.Hash
+	suiteID []byte
+}
+
+func (k *kdf) labeledExtract(salt []byte, label string, ikm []byte) []byte {
+	labeled := append(append(append([]byte("HPKE-v1"), k.suiteID...), label...), ikm...)
+	return hkdf.Extract(k.newHash, labeled, salt)
+}
+
+func (k *kdf) labeledExpand(prk []byte, label string, info []byte, length int) []byte {
+	labeled := append([]byte{byte(length >> 8), byte(length)}, "HPKE-v1"...)
+	labeled = append(append(append(labeled, k.suiteID...), label...), info...)
+	out := make([]byte, length)
+	if _, err := hkdf.Expand(k.newHash, prk, labeled).Read(out); err != nil {
+		// The lengths are checked by the callers
+		panic("hpke: " + err.Error())
+	}
+	return out
+}
+
+// Suite is an HPKE ciphersuite: a KEM, a KDF and an AEAD.
+type Suite struct {
+	kem  *dhkem
+	aead AEADID
+	kdf
+}
+
+// NewSuite returns the ciphersuite of the KEM, KDF and AEAD.
+func NewSuite(kemID KEMID, kdfID KDFID, aeadID AEADID) (*Suite, error) {
+	kem, err := newDHKEM(kemID)
+	if err != nil {
+		return nil, err
+	}
+	s := &Suite{kem: kem, aead: aeadID}
+	switch kdfID {
+	case HKDFSHA256:
+		s.newHash = sha256.New
+	case HKDFSHA512:
+		s.newHash = sha512.New
+	default:
+		return nil, errors.New("hpke: unknown KDF")
+	}
+	switch aeadID {
+	case AES128GCM, AES256GCM, ChaCha20Poly1305, ExportOnly:
+	default:
+		return nil, errors.New("hpke: unknown AEAD")
+	}
+	s.suiteID = []byte{'H', 'P', 'K', 'E', byte(kemID >> 8), byte(kemID), byte(kdfID >> 8), byte(kdfID),
+		byte(aeadID >> 8), byte(aeadID)}
+	return s, nil
+}
+
+// Group returns the group of the keys of the KEM.
+func (s *Suite) Group() kyber.Group {
+	return s.kem.g.group()
+}
+
+// GenerateKeyPair returns a random key pair of the KEM.
+func (s *Suite) GenerateKeyPair() (kyber.Scalar, kyber.Point, error) {
+	return s.kem.deriveKeyPair(s.randomIKM())
+}
+
+// DeriveKeyPair returns the key pair of the KEM derived from the input
+// keying material, which should have at least as many bytes as the private
+// keys.
+func (s *Suite) DeriveKeyPair(ikm []byte) (kyber.Scalar, kyber.Point, error) {
+	return s.kem.deriveKeyPair(ikm)
+}
+
+// SerializePublicKey returns the encoding of the public key of the RFC: the
+// uncompressed point for P-256 and the u-coordinate for X25519.
+func (s *Suite) SerializePublicKey(public kyber.Point) ([]byte, error) {
+	return s.kem.g.serializePublicKey(public)
+}
+
+// DeserializePublicKey decodes a public key encoded by SerializePublicKey.
+// For X25519, the point is only determined up to its sign, on which the
+// shared secrets don't depend.
+func (s *Suite) DeserializePublicKey(buf []byte) (kyber.Point, error) {
+	return s.kem.g.deserializePublicKey(buf)
+}
+
+// DeserializePrivateKey decodes a private key in the encoding of the RFC:
+// the big-endian scalar for P-256 and the X25519 secret key, which is
+// clamped, for X25519.
+func (s *Suite) DeserializePrivateKey(buf []byte) (kyber.Scalar, error) {
+	return s.kem.g.deserializePrivateKey(buf)
+}
+
+func (s *Suite) randomIKM() []byte {
+	return random.Bits(uint(8*s.kem.g.nsk()), false, random.New())
+}
+
+// keySize returns the length Nk of the keys and Nn of the nonces of the AEAD.
+func (s *Suite) keySize() (int, int) {
+	switch s.aead {
+	case AES128GCM:
+		return 16, 12
+	case AES256GCM, ChaCha20Poly1305:
+		return 32, 12
+	default:
+		return 0, 0
+	}
+}
+
+// SetupBaseS returns the encapsulated key to send to the receiver with the
+// public key, and the sender context of the Base mode.
+func (s *Suite) SetupBaseS(pkR kyber.Point, info []byte) ([]byte, *Context, error) {
+	return s.setupS(s.randomIKM(), modeBase, pkR, info, nil, nil, nil)
+}
+
+// SetupBaseR returns the receiver context of the Base mode for the
+// encapsulated key.
+func (s *Suite) SetupBaseR(enc []byte, skR kyber.Scalar, info []byte) (*Context, error) {
+	return s.setupR(modeBase, enc, skR, info, nil, nil, nil)
+}
+
+// SetupPSKS is SetupBaseS in the PSK mode, where the sender and the receiver
+// share the key psk of identifier pskID.
+func (s *Suite) SetupPSKS(pkR kyber.Point, info, psk, pskID []byte) ([]byte, *Context, error) {
+	return s.setupS(s.randomIKM(), modePSK, pkR, info, psk, pskID, nil)
+}
+
+// SetupPSKR is SetupBaseR in the PSK mode.
+func (s *Suite) SetupPSKR(enc []byte, skR kyber.Scalar, info, psk, pskID []byte) (*Context, error) {
+	return s.setupR(modePSK, enc, skR, info, psk, pskID, nil)
+}
+
+// SetupAuthS is SetupBaseS in the Auth mode, where the sender authenticates
+// with its private key skS.
+func (s *Suite) SetupAuthS(pkR kyber.Point, info []byte, skS kyber.Scalar) ([]byte, *Context, error) {
+	return s.setupS(s.randomIKM(), modeAuth, pkR, info, nil, nil, skS)
+}
+
+// SetupAuthR is SetupBaseR in the Auth mode, for the sender of public key
+// pkS.
+func (s *Suite) SetupAuthR(enc []byte, skR kyber.Scalar, info []byte, pkS kyber.Point) (*Context, error) {
+	return s.setupR(modeAuth, enc, skR, info, nil, nil, pkS)
+}
+
+// SetupAuthPSKS is SetupBaseS in the AuthPSK mode, which combines the PSK
+// and Auth modes.
+func (s *Suite) SetupAuthPSKS(pkR kyber.Point, info, psk, pskID []byte, skS kyber.Scalar) (
+	[]byte, *Context, error) {
+	return s.setupS(s.randomIKM(), modeAuthPSK, pkR, info, psk, pskID, skS)
+}
+
+// SetupAuthPSKR is SetupBaseR in the AuthPSK mode.
+func (s *Suite) SetupAuthPSKR(enc []byte, skR kyber.Scalar, info, psk, pskID []byte, pkS kyber.Point) (
+	*Context, error) {
+	return s.setupR(modeAuthPSK, enc, skR, info, psk, pskID, pkS)
+}
+
+// Seal encrypts the plaintext to the public key in the Base mode, and
+// returns the encapsulated key and the ciphertext.
+func (s *Suite) Seal(pkR kyber.Point, info, aad, pt []byte) ([]byte, []byte, error) {
+	enc, ctx, err := s.SetupBaseS(pkR, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	ct, err := ctx.Seal(aad, pt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return enc, ct, nil
+}
+
+// Open decrypts the ciphertext of Seal with the private key.
+func (s *Suite) Open(enc []byte, skR kyber.Scalar, info, aad, ct []byte) ([]byte, error) {
+	ctx, err := s.SetupBaseR(enc, skR, info)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Open(aad, ct)
+}
+
+func (s *Suite) setupS(ikmE []byte, mode byte, pkR kyber.Point, info, psk, pskID []byte, skS kyber.Scalar) (
+	[]byte, *Context, error) {
+	sharedSecret, enc, err := s.kem.encap(ikmE, pkR, skS)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err := s.keySchedule(mode, sharedSecret, info, psk, pskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx.sender = true
+	return enc, ctx, nil
+}
+
+func (s *Suite) setupR(mode byte, enc []byte, skR kyber.Scalar, info, psk, pskID []byte, pkS kyber.Point) (
+	*Context, error) {
+	sharedSecret, err := s.kem.decap(enc, skR, pkS)
+	if err != nil {
+		return nil, err
+	}
+	return s.keySchedule(mode, sharedSecret, info, psk, pskID)
+}
+
+// keySchedule implements KeySchedule of RFC 9180, section 5.1.
+func (s *Suite) keySchedule(mode byte, sharedSecret, info, psk, pskID []byte) (*Context, error) {
+	if (len(psk) == 0) != (len(pskID) == 0) {
+		return nil, ErrInvalidPSK
+	}
+	if (len(psk) != 0) != (mode == modePSK || mode == modeAuthPSK) {
+		return nil, ErrInvalidPSK
+	}
+
+	context := []byte{mode}
+	context = append(context, s.labeledExtract(nil, "psk_id_hash", pskID)...)
+	context = append(context, s.labeledExtract(nil, "info_hash", info)...)
+	secret := s.labeledExtract(sharedSecret, "secret", psk)
+
+	ctx := &Context{suite: s, exporterSecret: s.labeledExpand(secret, "exp", context, s.newHash().Size())}
+	nk, nn := s.keySize()
+	if nk == 0 {
+		return ctx, nil
+	}
+	key := s.labeledExpand(secret, "key", context, nk)
+	ctx.baseNonce = s.labeledExpand(secret, "base_nonce", context, nn)
+	var err error
+	if s.aead == ChaCha20Poly1305 {
+		ctx.aead, err = chacha20poly1305.New(key)
+	} else {
+		var block cipher.Block
+		if block, err = aes.NewCipher(key); err == nil {
+			ctx.aead, err = cipher.NewGCM(block)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// Context is the encryption context of a sender or of a receiver, which
+// encrypts or decrypts a sequence of messages, and exports secrets.
+type Context struct {
+	suite          *Suite
+	sender         bool
+	aead           cipher.AEAD
+	baseNonce      []byte
+	seq            uint64
+	exporterSecret []byte
+}
+
+// nonce returns the nonce of the current sequence number.
+func (c *Context) nonce() []byte {
+	nonce := append([]byte{}, c.baseNonce...)
+	for i := 0; i < 8; i++ {
+		nonce[len(nonce)-1-i] ^= byte(c.seq >> (8 * i))
+	}
+	return nonce
+}
+
+// Seal encrypts the next message of a sender context with the additional
+// data.
+func (c *Context) Seal(aad, pt []byte) ([]byte, error) {
+	if !c.sender || c.aead == nil {
+		return nil, errors.New("hpke: context can't seal")
+	}
+	if c.seq == math.MaxUint64 {
+		return nil, ErrMessageLimit
+	}
+	ct := c.aead.Seal(nil, c.nonce(), pt, aad)
+	c.seq++
+	return ct, nil
+}
+
+// Open decrypts the next message of a receiver context with the additional
+// data.
+func (c *Context) Open(aad, ct []byte) ([]byte, error) {
+	if c.sender || c.aead == nil {
+		return nil, errors.New("hpke: context can't open")
+	}
+	if c.seq == math.MaxUint64 {
+		return nil, ErrMessageLimit
+	}
+	pt, err := c.aead.Open(nil, c.nonce(), ct, aad)
+	if err != nil {
+		return nil, ErrOpen
+	}
+	c.seq++
+	return pt, nil
+}
+
+// Export returns a secret of the given length for the exporter context,
+// which both the sender and the receiver derive.
+func (c *Context) Export(exporterContext []byte, length int) ([]byte, error) {
+	if length < 0 || length > 255*c.suite.newHash().Size() {
+		return nil, errors.New("hpke: invalid export length")
+	}
+	return c.suite.labeledExpand(c.exporterSecret, "sec", exporterContext, length), nil
+}
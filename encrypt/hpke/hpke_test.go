@@ -0,0 +1,760 @@
+package hpke
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// The inputs common to the test vectors of RFC 9180, appendix A
+const (
+	vectorInfo  = "4f6465206f6e2061204772656369616e2055726e"
+	vectorAAD   = "436f756e742d30"
+	vectorPT    = "4265617574792069732074727574682c20747275746820626561757479"
+	vectorPSK   = "0247fd33b913760fa1fa51e1892d9f307fbe65eb171e8132c2af18555a738b82"
+	vectorPSKID = "456e6e796e20447572696e206172616e204d6f726961"
+)
+
+type vector struct {
+	name             string
+	kem              KEMID
+	kdf              KDFID
+	aead             AEADID
+	mode             byte
+	ikmE, ikmR, ikmS string
+	pkRm             string
+	enc              string
+	exporterSecret   string
+	// the ciphertext of the first message, absent with ExportOnly
+	ct string
+	// the exports of length 32 for the contexts "", "00" and "TestContext"
+	exports []string
+}
+
+// The vectors of appendix A with the KEMs of this package, which exclude the
+// P-521 ones of appendix A.6, as in
+// https://github.com/cfrg/draft-irtf-cfrg-hpke/blob/5f503c5/test-vectors.json
+var vectors = []vector{
+	{
+		name:           "A.1 X25519, HKDF-SHA256, AES-128-GCM, Base",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           AES128GCM,
+		mode:           modeBase,
+		ikmE:           "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+		ikmR:           "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+		pkRm:           "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d",
+		enc:            "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+		exporterSecret: "45ff1c2e220db587171952c0592d5f5ebe103f1561a2614e38f2ffd47e99e3f8",
+		ct:             "f938558b5d72f1a23810b4be2ab4f84331acc02fc97babc53a52ae8218a355a96d8770ac83d07bea87e13c512a",
+		exports: []string{
+			"3853fe2b4035195a573ffc53856e77058e15d9ea064de3e59f4961d0095250ee",
+			"2e8f0b54673c7029649d4eb9d5e33bf1872cf76d623ff164ac185da9e88c21a5",
+			"e9e43065102c3836401bed8c3c3c75ae46be1639869391d62c61f1ec7af54931",
+		},
+	},
+	{
+		name:           "A.1 X25519, HKDF-SHA256, AES-128-GCM, PSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           AES128GCM,
+		mode:           modePSK,
+		ikmE:           "78628c354e46f3e169bd231be7b2ff1c77aa302460a26dbfa15515684c00130b",
+		ikmR:           "d4a09d09f575fef425905d2ab396c1449141463f698f8efdb7accfaff8995098",
+		pkRm:           "9fed7e8c17387560e92cc6462a68049657246a09bfa8ade7aefe589672016366",
+		enc:            "0ad0950d9fb9588e59690b74f1237ecdf1d775cd60be2eca57af5a4b0471c91b",
+		exporterSecret: "3d76025dbbedc49448ec3f9080a1abab6b06e91c0b11ad23c912f043a0ee7655",
+		ct:             "e52c6fed7f758d0cf7145689f21bc1be6ec9ea097fef4e959440012f4feb73fb611b946199e681f4cfc34db8ea",
+		exports: []string{
+			"dff17af354c8b41673567db6259fd6029967b4e1aad13023c2ae5df8f4f43bf6",
+			"6a847261d8207fe596befb52928463881ab493da345b10e1dcc645e3b94e2d95",
+			"8aff52b45a1be3a734bc7a41e20b4e055ad4c4d22104b0c20285a7c4302401cd",
+		},
+	},
+	{
+		name:           "A.1 X25519, HKDF-SHA256, AES-128-GCM, Auth",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           AES128GCM,
+		mode:           modeAuth,
+		ikmE:           "6e6d8f200ea2fb20c30b003a8b4f433d2f4ed4c2658d5bc8ce2fef718059c9f7",
+		ikmR:           "f1d4a30a4cef8d6d4e3b016e6fd3799ea057db4f345472ed302a67ce1c20cdec",
+		ikmS:           "94b020ce91d73fca4649006c7e7329a67b40c55e9e93cc907d282bbbff386f58",
+		pkRm:           "1632d5c2f71c2b38d0a8fcc359355200caa8b1ffdf28618080466c909cb69b2e",
+		enc:            "23fb952571a14a25e3d678140cd0e5eb47a0961bb18afcf85896e5453c312e76",
+		exporterSecret: "ee1a093e6e1c393c162ea98fdf20560c75909653550540a2700511b65c88c6f1",
+		ct:             "5fd92cc9d46dbf8943e72a07e42f363ed5f721212cd90bcfd072bfd9f44e06b80fd17824947496e21b680c141b",
+		exports: []string{
+			"28c70088017d70c896a8420f04702c5a321d9cbf0279fba899b59e51bac72c85",
+			"25dfc004b0892be1888c3914977aa9c9bbaf2c7471708a49e1195af48a6f29ce",
+			"5a0131813abc9a522cad678eb6bafaabc43389934adb8097d23c5ff68059eb64",
+		},
+	},
+	{
+		name:           "A.1 X25519, HKDF-SHA256, AES-128-GCM, AuthPSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           AES128GCM,
+		mode:           modeAuthPSK,
+		ikmE:           "4303619085a20ebcf18edd22782952b8a7161e1dbae6e46e143a52a96127cf84",
+		ikmR:           "4b16221f3b269a88e207270b5e1de28cb01f847841b344b8314d6a622fe5ee90",
+		ikmS:           "62f77dcf5df0dd7eac54eac9f654f426d4161ec850cc65c54f8b65d2e0b4e345",
+		pkRm:           "1d11a3cd247ae48e901939659bd4d79b6b959e1f3e7d66663fbc9412dd4e0976",
+		enc:            "820818d3c23993492cc5623ab437a48a0a7ca3e9639c140fe1e33811eb844b7c",
+		exporterSecret: "f048d55eacbf60f9c6154bd4021774d1075ebf963c6adc71fa846f183ab2dde6",
+		ct:             "a84c64df1e11d8fd11450039d4fe64ff0c8a99fca0bd72c2d4c3e0400bc14a40f27e45e141a24001697737533e",
+		exports: []string{
+			"08f7e20644bb9b8af54ad66d2067457c5f9fcb2a23d9f6cb4445c0797b330067",
+			"52e51ff7d436557ced5265ff8b94ce69cf7583f49cdb374e6aad801fc063b010",
+			"a30c20370c026bbea4dca51cb63761695132d342bae33a6a11527d3e7679436d",
+		},
+	},
+	{
+		name:           "A.2 X25519, HKDF-SHA256, ChaCha20Poly1305, Base",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ChaCha20Poly1305,
+		mode:           modeBase,
+		ikmE:           "909a9b35d3dc4713a5e72a4da274b55d3d3821a37e5d099e74a647db583a904b",
+		ikmR:           "1ac01f181fdf9f352797655161c58b75c656a6cc2716dcb66372da835542e1df",
+		pkRm:           "4310ee97d88cc1f088a5576c77ab0cf5c3ac797f3d95139c6c84b5429c59662a",
+		enc:            "1afa08d3dec047a643885163f1180476fa7ddb54c6a8029ea33f95796bf2ac4a",
+		exporterSecret: "a3b010d4994890e2c6968a36f64470d3c824c8f5029942feb11e7a74b2921922",
+		ct:             "1c5250d8034ec2b784ba2cfd69dbdb8af406cfe3ff938e131f0def8c8b60b4db21993c62ce81883d2dd1b51a28",
+		exports: []string{
+			"4bbd6243b8bb54cec311fac9df81841b6fd61f56538a775e7c80a9f40160606e",
+			"8c1df14732580e5501b00f82b10a1647b40713191b7c1240ac80e2b68808ba69",
+			"5acb09211139c43b3090489a9da433e8a30ee7188ba8b0a9a1ccf0c229283e53",
+		},
+	},
+	{
+		name:           "A.2 X25519, HKDF-SHA256, ChaCha20Poly1305, PSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ChaCha20Poly1305,
+		mode:           modePSK,
+		ikmE:           "35706a0b09fb26fb45c39c2f5079c709c7cf98e43afa973f14d88ece7e29c2e3",
+		ikmR:           "26b923eade72941c8a85b09986cdfa3f1296852261adedc52d58d2930269812b",
+		pkRm:           "13640af826b722fc04feaa4de2f28fbd5ecc03623b317834e7ff4120dbe73062",
+		enc:            "2261299c3f40a9afc133b969a97f05e95be2c514e54f3de26cbe5644ac735b04",
+		exporterSecret: "73b506dc8b6b4269027f80b0362def5cbb57ee50eed0c2873dac9181f453c5ac",
+		ct:             "4a177f9c0d6f15cfdf533fb65bf84aecdc6ab16b8b85b4cf65a370e07fc1d78d28fb073214525276f4a89608ff",
+		exports: []string{
+			"813c1bfc516c99076ae0f466671f0ba5ff244a41699f7b2417e4c59d46d39f40",
+			"2745cf3d5bb65c333658732954ee7af49eb895ce77f8022873a62a13c94cb4e1",
+			"ad40e3ae14f21c99bfdebc20ae14ab86f4ca2dc9a4799d200f43a25f99fa78ae",
+		},
+	},
+	{
+		name:           "A.2 X25519, HKDF-SHA256, ChaCha20Poly1305, Auth",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ChaCha20Poly1305,
+		mode:           modeAuth,
+		ikmE:           "938d3daa5a8904540bc24f48ae90eed3f4f7f11839560597b55e7c9598c996c0",
+		ikmR:           "64835d5ee64aa7aad57c6f2e4f758f7696617f8829e70bc9ac7a5ef95d1c756c",
+		ikmS:           "9d8f94537d5a3ddef71234c0baedfad4ca6861634d0b94c3007fed557ad17df6",
+		pkRm:           "1a478716d63cb2e16786ee93004486dc151e988b34b475043d3e0175bdb01c44",
+		enc:            "f7674cc8cd7baa5872d1f33dbaffe3314239f6197ddf5ded1746760bfc847e0e",
+		exporterSecret: "be2d93b82071318cdb88510037cf504344151f2f9b9da8ab48974d40a2251dd7",
+		ct:             "ab1a13c9d4f01a87ec3440dbd756e2677bd2ecf9df0ce7ed73869b98e00c09be111cb9fdf077347aeb88e61bdf",
+		exports: []string{
+			"070cffafd89b67b7f0eeb800235303a223e6ff9d1e774dce8eac585c8688c872",
+			"2852e728568d40ddb0edde284d36a4359c56558bb2fb8837cd3d92e46a3a14a8",
+			"1df39dc5dd60edcbf5f9ae804e15ada66e885b28ed7929116f768369a3f950ee",
+		},
+	},
+	{
+		name:           "A.2 X25519, HKDF-SHA256, ChaCha20Poly1305, AuthPSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ChaCha20Poly1305,
+		mode:           modeAuthPSK,
+		ikmE:           "49d6eac8c6c558c953a0a252929a818745bb08cd3d29e15f9f5db5eb2e7d4b84",
+		ikmR:           "f3304ddcf15848488271f12b75ecaf72301faabf6ad283654a14c398832eb184",
+		ikmS:           "20ade1d5203de1aadfb261c4700b6432e260d0d317be6ebbb8d7fffb1f86ad9d",
+		pkRm:           "a5099431c35c491ec62ca91df1525d6349cb8aa170c51f9581f8627be6334851",
+		enc:            "656a2e00dc9990fd189e6e473459392df556e9a2758754a09db3f51179a3fc02",
+		exporterSecret: "7c6cc1bb98993cd93e2599322247a58fd41fdecd3db895fb4c5fd8d6bbe606b5",
+		ct:             "9aa52e29274fc6172e38a4461361d2342585d3aeec67fb3b721ecd63f059577c7fe886be0ede01456ebc67d597",
+		exports: []string{
+			"c23ebd4e7a0ad06a5dddf779f65004ce9481069ce0f0e6dd51a04539ddcbd5cd",
+			"ed7ff5ca40a3d84561067ebc8e01702bc36cf1eb99d42a92004642b9dfaadd37",
+			"d3bae066aa8da27d527d85c040f7dd6ccb60221c902ee36a82f70bcd62a60ee4",
+		},
+	},
+	{
+		name: "A.3 P-256, HKDF-SHA256, AES-128-GCM, Base",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: AES128GCM,
+		mode: modeBase,
+		ikmE: "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e",
+		ikmR: "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550",
+		pkRm: "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f6" +
+			"2fb3d30ad6aa8f80e30f1d128aafd68a2ce72ea0",
+		enc: "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f95" +
+			"1c0900be863c403ce65c9bfcb9382657222d18c4",
+		exporterSecret: "14ad94af484a7ad3ef40e9f3be99ecc6fa9036df9d4920548424df127ee0d99f",
+		ct:             "5ad590bb8baa577f8619db35a36311226a896e7342a6d836d8b7bcd2f20b6c7f9076ac232e3ab2523f39513434",
+		exports: []string{
+			"5e9bc3d236e1911d95e65b576a8a86d478fb827e8bdfe77b741b289890490d4d",
+			"6cff87658931bda83dc857e6353efe4987a201b849658d9b047aab4cf216e796",
+			"d8f1ea7942adbba7412c6d431c62d01371ea476b823eb697e1f6e6cae1dab85a",
+		},
+	},
+	{
+		name: "A.3 P-256, HKDF-SHA256, AES-128-GCM, PSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: AES128GCM,
+		mode: modePSK,
+		ikmE: "2afa611d8b1a7b321c761b483b6a053579afa4f767450d3ad0f84a39fda587a6",
+		ikmR: "d42ef874c1913d9568c9405407c805baddaffd0898a00f1e84e154fa787b2429",
+		pkRm: "040d97419ae99f13007a93996648b2674e5260a8ebd2b822e84899cd52d87446ea394ca76223b76639eccdf00e" +
+			"1967db10ade37db4e7db476261fcc8df97c5ffd1",
+		enc: "04305d35563527bce037773d79a13deabed0e8e7cde61eecee403496959e89e4d0ca701726696d1485137ccb53" +
+			"41b3c1c7aaee90a4a02449725e744b1193b53b5f",
+		exporterSecret: "895a723a1eab809804973a53c0ee18ece29b25a7555a4808277ad2651d66d705",
+		ct:             "90c4deb5b75318530194e4bb62f890b019b1397bbf9d0d6eb918890e1fb2be1ac2603193b60a49c2126b75d0eb",
+		exports: []string{
+			"a115a59bf4dd8dc49332d6a0093af8efca1bcbfd3627d850173f5c4a55d0c185",
+			"4517eaede0669b16aac7c92d5762dd459c301fa10e02237cd5aeb9be969430c4",
+			"164e02144d44b607a7722e58b0f4156e67c0c2874d74cf71da6ca48a4cbdc5e0",
+		},
+	},
+	{
+		name: "A.3 P-256, HKDF-SHA256, AES-128-GCM, Auth",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: AES128GCM,
+		mode: modeAuth,
+		ikmE: "798d82a8d9ea19dbc7f2c6dfa54e8a6706f7cdc119db0813dacf8440ab37c857",
+		ikmR: "7bc93bde8890d1fb55220e7f3b0c107ae7e6eda35ca4040bb6651284bf0747ee",
+		ikmS: "874baa0dcf93595a24a45a7f042e0d22d368747daaa7e19f80a802af19204ba8",
+		pkRm: "04423e363e1cd54ce7b7573110ac121399acbc9ed815fae03b72ffbd4c18b01836835c5a09513f28fc971b7266" +
+			"cfde2e96afe84bb0f266920e82c4f53b36e1a78d",
+		enc: "042224f3ea800f7ec55c03f29fc9865f6ee27004f818fcbdc6dc68932c1e52e15b79e264a98f2c535ef06745f3" +
+			"d308624414153b22c7332bc1e691cb4af4d53454",
+		exporterSecret: "f152759972660eb0e1db880835abd5de1c39c8e9cd269f6f082ed80e28acb164",
+		ct:             "82ffc8c44760db691a07c5627e5fc2c08e7a86979ee79b494a17cc3405446ac2bdb8f265db4a099ed3289ffe19",
+		exports: []string{
+			"837e49c3ff629250c8d80d3c3fb957725ed481e59e2feb57afd9fe9a8c7c4497",
+			"594213f9018d614b82007a7021c3135bda7b380da4acd9ab27165c508640dbda",
+			"14fe634f95ca0d86e15247cca7de7ba9b73c9b9deb6437e1c832daf7291b79d5",
+		},
+	},
+	{
+		name: "A.3 P-256, HKDF-SHA256, AES-128-GCM, AuthPSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: AES128GCM,
+		mode: modeAuthPSK,
+		ikmE: "3c1fceb477ec954c8d58ef3249e4bb4c38241b5925b95f7486e4d9f1d0d35fbb",
+		ikmR: "abcc2da5b3fa81d8aabd91f7f800a8ccf60ec37b1b585a5d1d1ac77f258b6cca",
+		ikmS: "6262031f040a9db853edd6f91d2272596eabbc78a2ed2bd643f770ecd0f19b82",
+		pkRm: "04d824d7e897897c172ac8a9e862e4bd820133b8d090a9b188b8233a64dfbc5f725aa0aa52c8462ab7c9188f1c" +
+			"4872f0c99087a867e8a773a13df48a627058e1b3",
+		enc: "046a1de3fc26a3d43f4e4ba97dbe24f7e99181136129c48fbe872d4743e2b131357ed4f29a7b317dc22509c7b0" +
+			"0991ae990bf65f8b236700c82ab7c11a84511401",
+		exporterSecret: "3f479020ae186788e4dfd4a42a21d24f3faabb224dd4f91c2b2e5e9524ca27b2",
+		ct:             "b9f36d58d9eb101629a3e5a7b63d2ee4af42b3644209ab37e0a272d44365407db8e655c72e4fa46f4ff81b9246",
+		exports: []string{
+			"595ce0eff405d4b3bb1d08308d70a4e77226ce11766e0a94c4fdb5d90025c978",
+			"110472ee0ae328f57ef7332a9886a1992d2c45b9b8d5abc9424ff68630f7d38d",
+			"18ee4d001a9d83a4c67e76f88dd747766576cac438723bad0700a910a4d717e6",
+		},
+	},
+	{
+		name: "A.4 P-256, HKDF-SHA512, AES-128-GCM, Base",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA512,
+		aead: AES128GCM,
+		mode: modeBase,
+		ikmE: "4ab11a9dd78c39668f7038f921ffc0993b368171d3ddde8031501ee1e08c4c9a",
+		ikmR: "ea9ff7cc5b2705b188841c7ace169290ff312a9cb31467784ca92d7a2e6e1be8",
+		pkRm: "04085aa5b665dc3826f9650ccbcc471be268c8ada866422f739e2d531d4a8818a9466bc6b449357096232919ec" +
+			"4fe9070ccbac4aac30f4a1a53efcf7af90610edd",
+		enc: "0493ed86735bdfb978cc055c98b45695ad7ce61ce748f4dd63c525a3b8d53a15565c6897888070070c1579db1f" +
+			"86aaa56deb8297e64db7e8924e72866f9a472580",
+		exporterSecret: "4a7abb2ac43e6553f129b2c5750a7e82d149a76ed56dc342d7bca61e26d494f4855dff0d0165f27ce57756f7f1" +
+			"6baca006539bb8e4518987ba610480ac03efa8",
+		ct: "d3cf4984931484a080f74c1bb2a6782700dc1fef9abe8442e44a6f09044c88907200b332003543754eb51917ba",
+		exports: []string{
+			"a32186b8946f61aeead1c093fe614945f85833b165b28c46bf271abf16b57208",
+			"84998b304a0ea2f11809398755f0abd5f9d2c141d1822def79dd15c194803c2a",
+			"93fb9411430b2cfa2cf0bed448c46922a5be9beff20e2e621df7e4655852edbc",
+		},
+	},
+	{
+		name: "A.4 P-256, HKDF-SHA512, AES-128-GCM, PSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA512,
+		aead: AES128GCM,
+		mode: modePSK,
+		ikmE: "c11d883d6587f911d2ddbc2a0859d5b42fb13bf2c8e89ef408a25564893856f5",
+		ikmR: "75bfc2a3a3541170a54c0b06444e358d0ee2b4fb78a401fd399a47a33723b700",
+		pkRm: "043f5266fba0742db649e1043102b8a5afd114465156719cea90373229aabdd84d7f45dabfc1f55664b888a7e8" +
+			"6d594853a6cccdc9b189b57839cbbe3b90b55873",
+		enc: "04a307934180ad5287f95525fe5bc6244285d7273c15e061f0f2efb211c35057f3079f6e0abae200992610b25f" +
+			"48b63aacfcb669106ddee8aa023feed301901371",
+		exporterSecret: "50c0a182b6f94b4c0bd955c4aa20df01f282cc12c43065a0812fe4d4352790171ed2b2c4756ad7f5a730ba336c" +
+			"8f1edd0089d8331192058c385bae39c7cc8b57",
+		ct: "57624b6e320d4aba0afd11f548780772932f502e2ba2a8068676b2a0d3b5129a45b9faa88de39e8306da41d4cc",
+		exports: []string{
+			"8158bea21a6700d37022bb7802866edca30ebf2078273757b656ef7fc2e428cf",
+			"6a348ba6e0e72bb3ef22479214a139ef8dac57be34509a61087a12565473da8d",
+			"2f6d4f7a18ec48de1ef4469f596aada4afdf6d79b037ed3c07e0118f8723bffc",
+		},
+	},
+	{
+		name: "A.4 P-256, HKDF-SHA512, AES-128-GCM, Auth",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA512,
+		aead: AES128GCM,
+		mode: modeAuth,
+		ikmE: "6bb031aa9197562da0b44e737db2b9e61f6c3ea1138c37de28fc37ac29bc7350",
+		ikmR: "649a3f92edbb7a2516a0ade0b7dccc58a37240c4ba06f9726a952227b4adf6ff",
+		ikmS: "4d79b8691aab55a7265e8490a04bb3860ed64dece90953ad0dc43a6ea59b4bf2",
+		pkRm: "04378bad519aab406e04d0e5608bcca809c02d6afd2272d4dd03e9357bd0eee8adf84c8deba3155c9cf9506d1d" +
+			"4c8bfefe3cf033a75716cc3cc07295100ec96276",
+		enc: "04fec59fa9f76f5d0f6c1660bb179cb314ed97953c53a60ab38f8e6ace60fd59178084d0dd66e0f79172992d4d" +
+			"db2e91172ce24949bcebfff158dcc417f2c6e9c6",
+		exporterSecret: "ca2410672369aae1afd6c2639f4fe34ca36d35410c090608d2924f60def17f910d7928575434d7f991b1f19d3e" +
+			"8358b8278ff59ced0d5eed4774cec72e12766e",
+		ct: "2480179d880b5f458154b8bfe3c7e8732332de84aabf06fc440f6b31f169e154157fa9eb44f2fa4d7b38a9236e",
+		exports: []string{
+			"f03fbc82f321a0ab4840e487cb75d07aafd8e6f68485e4f7ff72b2f55ff24ad6",
+			"1ce0cadec0a8f060f4b5070c8f8888dcdfefc2e35819df0cd559928a11ff0891",
+			"70c405c707102fd0041ea716090753be47d68d238b111d542846bd0d84ba907c",
+		},
+	},
+	{
+		name: "A.4 P-256, HKDF-SHA512, AES-128-GCM, AuthPSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA512,
+		aead: AES128GCM,
+		mode: modeAuthPSK,
+		ikmE: "37ae06a521cd555648c928d7af58ad2aa4a85e34b8cabd069e94ad55ab872cc8",
+		ikmR: "7466024b7e2d2366c3914d7833718f13afb9e3e45bcfbb510594d614ddd9b4e7",
+		ikmS: "ee27aaf99bf5cd8398e9de88ac09a82ac22cdb8d0905ab05c0f5fa12ba1709f3",
+		pkRm: "04a4ca7af2fc2cce48edbf2f1700983e927743a4e85bb5035ad562043e25d9a111cbf6f7385fac55edc5c9d2ca" +
+			"6ed351a5643de95c36748e11dbec98730f4d43e9",
+		enc: "04801740f4b1b35823f7fb2930eac2efc8c4893f34ba111c0bb976e3c7d5dc0aef5a7ef0bf4057949a140285f7" +
+			"74f1efc53b3860936b92279a11b68395d898d138",
+		exporterSecret: "7f72308ae68c9a2b3862e686cb547b16d33d00fe482c770c4717d8b54e9b1e547244c3602bdd86d5a788a8443b" +
+			"efea0a7658002b23f1c96a62a64986fffc511a",
+		ct: "840669634db51e28df54f189329c1b727fd303ae413f003020aff5e26276aaa910fc4296828cb9d862c2fd7d16",
+		exports: []string{
+			"c8c917e137a616d3d4e4c9fcd9c50202f366cb0d37862376bc79f9b72e8a8db9",
+			"33a5d4df232777008a06d0684f23bb891cfaef702f653c8601b6ad4d08dddddf",
+			"bed80f2e54f1285895c4a3f3b3625e6206f78f1ed329a0cfb5864f7c139b3c6a",
+		},
+	},
+	{
+		name: "A.5 P-256, HKDF-SHA256, ChaCha20Poly1305, Base",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: ChaCha20Poly1305,
+		mode: modeBase,
+		ikmE: "f1f1a3bc95416871539ecb51c3a8f0cf608afb40fbbe305c0a72819d35c33f1f",
+		ikmR: "61092f3f56994dd424405899154a9918353e3e008171517ad576b900ddb275e7",
+		pkRm: "04a697bffde9405c992883c5c439d6cc358170b51af72812333b015621dc0f40bad9bb726f68a5c013806a790e" +
+			"c716ab8669f84f6b694596c2987cf35baba2a006",
+		enc: "04c07836a0206e04e31d8ae99bfd549380b072a1b1b82e563c935c095827824fc1559eac6fb9e3c70cd3193968" +
+			"994e7fe9781aa103f5b50e934b5b2f387e381291",
+		exporterSecret: "4f9bd9b3a8db7d7c3a5b9d44fdc1f6e37d5d77689ade5ec44a7242016e6aa205",
+		ct:             "6469c41c5c81d3aa85432531ecf6460ec945bde1eb428cb2fedf7a29f5a685b4ccb0d057f03ea2952a27bb458b",
+		exports: []string{
+			"9b13c510416ac977b553bf1741018809c246a695f45eff6d3b0356dbefe1e660",
+			"6c8b7be3a20a5684edecb4253619d9051ce8583baf850e0cb53c402bdcaf8ebb",
+			"477a50d804c7c51941f69b8e32fe8288386ee1a84905fe4938d58972f24ac938",
+		},
+	},
+	{
+		name: "A.5 P-256, HKDF-SHA256, ChaCha20Poly1305, PSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: ChaCha20Poly1305,
+		mode: modePSK,
+		ikmE: "e1a4e1d50c4bfcf890f2b4c7d6b2d2aca61368eddc3c84162df2856843e1057a",
+		ikmR: "ee51dec304abf993ef8fd52aacdd3b539108bbf6e491943266c1de89ec596a17",
+		pkRm: "041eb8f4f20ab72661af369ff3231a733672fa26f385ffb959fd1bae46bfda43ad55e2d573b880831381d93674" +
+			"17f554ce5b2134fbba5235b44db465feffc6189e",
+		enc: "04f336578b72ad7932fe867cc4d2d44a718a318037a0ec271163699cee653fa805c1fec955e562663e0c2061bb" +
+			"96a87d78892bff0cc0bad7906c2d998ebe1a7246",
+		exporterSecret: "754ca00235b245e72d1f722a7718e7145bd113050a2aa3d89586d4cb7514bfdb",
+		ct:             "21433eaff24d7706f3ed5b9b2e709b07230e2b11df1f2b1fe07b3c70d5948a53d6fa5c8bed194020bd9df0877b",
+		exports: []string{
+			"530bbc2f68f078dccc89cc371b4f4ade372c9472bafe4601a8432cbb934f528d",
+			"6e25075ddcc528c90ef9218f800ca3dfe1b8ff4042de5033133adb8bd54c401d",
+			"6f6fbd0d1c7733f796461b3235a856cc34f676fe61ed509dfc18fa16efe6be78",
+		},
+	},
+	{
+		name: "A.5 P-256, HKDF-SHA256, ChaCha20Poly1305, Auth",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: ChaCha20Poly1305,
+		mode: modeAuth,
+		ikmE: "0ecd212019008138a31f9104d5dba76b9f8e34d5b996041fff9e3df221dd0d5d",
+		ikmR: "d32236d8378b9563840653789eb7bc33c3c720e537391727bf1c812d0eac110f",
+		ikmS: "0e6be0851283f9327295fd49858a8c8908ea9783212945eef6c598ee0a3cedbb",
+		pkRm: "0444f6ee41818d9fe0f8265bffd016b7e2dd3964d610d0f7514244a60dbb7a11ece876bb110a97a2ac6a9542d7" +
+			"344bf7d2bd59345e3e75e497f7416cf38d296233",
+		enc: "040d5176aedba55bc41709261e9195c5146bb62d783031280775f32e507d79b5cbc5748b6be6359760c73cfe10" +
+			"ca19521af704ca6d91ff32fc0739527b9385d415",
+		exporterSecret: "dba6e307f71769ba11e2c687cc19592f9d436da0c81e772d7a8a9fd28e54355f",
+		ct:             "25881f219935eec5ba70d7b421f13c35005734f3e4d959680270f55d71e2f5cb3bd2daced2770bf3d9d4916872",
+		exports: []string{
+			"56c4d6c1d3a46c70fd8f4ecda5d27c70886e348efb51bd5edeaa39ff6ce34389",
+			"d2d3e48ed76832b6b3f28fa84be5f11f09533c0e3c71825a34fb0f1320891b51",
+			"eb0d312b6263995b4c7761e64b688c215ffd6043ff3bad2368c862784cbe6eff",
+		},
+	},
+	{
+		name: "A.5 P-256, HKDF-SHA256, ChaCha20Poly1305, AuthPSK",
+		kem:  DHKEMP256HKDFSHA256,
+		kdf:  HKDFSHA256,
+		aead: ChaCha20Poly1305,
+		mode: modeAuthPSK,
+		ikmE: "f3a07f194703e321ef1f753a1b9fe27a498dfdfa309151d70bedd896c239c499",
+		ikmR: "1240e55a0a03548d7f963ef783b6a7362cb505e6b31dfd04c81d9b294543bfbd",
+		ikmS: "ce2a0387a2eb8870a3a92c34a2975f0f3f271af4384d446c7dc1524a6c6c515a",
+		pkRm: "04d383fd920c42d018b9d57fd73a01f1eee480008923f67d35169478e55d2e8817068daf62a06b10e0aad4a9e4" +
+			"29fa7f904481be96b79a9c231a33e956c20b81b6",
+		enc: "043539917ee26f8ae0aa5f784a387981b13de33124a3cde88b94672030183110f331400115855808244ff0c5b6" +
+			"ca6104483ac95724481d41bdcd9f15b430ad16f6",
+		exporterSecret: "600895965755db9c5027f25f039a6e3e506c35b3b7084ce33c4a48d59ee1f0e3",
+		ct:             "9eadfa0f954835e7e920ffe56dec6b31a046271cf71fdda55db72926e1d8fae94cc6280fcfabd8db71eaa65c05",
+		exports: []string{
+			"c52b4592cd33dd38b2a3613108ddda28dcf7f03d30f2a09703f758bfa8029c9a",
+			"2f03bebc577e5729e148554991787222b5c2a02b77e9b1ac380541f710e5a318",
+			"e01dd49e8bfc3d9216abc1be832f0418adf8b47a7b5a330a7436c31e33d765d7",
+		},
+	},
+	{
+		name:           "A.7 X25519, HKDF-SHA256, ExportOnly, Base",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ExportOnly,
+		mode:           modeBase,
+		ikmE:           "55bc245ee4efda25d38f2d54d5bb6665291b99f8108a8c4b686c2b14893ea5d9",
+		ikmR:           "683ae0da1d22181e74ed2e503ebf82840deb1d5e872cade20f4b458d99783e31",
+		pkRm:           "194141ca6c3c3beb4792cd97ba0ea1faff09d98435012345766ee33aae2d7664",
+		enc:            "e5e8f9bfff6c2f29791fc351d2c25ce1299aa5eaca78a757c0b4fb4bcd830918",
+		exporterSecret: "79dc8e0509cf4a3364ca027e5a0138235281611ca910e435e8ed58167c72f79b",
+		exports: []string{
+			"7a36221bd56d50fb51ee65edfd98d06a23c4dc87085aa5866cb7087244bd2a36",
+			"d5535b87099c6c3ce80dc112a2671c6ec8e811a2f284f948cec6dd1708ee33f0",
+			"ffaabc85a776136ca0c378e5d084c9140ab552b78f039d2e8775f26efff4c70e",
+		},
+	},
+	{
+		name:           "A.7 X25519, HKDF-SHA256, ExportOnly, PSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ExportOnly,
+		mode:           modePSK,
+		ikmE:           "c51211a8799f6b8a0021fcba673d9c4067a98ebc6794232e5b06cb9febcbbdf5",
+		ikmR:           "5e0516b1b29c0e13386529da16525210c796f7d647c37eac118023a6aa9eb89a",
+		pkRm:           "d53af36ea5f58f8868bb4a1333ed4cc47e7a63b0040eb54c77b9c8ec456da824",
+		enc:            "d3805a97cbcd5f08babd21221d3e6b362a700572d14f9bbeb94ec078d051ae3d",
+		exporterSecret: "04261818aeae99d6aba5101bd35ddf3271d909a756adcef0d41389d9ed9ab153",
+		exports: []string{
+			"be6c76955334376aa23e936be013ba8bbae90ae74ed995c1c6157e6f08dd5316",
+			"1721ed2aa852f84d44ad020c2e2be4e2e6375098bf48775a533505fd56a3f416",
+			"7c9d79876a288507b81a5a52365a7d39cc0fa3f07e34172984f96fec07c44cba",
+		},
+	},
+	{
+		name:           "A.7 X25519, HKDF-SHA256, ExportOnly, Auth",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ExportOnly,
+		mode:           modeAuth,
+		ikmE:           "43b078912a54b591a7b09b16ce89a1955a9dd60b29fb611e044260046e8b061b",
+		ikmR:           "fc9407ae72ed614901ebf44257fb540f617284b5361cfecd620bafc4aba36f73",
+		ikmS:           "2ff4c37a17b2e54046a076bf5fea9c3d59250d54d0dc8572bc5f7c046307040c",
+		pkRm:           "ffd7ac24694cb17939d95feb7c4c6539bb31621deb9b96d715a64abdd9d14b10",
+		enc:            "5ac1671a55c5c3875a8afe74664aa8bc68830be9ded0c5f633cd96400e8b5c05",
+		exporterSecret: "276d87e5cb0655c7d3dad95e76e6fc02746739eb9d968955ccf8a6346c97509e",
+		exports: []string{
+			"83c1bac00a45ed4cb6bd8a6007d2ce4ec501f55e485c5642bd01bf6b6d7d6f0a",
+			"08a1d1ad2af3ef5bc40232a64f920650eb9b1034fac3892f729f7949621bf06e",
+			"ff3b0e37a9954247fea53f251b799e2edd35aac7152c5795751a3da424feca73",
+		},
+	},
+	{
+		name:           "A.7 X25519, HKDF-SHA256, ExportOnly, AuthPSK",
+		kem:            DHKEMX25519HKDFSHA256,
+		kdf:            HKDFSHA256,
+		aead:           ExportOnly,
+		mode:           modeAuthPSK,
+		ikmE:           "94efae91e96811a3a49fd1b20eb0344d68ead6ac01922c2360779aa172487f40",
+		ikmR:           "4dfde6fadfe5cb50fced4034e84e6d3a104aa4bf2971360032c1c0580e286663",
+		ikmS:           "26c12fef8d71d13bbbf08ce8157a283d5e67ecf0f345366b0e90341911110f1b",
+		pkRm:           "f47cd9d6993d2e2234eb122b425accfb486ee80f89607b087094e9f413253c2d",
+		enc:            "81cbf4bd7eee97dd0b600252a1c964ea186846252abb340be47087cc78f3d87c",
+		exporterSecret: "695b1faa479c0e0518b6414c3b46e8ef5caea04c0a192246843765ae6a8a78e0",
+		exports: []string{
+			"dafd8beb94c5802535c22ff4c1af8946c98df2c417e187c6ccafe45335810b58",
+			"7346bb0b56caf457bcc1aa63c1b97d9834644bdacac8f72dbbe3463e4e46b0dd",
+			"84f3466bd5a03bde6444324e63d7560e7ac790da4e5bbab01e7c4d575728c34a",
+		},
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			s, err := NewSuite(v.kem, v.kdf, v.aead)
+			require.NoError(t, err)
+			info := unhex(t, vectorInfo)
+			var psk, pskID []byte
+			if v.mode == modePSK || v.mode == modeAuthPSK {
+				psk, pskID = unhex(t, vectorPSK), unhex(t, vectorPSKID)
+			}
+
+			skR, pkR, err := s.DeriveKeyPair(unhex(t, v.ikmR))
+			require.NoError(t, err)
+			pkRm, err := s.SerializePublicKey(pkR)
+			require.NoError(t, err)
+			require.Equal(t, v.pkRm, hex.EncodeToString(pkRm))
+			var skS kyber.Scalar
+			var pkS kyber.Point
+			if v.ikmS != "" {
+				skS, pkS, err = s.DeriveKeyPair(unhex(t, v.ikmS))
+				require.NoError(t, err)
+			}
+
+			enc, sender, err := s.setupS(unhex(t, v.ikmE), v.mode, pkR, info, psk, pskID, skS)
+			require.NoError(t, err)
+			require.Equal(t, v.enc, hex.EncodeToString(enc))
+			require.Equal(t, v.exporterSecret, hex.EncodeToString(sender.exporterSecret))
+			receiver, err := s.setupR(v.mode, enc, skR, info, psk, pskID, pkS)
+			require.NoError(t, err)
+
+			if v.aead == ExportOnly {
+				_, err = sender.Seal(unhex(t, vectorAAD), unhex(t, vectorPT))
+				require.Error(t, err)
+			} else {
+				ct, err := sender.Seal(unhex(t, vectorAAD), unhex(t, vectorPT))
+				require.NoError(t, err)
+				require.Equal(t, v.ct, hex.EncodeToString(ct))
+				pt, err := receiver.Open(unhex(t, vectorAAD), ct)
+				require.NoError(t, err)
+				require.Equal(t, unhex(t, vectorPT), pt)
+			}
+
+			for i, exporterContext := range []string{"", "00", "54657374436f6e74657874"} {
+				for _, ctx := range []*Context{sender, receiver} {
+					secret, err := ctx.Export(unhex(t, exporterContext), 32)
+					require.NoError(t, err)
+					require.Equal(t, v.exports[i], hex.EncodeToString(secret))
+				}
+			}
+		})
+	}
+}
+
+func TestX25519PrivateKey(t *testing.T) {
+	// skRm and pkRm of the vector of appendix A.1.1
+	s, err := NewSuite(DHKEMX25519HKDFSHA256, HKDFSHA256, AES128GCM)
+	require.NoError(t, err)
+	skR, err := s.DeserializePrivateKey(unhex(t,
+		"4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8"))
+	require.NoError(t, err)
+	pkRm, err := s.SerializePublicKey(s.Group().Point().Mul(skR, nil))
+	require.NoError(t, err)
+	require.Equal(t, "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d", hex.EncodeToString(pkRm))
+
+	_, err = s.DeserializePrivateKey(make([]byte, 31))
+	require.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestModes(t *testing.T) {
+	info := []byte("info")
+	psk, pskID := []byte("a pre-shared key of enough entropy"), []byte("psk")
+	aad, msg := []byte("aad"), []byte("a message")
+
+	for _, kemID := range []KEMID{DHKEMP256HKDFSHA256, DHKEMX25519HKDFSHA256} {
+		for _, kdfID := range []KDFID{HKDFSHA256, HKDFSHA512} {
+			for _, aeadID := range []AEADID{AES128GCM, AES256GCM, ChaCha20Poly1305, ExportOnly} {
+				s, err := NewSuite(kemID, kdfID, aeadID)
+				require.NoError(t, err)
+				skR, pkR, err := s.GenerateKeyPair()
+				require.NoError(t, err)
+				skS, pkS, err := s.GenerateKeyPair()
+				require.NoError(t, err)
+
+				setups := map[string]func() (*Context, *Context, error){
+					"Base": func() (*Context, *Context, error) {
+						enc, sender, err := s.SetupBaseS(pkR, info)
+						if err != nil {
+							return nil, nil, err
+						}
+						receiver, err := s.SetupBaseR(enc, skR, info)
+						return sender, receiver, err
+					},
+					"PSK": func() (*Context, *Context, error) {
+						enc, sender, err := s.SetupPSKS(pkR, info, psk, pskID)
+						if err != nil {
+							return nil, nil, err
+						}
+						receiver, err := s.SetupPSKR(enc, skR, info, psk, pskID)
+						return sender, receiver, err
+					},
+					"Auth": func() (*Context, *Context, error) {
+						enc, sender, err := s.SetupAuthS(pkR, info, skS)
+						if err != nil {
+							return nil, nil, err
+						}
+						receiver, err := s.SetupAuthR(enc, skR, info, pkS)
+						return sender, receiver, err
+					},
+					"AuthPSK": func() (*Context, *Context, error) {
+						enc, sender, err := s.SetupAuthPSKS(pkR, info, psk, pskID, skS)
+						if err != nil {
+							return nil, nil, err
+						}
+						receiver, err := s.SetupAuthPSKR(enc, skR, info, psk, pskID, pkS)
+						return sender, receiver, err
+					},
+				}
+				for mode, setup := range setups {
+					t.Run(fmt.Sprintf("%d-%d-%d-%s", kemID, kdfID, aeadID, mode), func(t *testing.T) {
+						sender, receiver, err := setup()
+						require.NoError(t, err)
+
+						secret, err := sender.Export([]byte("context"), 64)
+						require.NoError(t, err)
+						expected, err := receiver.Export([]byte("context"), 64)
+						require.NoError(t, err)
+						require.Equal(t, expected, secret)
+
+						if aeadID == ExportOnly {
+							_, err = sender.Seal(aad, msg)
+							require.Error(t, err)
+							return
+						}
+						for i := 0; i < 3; i++ {
+							ct, err := sender.Seal(aad, msg)
+							require.NoError(t, err)
+							pt, err := receiver.Open(aad, ct)
+							require.NoError(t, err)
+							require.Equal(t, msg, pt)
+						}
+						_, err = receiver.Seal(aad, msg)
+						require.Error(t, err)
+						_, err = sender.Open(aad, msg)
+						require.Error(t, err)
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	for _, kemID := range []KEMID{DHKEMP256HKDFSHA256, DHKEMX25519HKDFSHA256} {
+		s, err := NewSuite(kemID, HKDFSHA256, ChaCha20Poly1305)
+		require.NoError(t, err)
+		skR, pkR, err := s.GenerateKeyPair()
+		require.NoError(t, err)
+		other, _, err := s.GenerateKeyPair()
+		require.NoError(t, err)
+		info, aad, msg := []byte("info"), []byte("aad"), []byte("a message")
+
+		enc, ct, err := s.Seal(pkR, info, aad, msg)
+		require.NoError(t, err)
+		pt, err := s.Open(enc, skR, info, aad, ct)
+		require.NoError(t, err)
+		require.Equal(t, msg, pt)
+
+		// Serialized keys
+		buf, err := s.SerializePublicKey(pkR)
+		require.NoError(t, err)
+		pk, err := s.DeserializePublicKey(buf)
+		require.NoError(t, err)
+		again, err := s.SerializePublicKey(pk)
+		require.NoError(t, err)
+		require.Equal(t, buf, again)
+
+		_, err = s.Open(enc, other, info, aad, ct)
+		require.ErrorIs(t, err, ErrOpen)
+		_, err = s.Open(enc, skR, []byte("other info"), aad, ct)
+		require.ErrorIs(t, err, ErrOpen)
+		_, err = s.Open(enc, skR, info, []byte("other aad"), ct)
+		require.ErrorIs(t, err, ErrOpen)
+		ct[0] ^= 1
+		_, err = s.Open(enc, skR, info, aad, ct)
+		require.ErrorIs(t, err, ErrOpen)
+		_, err = s.Open(enc[1:], skR, info, aad, ct)
+		require.ErrorIs(t, err, ErrInvalidKey)
+	}
+}
+
+func TestInvalidInputs(t *testing.T) {
+	s, err := NewSuite(DHKEMX25519HKDFSHA256, HKDFSHA256, AES128GCM)
+	require.NoError(t, err)
+	skR, pkR, err := s.GenerateKeyPair()
+	require.NoError(t, err)
+	skS, _, err := s.GenerateKeyPair()
+	require.NoError(t, err)
+	_, other, err := s.GenerateKeyPair()
+	require.NoError(t, err)
+	psk, pskID := []byte("a pre-shared key of enough entropy"), []byte("psk")
+
+	_, err = NewSuite(0x0042, HKDFSHA256, AES128GCM)
+	require.Error(t, err)
+	_, err = NewSuite(DHKEMX25519HKDFSHA256, 0x0042, AES128GCM)
+	require.Error(t, err)
+	_, err = NewSuite(DHKEMX25519HKDFSHA256, HKDFSHA256, 0x0042)
+	require.Error(t, err)
+
+	_, _, err = s.SetupPSKS(pkR, nil, psk, nil)
+	require.ErrorIs(t, err, ErrInvalidPSK)
+	_, _, err = s.SetupPSKS(pkR, nil, nil, nil)
+	require.ErrorIs(t, err, ErrInvalidPSK)
+
+	// A different pre-shared key
+	enc, sender, err := s.SetupPSKS(pkR, nil, psk, pskID)
+	require.NoError(t, err)
+	ct, err := sender.Seal(nil, []byte("message"))
+	require.NoError(t, err)
+	receiver, err := s.SetupPSKR(enc, skR, nil, []byte("another pre-shared key"), pskID)
+	require.NoError(t, err)
+	_, err = receiver.Open(nil, ct)
+	require.ErrorIs(t, err, ErrOpen)
+
+	// A different sender
+	enc, sender, err = s.SetupAuthS(pkR, nil, skS)
+	require.NoError(t, err)
+	ct, err = sender.Seal(nil, []byte("message"))
+	require.NoError(t, err)
+	receiver, err = s.SetupAuthR(enc, skR, nil, other)
+	require.NoError(t, err)
+	_, err = receiver.Open(nil, ct)
+	require.ErrorIs(t, err, ErrOpen)
+
+	// The encapsulated keys of small order, u = 0 and u = 1
+	for _, u := range []byte{0, 1} {
+		enc := make([]byte, 32)
+		enc[0] = u
+		_, err = s.SetupBaseR(enc, skR, nil)
+		require.ErrorIs(t, err, ErrInvalidKey)
+	}
+
+	_, err = sender.Export(nil, 255*32+1)
+	require.Error(t, err)
+}
@@ -0,0 +1,257 @@
+package hpke
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+)
+
+// KEMID identifies a key encapsulation mechanism.
+type KEMID uint16
+
+// The DHKEMs of RFC 9180, section 7.1, both with HKDF-SHA256.
+const (
+	DHKEMP256HKDFSHA256   KEMID = 0x0010
+	DHKEMX25519HKDFSHA256 KEMID = 0x0020
+)
+
+// ErrInvalidKey is returned for the invalid encodings of keys, and the
+// public keys whose Diffie-Hellman shared secret is the identity.
+var ErrInvalidKey = errors.New("hpke: invalid key")
+
+// dhGroup is the group of a DHKEM with its encodings.
+type dhGroup interface {
+	group() kyber.Group
+	// nsk is the length of the secret keys and of the shared secrets.
+	nsk() int
+	serializePublicKey(P kyber.Point) ([]byte, error)
+	deserializePublicKey(buf []byte) (kyber.Point, error)
+	deserializePrivateKey(buf []byte) (kyber.Scalar, error)
+	// deriveKeyPair derives the secret key from the extracted dkp_prk.
+	deriveKeyPair(k *dhkem, prk []byte) (kyber.Scalar, error)
+	dh(x kyber.Scalar, P kyber.Point) ([]byte, error)
+}
+
+// dhkem is the DHKEM of RFC 9180, section 4.1.
+type dhkem struct {
+	id KEMID
+	g  dhGroup
+	kdf
+}
+
+func newDHKEM(id KEMID) (*dhkem, error) {
+	k := &dhkem{id: id, kdf: kdf{newHash: sha256.New}}
+	switch id {
+	case DHKEMP256HKDFSHA256:
+		k.g = &p256Group{g: p256.NewBlakeSHA256P256()}
+	case DHKEMX25519HKDFSHA256:
+		k.g = &x25519Group{g: new(edwards25519.Curve)}
+	default:
+		return nil, errors.New("hpke: unknown KEM")
+	}
+	k.suiteID = append([]byte("KEM"), byte(id>>8), byte(id))
+	return k, nil
+}
+
+// deriveKeyPair implements DeriveKeyPair.
+func (k *dhkem) deriveKeyPair(ikm []byte) (kyber.Scalar, kyber.Point, error) {
+	prk := k.labeledExtract(nil, "dkp_prk", ikm)
+	x, err := k.g.deriveKeyPair(k, prk)
+	if err != nil {
+		return nil, nil, err
+	}
+	return x, k.g.group().Point().Mul(x, nil), nil
+}
+
+// encap implements Encap and AuthEncap, the latter if private isn't nil,
+// with the ephemeral key derived from ikm.
+func (k *dhkem) encap(ikm []byte, pkR kyber.Point, private kyber.Scalar) ([]byte, []byte, error) {
+	skE, pkE, err := k.deriveKeyPair(ikm)
+	if err != nil {
+		return nil, nil, err
+	}
+	dh, err := k.g.dh(skE, pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	enc, err := k.g.serializePublicKey(pkE)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkRm, err := k.g.serializePublicKey(pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pkRm...)
+	if private != nil {
+		dhS, err := k.g.dh(private, pkR)
+		if err != nil {
+			return nil, nil, err
+		}
+		pkSm, err := k.g.serializePublicKey(k.g.group().Point().Mul(private, nil))
+		if err != nil {
+			return nil, nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkSm...)
+	}
+	return k.extractAndExpand(dh, kemContext), enc, nil
+}
+
+// decap implements Decap and AuthDecap, the latter if pkS isn't nil.
+func (k *dhkem) decap(enc []byte, skR kyber.Scalar, pkS kyber.Point) ([]byte, error) {
+	pkE, err := k.g.deserializePublicKey(enc)
+	if err != nil {
+		return nil, err
+	}
+	dh, err := k.g.dh(skR, pkE)
+	if err != nil {
+		return nil, err
+	}
+	pkRm, err := k.g.serializePublicKey(k.g.group().Point().Mul(skR, nil))
+	if err != nil {
+		return nil, err
+	}
+	kemContext := append(append([]byte{}, enc...), pkRm...)
+	if pkS != nil {
+		dhS, err := k.g.dh(skR, pkS)
+		if err != nil {
+			return nil, err
+		}
+		pkSm, err := k.g.serializePublicKey(pkS)
+		if err != nil {
+			return nil, err
+		}
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkSm...)
+	}
+	return k.extractAndExpand(dh, kemContext), nil
+}
+
+func (k *dhkem) extractAndExpand(dh, kemContext []byte) []byte {
+	prk := k.labeledExtract(nil, "eae_prk", dh)
+	return k.labeledExpand(prk, "shared_secret", kemContext, k.newHash().Size())
+}
+
+// p256Group is the group of DHKEM(P-256, HKDF-SHA256), whose public keys are
+// encoded uncompressed.
+type p256Group struct {
+	g *p256.Suite128
+}
+
+func (p *p256Group) group() kyber.Group { return p.g }
+
+func (p *p256Group) nsk() int { return 32 }
+
+func (p *p256Group) serializePublicKey(P kyber.Point) ([]byte, error) {
+	buf, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if P.Equal(p.g.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	return buf, nil
+}
+
+func (p *p256Group) deserializePublicKey(buf []byte) (kyber.Point, error) {
+	P := p.g.Point()
+	if len(buf) != P.MarshalSize() || buf[0] != 4 {
+		return nil, ErrInvalidKey
+	}
+	if err := P.UnmarshalBinary(buf); err != nil || P.Equal(p.g.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	return P, nil
+}
+
+func (p *p256Group) deserializePrivateKey(buf []byte) (kyber.Scalar, error) {
+	x := p.g.Scalar()
+	if len(buf) != p.nsk() || x.UnmarshalBinary(buf) != nil || x.Equal(p.g.Scalar().Zero()) {
+		return nil, ErrInvalidKey
+	}
+	return x, nil
+}
+
+// deriveKeyPair follows RFC 9180, section 7.1.3, for P-256.
+func (p *p256Group) deriveKeyPair(k *dhkem, prk []byte) (kyber.Scalar, error) {
+	for counter := 0; counter < 256; counter++ {
+		buf := k.labeledExpand(prk, "candidate", []byte{byte(counter)}, p.nsk())
+		if x, err := p.deserializePrivateKey(buf); err == nil {
+			return x, nil
+		}
+	}
+	return nil, errors.New("hpke: DeriveKeyPairError")
+}
+
+// dh returns the x-coordinate of x*P.
+func (p *p256Group) dh(x kyber.Scalar, P kyber.Point) ([]byte, error) {
+	Z := p.g.Point().Mul(x, P)
+	if Z.Equal(p.g.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	buf, err := Z.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return buf[1 : 1+p.nsk()], nil
+}
+
+// x25519Group is the group of DHKEM(X25519, HKDF-SHA256), computed on the
+// birationally equivalent edwards25519 group: the public keys are the
+// u-coordinates u = (1+y)/(1-y) of the points, and the private keys the
+// clamped X25519 scalars reduced modulo the prime order. The public keys
+// whose u-coordinate is on the twist of the curve are rejected.
+type x25519Group struct {
+	g *edwards25519.Curve
+}
+
+func (x *x25519Group) group() kyber.Group { return x.g }
+
+func (x *x25519Group) nsk() int { return 32 }
+
+func (x *x25519Group) serializePublicKey(P kyber.Point) ([]byte, error) {
+	if P.Equal(x.g.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	return edwards25519.MontgomeryEncode(P), nil
+}
+
+func (x *x25519Group) deserializePublicKey(buf []byte) (kyber.Point, error) {
+	P := x.g.Point()
+	if err := edwards25519.MontgomeryDecode(P, buf); err != nil {
+		return nil, ErrInvalidKey
+	}
+	return P, nil
+}
+
+func (x *x25519Group) deserializePrivateKey(buf []byte) (kyber.Scalar, error) {
+	if len(buf) != x.nsk() {
+		return nil, ErrInvalidKey
+	}
+	k := append([]byte{}, buf...)
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+	return x.g.Scalar().SetBytes(k), nil
+}
+
+// deriveKeyPair follows RFC 9180, section 7.1.3, for X25519.
+func (x *x25519Group) deriveKeyPair(k *dhkem, prk []byte) (kyber.Scalar, error) {
+	return x.deserializePrivateKey(k.labeledExpand(prk, "sk", nil, x.nsk()))
+}
+
+// dh returns the u-coordinate of X25519(k, P), where x = k mod L. As k is a
+// multiple of the cofactor 8, it is (x/8)*(8*P).
+func (x *x25519Group) dh(s kyber.Scalar, P kyber.Point) ([]byte, error) {
+	eight := x.g.Scalar().SetInt64(8)
+	Z := x.g.Point().Mul(eight, P)
+	Z.Mul(x.g.Scalar().Div(s, eight), Z)
+	if Z.Equal(x.g.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	return x.serializePublicKey(Z)
+}
@@ -0,0 +1,340 @@
+package timelock
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// The age v1 file format, https://age-encryption.org/v1: a textual header
+// with a stanza per recipient, each wrapping the 16-byte file key, and the
+// MAC of the header with the file key, followed by the binary payload: a
+// 16-byte nonce and the chunks of the STREAM encryption of the plaintext
+// with ChaCha20-Poly1305.
+const (
+	ageIntro    = "age-encryption.org/v1"
+	fileKeyLen  = 16
+	nonceLen    = 16
+	chunkSize   = 64 * 1024
+	columnsLen  = 64
+	stanzaStart = "-> "
+	macStart    = "---"
+)
+
+// stanza is a recipient stanza of an age header.
+type stanza struct {
+	typ  string
+	args []string
+	body []byte
+}
+
+func (s *stanza) marshal(w io.Writer) error {
+	line := stanzaStart + strings.Join(append([]string{s.typ}, s.args...), " ")
+	if _, err := io.WriteString(w, line+"\n"); err != nil {
+		return err
+	}
+	// The body is wrapped at 64 columns, and ends with a shorter line
+	b64 := base64.RawStdEncoding.EncodeToString(s.body)
+	for {
+		n := min(len(b64), columnsLen)
+		if _, err := io.WriteString(w, b64[:n]+"\n"); err != nil {
+			return err
+		}
+		b64 = b64[n:]
+		if n < columnsLen {
+			return nil
+		}
+	}
+}
+
+// deriveKey returns HKDF-SHA-256 of the file key with the salt and the info.
+func deriveKey(fileKey, salt []byte, info string) []byte {
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, fileKey, salt, []byte(info)), key); err != nil {
+		panic("timelock: " + err.Error())
+	}
+	return key
+}
+
+// headerMAC returns the MAC of the header, up to and including "---".
+func headerMAC(fileKey, header []byte) []byte {
+	h := hmac.New(sha256.New, deriveKey(fileKey, nil, "header"))
+	_, _ = h.Write(header)
+	return h.Sum(nil)
+}
+
+// encryptAge writes the header with the stanzas and the nonce of the payload
+// to dst, and returns the writer of the plaintext, which must be closed.
+func encryptAge(dst io.Writer, fileKey []byte, stanzas []*stanza) (io.WriteCloser, error) {
+	var header bytes.Buffer
+	header.WriteString(ageIntro + "\n")
+	for _, s := range stanzas {
+		if err := s.marshal(&header); err != nil {
+			return nil, err
+		}
+	}
+	header.WriteString(macStart)
+	mac := headerMAC(fileKey, header.Bytes())
+	header.WriteString(" " + base64.RawStdEncoding.EncodeToString(mac) + "\n")
+
+	nonce := make([]byte, nonceLen)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	header.Write(nonce)
+	if _, err := dst.Write(header.Bytes()); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.New(deriveKey(fileKey, nonce, "payload"))
+	if err != nil {
+		return nil, err
+	}
+	return &payloadWriter{aead: aead, w: dst, buf: make([]byte, 0, chunkSize+aead.Overhead())}, nil
+}
+
+// header is a parsed age header.
+type header struct {
+	stanzas []*stanza
+	// the bytes of the header covered by the MAC
+	raw []byte
+	mac []byte
+}
+
+var errInvalidHeader = errors.New("timelock: invalid age header")
+
+// readLine returns the next line of the header, without the newline.
+func readLine(r *bufio.Reader, raw *bytes.Buffer) (string, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return "", errInvalidHeader
+	}
+	raw.Write(line)
+	return string(line[:len(line)-1]), nil
+}
+
+// validArg tells if s is a non-empty string of visible ASCII characters.
+func validArg(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func parseHeader(r *bufio.Reader) (*header, error) {
+	var raw bytes.Buffer
+	line, err := readLine(r, &raw)
+	if err != nil || line != ageIntro {
+		return nil, errInvalidHeader
+	}
+	h := &header{}
+	for {
+		line, err := readLine(r, &raw)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(line, macStart+" ") {
+			h.raw = raw.Bytes()[:raw.Len()-len(line)-1+len(macStart)]
+			if h.mac, err = base64.RawStdEncoding.Strict().DecodeString(line[len(macStart)+1:]); err != nil ||
+				len(h.mac) != sha256.Size {
+				return nil, errInvalidHeader
+			}
+			return h, nil
+		}
+		if !strings.HasPrefix(line, stanzaStart) {
+			return nil, errInvalidHeader
+		}
+		fields := strings.Split(line[len(stanzaStart):], " ")
+		for _, f := range fields {
+			if !validArg(f) {
+				return nil, errInvalidHeader
+			}
+		}
+		var b64 strings.Builder
+		for {
+			line, err := readLine(r, &raw)
+			if err != nil {
+				return nil, err
+			}
+			if len(line) > columnsLen {
+				return nil, errInvalidHeader
+			}
+			b64.WriteString(line)
+			if len(line) < columnsLen {
+				break
+			}
+		}
+		body, err := base64.RawStdEncoding.Strict().DecodeString(b64.String())
+		if err != nil {
+			return nil, errInvalidHeader
+		}
+		h.stanzas = append(h.stanzas, &stanza{typ: fields[0], args: fields[1:], body: body})
+	}
+}
+
+// decryptAge returns the reader of the plaintext of the payload following
+// the header, once the header MAC is checked with the file key.
+func decryptAge(r *bufio.Reader, h *header, fileKey []byte) (io.Reader, error) {
+	if !hmac.Equal(headerMAC(fileKey, h.raw), h.mac) {
+		return nil, errors.New("timelock: invalid header MAC")
+	}
+	nonce := make([]byte, nonceLen)
+	if _, err := io.ReadFull(r, nonce); err != nil {
+		return nil, fmt.Errorf("timelock: reading the payload nonce: %w", err)
+	}
+	aead, err := chacha20poly1305.New(deriveKey(fileKey, nonce, "payload"))
+	if err != nil {
+		return nil, err
+	}
+	return &payloadReader{aead: aead, r: r, buf: make([]byte, chunkSize+aead.Overhead())}, nil
+}
+
+// payloadNonce is the nonce of the STREAM chunks: an 11-byte big-endian
+// counter and the last chunk flag.
+type payloadNonce [chacha20poly1305.NonceSize]byte
+
+func (n *payloadNonce) next(last bool) ([]byte, error) {
+	nonce := *n
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	// increment the counter
+	for i := len(n) - 2; i >= 0; i-- {
+		n[i]++
+		if n[i] != 0 {
+			return nonce[:], nil
+		}
+	}
+	return nil, errors.New("timelock: payload too long")
+}
+
+type payloadWriter struct {
+	aead   cipher.AEAD
+	nonce  payloadNonce
+	w      io.Writer
+	buf    []byte
+	closed bool
+	failed error
+}
+
+// Write encrypts p. The chunks are written once it is known that they are
+// not the last one, which is only empty if the whole payload is.
+func (p *payloadWriter) Write(data []byte) (int, error) {
+	if p.closed {
+		return 0, errors.New("timelock: write to closed writer")
+	}
+	if p.failed != nil {
+		return 0, p.failed
+	}
+	n := 0
+	for len(data) > 0 {
+		if len(p.buf) == chunkSize {
+			if err := p.flush(false); err != nil {
+				return n, err
+			}
+		}
+		m := min(len(data), chunkSize-len(p.buf))
+		p.buf = append(p.buf, data[:m]...)
+		data = data[m:]
+		n += m
+	}
+	return n, nil
+}
+
+// Close writes the last chunk, but doesn't close the underlying writer.
+func (p *payloadWriter) Close() error {
+	if p.closed {
+		return nil
+	}
+	if p.failed != nil {
+		return p.failed
+	}
+	p.closed = true
+	return p.flush(true)
+}
+
+func (p *payloadWriter) flush(last bool) error {
+	nonce, err := p.nonce.next(last)
+	if err == nil {
+		_, err = p.w.Write(p.aead.Seal(p.buf[:0], nonce, p.buf, nil))
+	}
+	p.buf = p.buf[:0]
+	p.failed = err
+	return err
+}
+
+type payloadReader struct {
+	aead  cipher.AEAD
+	nonce payloadNonce
+	r     *bufio.Reader
+	buf   []byte
+	pt    []byte
+	// whether a chunk was read
+	started bool
+	done    bool
+	failed  error
+}
+
+func (p *payloadReader) Read(data []byte) (int, error) {
+	for len(p.pt) == 0 {
+		if p.failed != nil {
+			return 0, p.failed
+		}
+		if p.done {
+			return 0, io.EOF
+		}
+		p.failed = p.readChunk()
+	}
+	n := copy(data, p.pt)
+	p.pt = p.pt[n:]
+	return n, nil
+}
+
+// readChunk decrypts the next chunk, which is the last one if it is shorter
+// than a full chunk or if it ends the payload.
+func (p *payloadReader) readChunk() error {
+	n, err := io.ReadFull(p.r, p.buf)
+	last := false
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := p.r.Peek(1); errors.Is(err, io.EOF) {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if n < p.aead.Overhead() {
+		return errors.New("timelock: truncated payload")
+	}
+	nonce, err := p.nonce.next(last)
+	if err != nil {
+		return err
+	}
+	pt, err := p.aead.Open(p.buf[:0], nonce, p.buf[:n], nil)
+	if err != nil {
+		return errors.New("timelock: invalid payload chunk")
+	}
+	if last && len(pt) == 0 && p.started {
+		return errors.New("timelock: empty last chunk")
+	}
+	p.pt = pt
+	p.started = true
+	p.done = last
+	return nil
+}
@@ -0,0 +1,162 @@
+package timelock
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+)
+
+// The ASCII armor of age files: the padded base64 encoding of the file,
+// wrapped at 64 columns, between these two lines.
+const (
+	armorBegin = "-----BEGIN AGE ENCRYPTED FILE-----"
+	armorEnd   = "-----END AGE ENCRYPTED FILE-----"
+)
+
+var errInvalidArmor = errors.New("timelock: invalid armor")
+
+// lineWriter wraps the data written to it at 64 columns.
+type lineWriter struct {
+	w      io.Writer
+	column int
+}
+
+func (l *lineWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if l.column == columnsLen {
+			if _, err := l.w.Write([]byte("\n")); err != nil {
+				return n, err
+			}
+			l.column = 0
+		}
+		m := min(len(p), columnsLen-l.column)
+		if _, err := l.w.Write(p[:m]); err != nil {
+			return n, err
+		}
+		l.column += m
+		p = p[m:]
+		n += m
+	}
+	return n, nil
+}
+
+type armorWriter struct {
+	w       io.Writer
+	lines   *lineWriter
+	encoder io.WriteCloser
+	started bool
+}
+
+// NewArmorWriter returns a writer which writes the data written to it to w
+// in the ASCII armor of age, e.g. for an encrypted file to be sent as text.
+// The writer must be closed, but doesn't close w.
+func NewArmorWriter(w io.Writer) io.WriteCloser {
+	lines := &lineWriter{w: w}
+	return &armorWriter{w: w, lines: lines, encoder: base64.NewEncoder(base64.StdEncoding, lines)}
+}
+
+func (a *armorWriter) begin() error {
+	if a.started {
+		return nil
+	}
+	a.started = true
+	_, err := io.WriteString(a.w, armorBegin+"\n")
+	return err
+}
+
+func (a *armorWriter) Write(p []byte) (int, error) {
+	if err := a.begin(); err != nil {
+		return 0, err
+	}
+	return a.encoder.Write(p)
+}
+
+func (a *armorWriter) Close() error {
+	if err := a.begin(); err != nil {
+		return err
+	}
+	if err := a.encoder.Close(); err != nil {
+		return err
+	}
+	end := armorEnd + "\n"
+	if a.lines.column > 0 {
+		end = "\n" + end
+	}
+	_, err := io.WriteString(a.w, end)
+	return err
+}
+
+type armorReader struct {
+	r       *bufio.Reader
+	buf     []byte
+	started bool
+	done    bool
+	err     error
+}
+
+// NewArmorReader returns a reader of the data in the ASCII armor of age of
+// r. Decrypt detects the armor by itself.
+func NewArmorReader(r io.Reader) io.Reader {
+	return &armorReader{r: bufio.NewReader(r)}
+}
+
+// readLine returns the next line of the armor, without the newline, which
+// the last line may lack.
+func (a *armorReader) readLine() (string, error) {
+	line, err := a.r.ReadSlice('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || len(line) == 0) {
+		return "", errInvalidArmor
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+}
+
+func (a *armorReader) Read(p []byte) (int, error) {
+	for len(a.buf) == 0 {
+		if a.err != nil {
+			return 0, a.err
+		}
+		if a.done {
+			return 0, io.EOF
+		}
+		a.err = a.next()
+	}
+	n := copy(p, a.buf)
+	a.buf = a.buf[n:]
+	return n, nil
+}
+
+// next decodes the next line of the armor. The lines are full but the last
+// one, which is followed by the end line and is the only padded one.
+func (a *armorReader) next() error {
+	if !a.started {
+		line, err := a.readLine()
+		if err != nil || line != armorBegin {
+			return errInvalidArmor
+		}
+		a.started = true
+	}
+	line, err := a.readLine()
+	if err != nil {
+		return err
+	}
+	if line == armorEnd {
+		a.done = true
+		return nil
+	}
+	if len(line) > columnsLen || line == "" {
+		return errInvalidArmor
+	}
+	if a.buf, err = base64.StdEncoding.Strict().DecodeString(line); err != nil {
+		return errInvalidArmor
+	}
+	if len(line) < columnsLen || strings.HasSuffix(line, "=") {
+		if end, err := a.readLine(); err != nil || end != armorEnd {
+			return errInvalidArmor
+		}
+		a.done = true
+	}
+	return nil
+}
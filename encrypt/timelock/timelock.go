@@ -0,0 +1,230 @@
+// Package timelock implements the timelock encryption of drand's tlock, on
+// top of the identity-based encryption of the encrypt/ibe package.
+//
+// A threshold BLS beacon, such as a drand network with an unchained scheme,
+// signs each round with its distributed key, as in the sign/tbls package.
+// The signature of a round is thus the IBE private key, for the master
+// public key of the beacon, of the identity of the round: the message
+// RoundID(round) that the beacon signs. A message encrypted to a future
+// round can be decrypted by anyone once the beacon publishes the signature
+// of the round, and not before.
+//
+// Encrypt and Decrypt process the files of tlock, in the age v1 format: the
+// 16-byte file key of the age file is encrypted with ibe.EncryptCCAonG1 or
+// ibe.EncryptCCAonG2 in a "tlock" recipient stanza, whose arguments are the
+// round and the hexadecimal chain hash of the beacon. The files can be
+// armored with NewArmorWriter, as with tlock's --armor flag.
+package timelock
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/encrypt/ibe"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/sign/bls"
+)
+
+// the type of the recipient stanzas of tlock
+const stanzaType = "tlock"
+
+var (
+	// ErrInvalidPublicKey is returned for the null public key.
+	ErrInvalidPublicKey = errors.New("timelock: invalid public key")
+	// ErrInvalidSignature is returned when the signature of a round isn't
+	// the valid signature of the beacon.
+	ErrInvalidSignature = errors.New("timelock: invalid signature")
+	// ErrWrongChainHash is returned when a file is encrypted to another
+	// beacon.
+	ErrWrongChainHash = errors.New("timelock: wrong chain hash")
+	// ErrNoStanza is returned when a file has no tlock stanza.
+	ErrNoStanza = errors.New("timelock: no tlock stanza")
+	// ErrTooEarly is the error that the signature functions of Decrypt
+	// should return for the rounds that the beacon hasn't signed yet.
+	ErrTooEarly = errors.New("timelock: too early to decrypt")
+)
+
+// Beacon is the public information of a threshold BLS beacon: its master
+// public key and its chain hash.
+type Beacon struct {
+	suite     pairing.Suite
+	public    kyber.Point
+	chainHash []byte
+	sigsOnG1  bool
+	scheme    sign.Scheme
+}
+
+// NewBeaconOnG1 returns the beacon whose signatures are on G1, with the
+// hash to curve of RFC 9380, and whose public key is on G2, as the
+// bls-unchained-g1-rfc9380 scheme of drand's quicknet.
+func NewBeaconOnG1(suite pairing.Suite, public kyber.Point, chainHash []byte) (*Beacon, error) {
+	return newBeacon(suite, public, chainHash, true, bls.NewSchemeOnG1(suite))
+}
+
+// NewBeaconOnG2 returns the beacon whose signatures are on G2 and whose
+// public key is on G1, as the pedersen-bls-unchained scheme of drand.
+func NewBeaconOnG2(suite pairing.Suite, public kyber.Point, chainHash []byte) (*Beacon, error) {
+	return newBeacon(suite, public, chainHash, false, bls.NewSchemeOnG2(suite))
+}
+
+func newBeacon(suite pairing.Suite, public kyber.Point, chainHash []byte, sigsOnG1 bool, scheme sign.Scheme) (
+	*Beacon, error) {
+	if public.Equal(public.Clone().Null()) {
+		return nil, ErrInvalidPublicKey
+	}
+	return &Beacon{suite: suite, public: public, chainHash: chainHash, sigsOnG1: sigsOnG1, scheme: scheme}, nil
+}
+
+// RoundID returns the identity of the round, the SHA-256 hash of its 8-byte
+// big-endian encoding, which is the message that an unchained beacon signs.
+func RoundID(round uint64) []byte {
+	h := sha256.New()
+	_ = binary.Write(h, binary.BigEndian, round)
+	return h.Sum(nil)
+}
+
+// Lock encrypts the data, of at most 32 bytes, to the round.
+func (b *Beacon) Lock(round uint64, data []byte) (*ibe.Ciphertext, error) {
+	if b.sigsOnG1 {
+		return ibe.EncryptCCAonG2(b.suite, b.public, RoundID(round), data)
+	}
+	return ibe.EncryptCCAonG1(b.suite, b.public, RoundID(round), data)
+}
+
+// Unlock decrypts the ciphertext of Lock for the round with the signature of
+// the round.
+func (b *Beacon) Unlock(round uint64, signature []byte, ct *ibe.Ciphertext) ([]byte, error) {
+	if err := b.scheme.Verify(b.public, RoundID(round), signature); err != nil {
+		return nil, fmt.Errorf("%w of round %d: %w", ErrInvalidSignature, round, err)
+	}
+	if b.sigsOnG1 {
+		private := b.suite.G1().Point()
+		if err := private.UnmarshalBinary(signature); err != nil {
+			return nil, err
+		}
+		return ibe.DecryptCCAonG2(b.suite, private, ct)
+	}
+	private := b.suite.G2().Point()
+	if err := private.UnmarshalBinary(signature); err != nil {
+		return nil, err
+	}
+	return ibe.DecryptCCAonG1(b.suite, private, ct)
+}
+
+// Encrypt writes to dst the tlock file of the data of src, encrypted to the
+// round.
+func (b *Beacon) Encrypt(dst io.Writer, src io.Reader, round uint64) error {
+	fileKey := make([]byte, fileKeyLen)
+	if _, err := rand.Read(fileKey); err != nil {
+		return err
+	}
+	ct, err := b.Lock(round, fileKey)
+	if err != nil {
+		return err
+	}
+	body, err := ct.U.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	body = append(append(body, ct.V...), ct.W...)
+
+	w, err := encryptAge(dst, fileKey, []*stanza{{
+		typ:  stanzaType,
+		args: []string{strconv.FormatUint(round, 10), hex.EncodeToString(b.chainHash)},
+		body: body,
+	}})
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, src); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Decrypt writes to dst the data of the tlock file of src, armored or not,
+// with the signature of its round returned by the signature function, e.g.
+// fetched from the beacon. The data is written as it is authenticated, so
+// that dst may have received part of it when an error is returned.
+func (b *Beacon) Decrypt(dst io.Writer, src io.Reader, signature func(round uint64) ([]byte, error)) error {
+	r := bufio.NewReader(src)
+	if start, _ := r.Peek(len(armorBegin)); bytes.Equal(start, []byte(armorBegin)) {
+		r = bufio.NewReader(NewArmorReader(r))
+	}
+	h, err := parseHeader(r)
+	if err != nil {
+		return err
+	}
+
+	var fileKey []byte
+	for _, s := range h.stanzas {
+		if s.typ != stanzaType {
+			continue
+		}
+		if fileKey, err = b.unwrap(s, signature); err != nil {
+			return err
+		}
+		break
+	}
+	if fileKey == nil {
+		return ErrNoStanza
+	}
+
+	payload, err := decryptAge(r, h, fileKey)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, payload)
+	return err
+}
+
+// unwrap returns the file key of the tlock stanza.
+func (b *Beacon) unwrap(s *stanza, signature func(round uint64) ([]byte, error)) ([]byte, error) {
+	if len(s.args) != 2 {
+		return nil, errInvalidHeader
+	}
+	round, err := strconv.ParseUint(s.args[0], 10, 64)
+	if err != nil {
+		return nil, errInvalidHeader
+	}
+	chainHash, err := hex.DecodeString(s.args[1])
+	if err != nil {
+		return nil, errInvalidHeader
+	}
+	if !bytes.Equal(chainHash, b.chainHash) {
+		return nil, ErrWrongChainHash
+	}
+
+	U := b.suite.G1().Point()
+	if b.sigsOnG1 {
+		U = b.suite.G2().Point()
+	}
+	l := U.MarshalSize()
+	if len(s.body) != l+2*fileKeyLen {
+		return nil, errInvalidHeader
+	}
+	if err := U.UnmarshalBinary(s.body[:l]); err != nil {
+		return nil, errInvalidHeader
+	}
+	ct := &ibe.Ciphertext{U: U, V: s.body[l : l+fileKeyLen], W: s.body[l+fileKeyLen:]}
+
+	sig, err := signature(round)
+	if err != nil {
+		return nil, fmt.Errorf("timelock: signature of round %d: %w", round, err)
+	}
+	fileKey, err := b.Unlock(round, sig, ct)
+	if err != nil {
+		return nil, err
+	}
+	return fileKey, nil
+}
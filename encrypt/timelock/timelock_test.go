@@ -0,0 +1,338 @@
+package timelock
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/sign/tbls"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// testBeacon is a threshold beacon whose nodes sign the rounds with tbls.
+type testBeacon struct {
+	*Beacon
+	scheme sign.ThresholdScheme
+	shares []*share.PriShare
+	public *share.PubPoly
+	t      int
+	// the last round signed
+	current uint64
+}
+
+func newTestBeacon(t *testing.T, sigsOnG1 bool) *testBeacon {
+	suite := circl.NewSuiteBLS12381()
+	keyGroup, scheme := kyber.Group(suite.G1()), tbls.NewThresholdSchemeOnG2(suite)
+	newBeacon := NewBeaconOnG2
+	if sigsOnG1 {
+		keyGroup, scheme = suite.G2(), tbls.NewThresholdSchemeOnG1(suite)
+		newBeacon = NewBeaconOnG1
+	}
+	n, threshold := 5, 3
+	poly := share.NewPriPoly(keyGroup, threshold, nil, random.New())
+	public := poly.Commit(keyGroup.Point().Base())
+	chainHash := make([]byte, 32)
+	_, _ = rand.Read(chainHash)
+	b, err := newBeacon(suite, public.Commit(), chainHash)
+	require.NoError(t, err)
+	return &testBeacon{Beacon: b, scheme: scheme, shares: poly.Shares(n), public: public, t: threshold, current: 10}
+}
+
+// signature returns the signature of the round, recovered from the partial
+// signatures of a threshold of nodes.
+func (b *testBeacon) signature(round uint64) ([]byte, error) {
+	if round > b.current {
+		return nil, ErrTooEarly
+	}
+	msg := RoundID(round)
+	var partials [][]byte
+	for _, s := range b.shares[:b.t] {
+		partial, err := b.scheme.Sign(s, msg)
+		if err != nil {
+			return nil, err
+		}
+		partials = append(partials, partial)
+	}
+	return b.scheme.Recover(b.public, msg, partials, b.t, len(b.shares))
+}
+
+func TestRoundID(t *testing.T) {
+	require.Equal(t, "cd2662154e6d76b2b2b92e70c0cac3ccf534f9b74eb5b89819ec509083d00a50", hex.EncodeToString(RoundID(1)))
+}
+
+func TestLockUnlock(t *testing.T) {
+	for _, sigsOnG1 := range []bool{true, false} {
+		b := newTestBeacon(t, sigsOnG1)
+		key := []byte("deadbeefdeadbeef")
+		ct, err := b.Lock(7, key)
+		require.NoError(t, err)
+
+		sig, err := b.signature(7)
+		require.NoError(t, err)
+		plain, err := b.Unlock(7, sig, ct)
+		require.NoError(t, err)
+		require.Equal(t, key, plain)
+
+		// The signature of another round
+		other, err := b.signature(8)
+		require.NoError(t, err)
+		_, err = b.Unlock(7, other, ct)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+		_, err = b.Unlock(8, other, ct)
+		require.Error(t, err)
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, sigsOnG1 := range []bool{true, false} {
+		b := newTestBeacon(t, sigsOnG1)
+		for _, size := range []int{0, 1, 48, chunkSize, chunkSize + 1, 2*chunkSize + 100} {
+			for _, armor := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%v-%d-%v", sigsOnG1, size, armor), func(t *testing.T) {
+					data := make([]byte, size)
+					_, _ = rand.Read(data)
+
+					var file bytes.Buffer
+					dst := io.WriteCloser(nopCloser{&file})
+					if armor {
+						dst = NewArmorWriter(&file)
+					}
+					require.NoError(t, b.Encrypt(dst, bytes.NewReader(data), 5))
+					require.NoError(t, dst.Close())
+					if armor {
+						for _, line := range strings.Split(strings.TrimSuffix(file.String(), "\n"), "\n") {
+							require.LessOrEqual(t, len(line), columnsLen)
+						}
+					}
+
+					var out bytes.Buffer
+					require.NoError(t, b.Decrypt(&out, bytes.NewReader(file.Bytes()), b.signature))
+					require.Equal(t, data, out.Bytes())
+				})
+			}
+		}
+	}
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestFormat(t *testing.T) {
+	b := newTestBeacon(t, true)
+	var file bytes.Buffer
+	require.NoError(t, b.Encrypt(&file, strings.NewReader("hello"), 1234))
+
+	r := bufio.NewReader(bytes.NewReader(file.Bytes()))
+	h, err := parseHeader(r)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(file.String(),
+		"age-encryption.org/v1\n-> tlock 1234 "+hex.EncodeToString(b.chainHash)+"\n"))
+	require.Len(t, h.stanzas, 1)
+	// U on G2, and V and W of the length of the file key
+	require.Len(t, h.stanzas[0].body, 96+2*fileKeyLen)
+
+	// The nonce, and a single chunk of 5 bytes
+	rest, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Len(t, rest, nonceLen+5+16)
+}
+
+func TestDecryptErrors(t *testing.T) {
+	b := newTestBeacon(t, false)
+	data := make([]byte, chunkSize+10)
+	_, _ = rand.Read(data)
+	encrypt := func(round uint64) []byte {
+		var file bytes.Buffer
+		require.NoError(t, b.Encrypt(&file, bytes.NewReader(data), round))
+		return file.Bytes()
+	}
+	decrypt := func(b *Beacon, file []byte) error {
+		return b.Decrypt(io.Discard, bytes.NewReader(file), newTestBeacon(t, false).signature)
+	}
+	file := encrypt(3)
+
+	// A round not signed yet
+	err := b.Decrypt(io.Discard, bytes.NewReader(encrypt(11)), b.signature)
+	require.ErrorIs(t, err, ErrTooEarly)
+
+	// Another beacon, and the same one with another chain hash
+	other := newTestBeacon(t, false)
+	require.ErrorIs(t, other.Decrypt(io.Discard, bytes.NewReader(file), other.signature), ErrWrongChainHash)
+	sameKey, err := NewBeaconOnG2(b.suite, b.Beacon.public, other.chainHash)
+	require.NoError(t, err)
+	require.ErrorIs(t, decrypt(sameKey, file), ErrWrongChainHash)
+	// The signatures of another beacon
+	require.ErrorIs(t, decrypt(b.Beacon, file), ErrInvalidSignature)
+
+	// A modified header, payload nonce, chunk and a truncated payload
+	end := bytes.Index(file, []byte("\n---")) + 1
+	for _, i := range []int{30, end - 2, len(file) - chunkSize - 30, len(file) - 5} {
+		modified := append([]byte{}, file...)
+		modified[i] ^= 1
+		require.Error(t, b.Decrypt(io.Discard, bytes.NewReader(modified), b.signature))
+	}
+	require.Error(t, b.Decrypt(io.Discard, bytes.NewReader(file[:len(file)-27]), b.signature))
+	require.Error(t, b.Decrypt(io.Discard, bytes.NewReader(file[:len(file)-chunkSize/2]), b.signature))
+
+	// The stanzas of other types are skipped, but covered by the MAC
+	i := bytes.Index(file, []byte("-> tlock"))
+	grease := append([]byte{}, file[:i]...)
+	grease = append(grease, "-> grease 1 2\n"+base64.RawStdEncoding.EncodeToString([]byte("body"))+"\n"...)
+	grease = append(grease, file[i:]...)
+	require.ErrorContains(t, b.Decrypt(io.Discard, bytes.NewReader(grease), b.signature), "MAC")
+
+	noStanza := []byte("age-encryption.org/v1\n-> X25519 abc\n\n--- " +
+		base64.RawStdEncoding.EncodeToString(make([]byte, 32)) + "\n")
+	require.ErrorIs(t, b.Decrypt(io.Discard, bytes.NewReader(noStanza), b.signature), ErrNoStanza)
+	require.Error(t, b.Decrypt(io.Discard, strings.NewReader("age-encryption.org/v2\n"), b.signature))
+}
+
+func TestArmor(t *testing.T) {
+	for _, size := range []int{0, 1, 47, 48, 49, 96, 1000} {
+		data := make([]byte, size)
+		_, _ = rand.Read(data)
+		var armored bytes.Buffer
+		w := NewArmorWriter(&armored)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		text := armored.String()
+		require.True(t, strings.HasPrefix(text, armorBegin+"\n"))
+		require.True(t, strings.HasSuffix(text, "\n"+armorEnd+"\n"))
+
+		for _, variant := range []string{text, strings.ReplaceAll(text, "\n", "\r\n"), strings.TrimSuffix(text, "\n")} {
+			decoded, err := io.ReadAll(NewArmorReader(strings.NewReader(variant)))
+			require.NoError(t, err)
+			require.Equal(t, data, decoded)
+		}
+	}
+
+	for _, invalid := range []string{
+		"",
+		armorBegin + "\n",
+		armorBegin + "\nAAAA\n",
+		armorBegin + "\nAA==\nAAAA\n" + armorEnd + "\n",
+		armorBegin + "\nAA=A\n" + armorEnd + "\n",
+		armorBegin + "\n" + strings.Repeat("A", 68) + "\n" + armorEnd + "\n",
+	} {
+		_, err := io.ReadAll(NewArmorReader(strings.NewReader(invalid)))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestInvalidBeacon(t *testing.T) {
+	var suite pairing.Suite = circl.NewSuiteBLS12381()
+	_, err := NewBeaconOnG1(suite, suite.G2().Point().Null(), nil)
+	require.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+// The public key, chain hash and signatures of rounds 1 and 1000 of drand's
+// quicknet beacon, from https://api.drand.sh/52db9ba70e0cc0f6eaf7803dd07447a1f5477735fd3f661792ba94600c84e971.
+const (
+	quicknetPublic = "83cf0f2896adee7eb8b5f01fcad3912212c437e0073e911fb90022d3e760183c" +
+		"8c4b450b6a0a6c3ac6a5776a2d1064510d1fec758c921cc22b0e17e63aaf4bcb" +
+		"5ed66304de9cf809bd274ca73bab4af5a6e9c76a4bc09e76eae8991ef5ece45a"
+	quicknetChainHash = "52db9ba70e0cc0f6eaf7803dd07447a1f5477735fd3f661792ba94600c84e971"
+)
+
+var quicknetSignatures = map[uint64]string{
+	1: "b55e7cb2d5c613ee0b2e28d6750aabbb78c39dcc96bd9d38c2c2e12198df9557" +
+		"1de8e8e402a0cc48871c7089a2b3af4b",
+	1000: "b44679b9a59af2ec876b1a6b1ad52ea9b1615fc3982b19576350f93447cb1125" +
+		"e342b73a8dd2bacbe47e4b6b63ed5e39",
+}
+
+// quicknet returns the quicknet beacon.
+func quicknet(t *testing.T) *Beacon {
+	suite := circl.NewSuiteBLS12381()
+	pub, err := hex.DecodeString(quicknetPublic)
+	require.NoError(t, err)
+	public := suite.G2().Point()
+	require.NoError(t, public.UnmarshalBinary(pub))
+	chainHash, err := hex.DecodeString(quicknetChainHash)
+	require.NoError(t, err)
+	b, err := NewBeaconOnG1(suite, public, chainHash)
+	require.NoError(t, err)
+	return b
+}
+
+// quicknetSignature returns the signature of the quicknet round, or
+// ErrTooEarly if it isn't in quicknetSignatures.
+func quicknetSignature(round uint64) ([]byte, error) {
+	sig, ok := quicknetSignatures[round]
+	if !ok {
+		return nil, ErrTooEarly
+	}
+	return hex.DecodeString(sig)
+}
+
+func TestQuicknet(t *testing.T) {
+	b := quicknet(t)
+	signature := quicknetSignature
+
+	// the signatures of the beacon are the private keys of the rounds
+	data := []byte("Hello quicknet")
+	for round := range quicknetSignatures {
+		sig, err := signature(round)
+		require.NoError(t, err)
+		ct, err := b.Lock(round, data)
+		require.NoError(t, err)
+		out, err := b.Unlock(round, sig, ct)
+		require.NoError(t, err)
+		require.Equal(t, data, out)
+
+		var file bytes.Buffer
+		require.NoError(t, b.Encrypt(&file, bytes.NewReader(data), round))
+		require.Contains(t, file.String(), fmt.Sprintf("-> tlock %d %s\n", round, quicknetChainHash))
+		var plain bytes.Buffer
+		require.NoError(t, b.Decrypt(&plain, &file, signature))
+		require.Equal(t, data, plain.Bytes())
+	}
+
+	// the signature of another round
+	sig, err := signature(1)
+	require.NoError(t, err)
+	ct, err := b.Lock(1000, data)
+	require.NoError(t, err)
+	_, err = b.Unlock(1000, sig, ct)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	var file bytes.Buffer
+	require.NoError(t, b.Encrypt(&file, bytes.NewReader(data), 1001))
+	require.ErrorIs(t, b.Decrypt(io.Discard, &file, signature), ErrTooEarly)
+}
+
+// The files of testdata were encrypted by tlock v1.1.1 to the round 1000 of
+// quicknet, binary and armored.
+func TestTlockFiles(t *testing.T) {
+	b := quicknet(t)
+	expected := "Hello from tlock, for quicknet round 1000.\n"
+	for _, name := range []string{"quicknet-1000.age", "quicknet-1000.tle"} {
+		file, err := os.ReadFile("testdata/" + name)
+		require.NoError(t, err)
+		var out bytes.Buffer
+		require.NoError(t, b.Decrypt(&out, bytes.NewReader(file), quicknetSignature), name)
+		require.Equal(t, expected, out.String(), name)
+
+		// the file can't be decrypted with the signature of another round
+		other := func(uint64) ([]byte, error) { return quicknetSignature(1) }
+		require.ErrorIs(t, b.Decrypt(io.Discard, bytes.NewReader(file), other), ErrInvalidSignature, name)
+	}
+}
@@ -57,7 +57,9 @@ the proactively verifiable anonymous messaging scheme prototyped in Verdict
 
 For fancier versions of ElGamal encryption implemented in this toolkit
 see for example anon.Encrypt, which encrypts a message for
-one of several possible receivers forming an explicit anonymity set.
+one of several possible receivers forming an explicit anonymity set,
+or the encrypt/elgamal package, whose ciphertexts are decrypted
+by a threshold of the holders of the shares of a distributed key.
 */
 func Example_elGamalEncryption() {
 	suite := edwards25519.NewBlakeSHA256Ed25519()
@@ -7,6 +7,7 @@ toolchain go1.23.2
 require (
 	github.com/cloudflare/circl v1.3.9
 	github.com/consensys/gnark-crypto v0.12.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1
 	github.com/ethereum/go-ethereum v1.14.12
 	github.com/jonboulle/clockwork v0.4.0
 	github.com/kilic/bls12-381 v0.1.0
@@ -22,7 +23,6 @@ require (
 	github.com/bits-and-blooms/bitset v1.13.0 // indirect
 	github.com/consensys/bavard v0.1.13 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.0.1 // indirect
 	github.com/holiman/uint256 v1.3.1 // indirect
 	github.com/mmcloughlin/addchain v0.4.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
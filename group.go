@@ -141,6 +141,42 @@ type AllowsVarTime interface {
 	AllowVarTime(bool)
 }
 
+// MultiScalarMultiplier is an optional interface implemented by Points
+// of groups offering an optimized multi-scalar multiplication. Callers
+// should not use it directly but go through msm.MultiScalarMul, which
+// falls back to a generic implementation for the other groups.
+//
+// MultiScalarMul sets the receiver to the sum of scalars[i]*points[i].
+// A nil entry in points stands for the standard base point, as in
+// Point.Mul. Implementations run in variable time, thus they must only
+// be used on public Scalars and Points, never on secret ones.
+type MultiScalarMultiplier interface {
+	MultiScalarMul(scalars []Scalar, points []Point) Point
+}
+
+// FixedBase holds precomputed multiples of a fixed Point, so that its
+// repeated multiplication by different Scalars runs about as fast as the
+// multiplication of the standard base point. A FixedBase is never modified
+// after its creation, thus it can be shared between goroutines.
+type FixedBase interface {
+	// Mul returns a new Point set to s times the fixed Point.
+	Mul(s Scalar) Point
+}
+
+// FixedBasePrecomputer is an optional interface implemented by Points
+// of groups offering their own precomputed tables. Callers should not use
+// it directly but go through fixedbase.Precompute, which falls back to a
+// generic table for the other groups, or fixedbase.Native.
+//
+// Precompute returns the table of multiples of the receiver, which may
+// be modified afterwards without affecting the table. The multiplications
+// of the table run in constant time if the ones of the group do, as with
+// edwards25519, ristretto255, p256ct, ed448 and decaf448, so that they can
+// be used wherever Mul is, on secret Scalars as well.
+type FixedBasePrecomputer interface {
+	Precompute() FixedBase
+}
+
 // Group interface represents a mathematical group
 // usable for Diffie-Hellman key exchange, ElGamal encryption,
 // and the related body of public-key cryptographic algorithms
@@ -0,0 +1,90 @@
+// Package ed448 implements the Edwards448 curve of RFC 8032 and RFC 7748,
+// also known as Goldilocks, and the Decaf448 prime-order group of RFC 9496
+// built on it, providing about 224 bits of security.
+//
+// The field arithmetic comes from package
+// github.com/cloudflare/circl/math/fp448, and every group operation
+// depending on secret data runs in constant time. Ed448 points are encoded
+// in 57 bytes as in RFC 8032 and may lie outside of the prime-order
+// subgroup, like the Ed25519 points of package
+// go.dedis.ch/kyber/v4/group/edwards25519. Decaf448 elements are encoded
+// in 56 bytes, and the Decaf448 group has prime order, so every valid
+// encoding is a group element, and every element has a unique encoding.
+// Both groups share the same scalars, which are encoded in little-endian.
+//
+// [RFC8032]: https://datatracker.ietf.org/doc/html/rfc8032
+// [RFC9496]: https://datatracker.ietf.org/doc/html/rfc9496
+package ed448
+
+import (
+	"crypto/cipher"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+	"golang.org/x/crypto/sha3"
+)
+
+// SeedSize is the size of the seeds of the Ed448 private keys.
+const SeedSize = 57
+
+// Curve represents the Ed448 group. There are no parameters and no
+// initialization is required.
+type Curve struct {
+}
+
+// String returns the name of the curve, "Ed448".
+func (c *Curve) String() string {
+	return "Ed448"
+}
+
+// ScalarLen returns 56, the size in bytes of an encoded Scalar.
+func (c *Curve) ScalarLen() int {
+	return 56
+}
+
+// Scalar creates a new Scalar for the prime-order subgroup of the Ed448
+// curve.
+func (c *Curve) Scalar() kyber.Scalar {
+	return &scalar{}
+}
+
+// PointLen returns 57, the size in bytes of an encoded Point.
+func (c *Curve) PointLen() int {
+	return pointSize
+}
+
+// Point creates a new Point on the Ed448 curve.
+func (c *Curve) Point() kyber.Point {
+	return new(point)
+}
+
+// NewKeyAndSeedWithInput returns the Ed448 secret scalar derived from the
+// seed buffer as specified in RFC 8032, section 5.2.5, reduced modulo the
+// group order. It also returns the seed and the prefix used to generate
+// the signature nonces.
+func (c *Curve) NewKeyAndSeedWithInput(buffer []byte) (kyber.Scalar, []byte, []byte) {
+	var digest [2 * SeedSize]byte
+	sha3.ShakeSum256(digest[:], buffer)
+	digest[0] &= 0xfc
+	digest[55] |= 0x80
+	digest[56] = 0
+
+	secret := c.Scalar().(*scalar) //nolint:errcheck // Design pattern to emulate generics
+	secret.v.FromBytes(digest[:SeedSize])
+	return secret, buffer, digest[SeedSize:]
+}
+
+// NewKeyAndSeed returns a fresh Ed448 secret scalar, along with the seed
+// and the prefix used to generate the signature nonces.
+func (c *Curve) NewKeyAndSeed(stream cipher.Stream) (kyber.Scalar, []byte, []byte) {
+	var buffer [SeedSize]byte
+	random.Bytes(buffer[:], stream)
+	return c.NewKeyAndSeedWithInput(buffer[:])
+}
+
+// NewKey returns a fresh Ed448 secret scalar. NewKey implements the
+// kyber/util/key.Generator interface.
+func (c *Curve) NewKey(stream cipher.Stream) kyber.Scalar {
+	secret, _, _ := c.NewKeyAndSeed(stream)
+	return secret
+}
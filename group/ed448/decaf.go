@@ -0,0 +1,308 @@
+package ed448
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+)
+
+var marshalDecafID = [8]byte{'d', 'e', 'c', 'a', 'f', '.', 'p', 't'}
+
+// decafSize is the length of an encoded Decaf448 element.
+const decafSize = 56
+
+// Decaf448 represents the Decaf448 prime-order group of RFC 9496. There
+// are no parameters and no initialization is required.
+type Decaf448 struct {
+}
+
+// String returns the name of the group, "Decaf448".
+func (g *Decaf448) String() string {
+	return "Decaf448"
+}
+
+// ScalarLen returns 56, the size in bytes of an encoded Scalar.
+func (g *Decaf448) ScalarLen() int {
+	return 56
+}
+
+// Scalar creates a new Scalar modulo the order of the group, which is the
+// order of the prime-order subgroup of Ed448.
+func (g *Decaf448) Scalar() kyber.Scalar {
+	return &scalar{}
+}
+
+// PointLen returns 56, the size in bytes of an encoded Point.
+func (g *Decaf448) PointLen() int {
+	return decafSize
+}
+
+// Point creates a new Decaf448 Point.
+func (g *Decaf448) Point() kyber.Point {
+	return new(decafPoint)
+}
+
+// decafPoint is a Decaf448 element, represented by either of the two Ed448
+// points of its equivalence class. The representatives are multiples of 2,
+// and two of them are equivalent if they differ by the point (0, -1) of
+// order 2.
+type decafPoint struct {
+	ge extendedPoint
+}
+
+// decafEncode implements the encoding of RFC 9496, section 5.3.2.
+func decafEncode(p *extendedPoint) []byte {
+	var u1, u2, invSqrt, ratio, t, s fp.Elt
+	fp.Add(&u1, &p.X, &p.T)
+	fp.Sub(&t, &p.X, &p.T)
+	fp.Mul(&u1, &u1, &t)
+
+	fp.Sqr(&t, &p.X)
+	fp.Mul(&t, &t, &oneMinusD)
+	fp.Mul(&t, &t, &u1)
+	feSqrtRatio(&invSqrt, &feOne, &t)
+
+	fp.Mul(&ratio, &invSqrt, &u1)
+	fp.Mul(&ratio, &ratio, &sqrtMinusD)
+	feAbs(&ratio)
+
+	fp.Mul(&u2, &invSqrtMinusD, &ratio)
+	fp.Mul(&u2, &u2, &p.Z)
+	fp.Sub(&u2, &u2, &p.T)
+
+	fp.Mul(&s, &oneMinusD, &invSqrt)
+	fp.Mul(&s, &s, &p.X)
+	fp.Mul(&s, &s, &u2)
+	feAbs(&s)
+
+	s = feReduce(&s)
+	b := make([]byte, decafSize)
+	copy(b, s[:])
+	return b
+}
+
+// decafDecode implements the decoding of RFC 9496, section 5.3.1, which
+// rejects non-canonical and negative field elements.
+func decafDecode(p *extendedPoint, b []byte) error {
+	if len(b) != decafSize {
+		return errors.New("invalid Decaf448 encoding")
+	}
+	var s fp.Elt
+	copy(s[:], b)
+	r := feReduce(&s)
+	if subtle.ConstantTimeCompare(r[:], s[:]) != 1 || feIsNegative(&s) == 1 {
+		return errors.New("invalid Decaf448 encoding")
+	}
+
+	var ss, u1, u2, t, invSqrt, u3, x, y fp.Elt
+	fp.Sqr(&ss, &s)
+	fp.Add(&u1, &feOne, &ss)
+	fp.Sqr(&u2, &u1)
+	fp.Mul(&t, &dTimesFour, &ss)
+	fp.Sub(&u2, &u2, &t)
+
+	fp.Sqr(&t, &u1)
+	fp.Mul(&t, &t, &u2)
+	wasSquare := feSqrtRatio(&invSqrt, &feOne, &t)
+
+	fp.Add(&u3, &s, &s)
+	fp.Mul(&u3, &u3, &invSqrt)
+	fp.Mul(&u3, &u3, &u1)
+	fp.Mul(&u3, &u3, &sqrtMinusD)
+	feAbs(&u3)
+
+	fp.Mul(&x, &u3, &invSqrt)
+	fp.Mul(&x, &x, &u2)
+	fp.Mul(&x, &x, &invSqrtMinusD)
+
+	fp.Sub(&y, &feOne, &ss)
+	fp.Mul(&y, &y, &invSqrt)
+	fp.Mul(&y, &y, &u1)
+
+	if wasSquare == 0 {
+		return errors.New("invalid Decaf448 encoding")
+	}
+	*p = extendedPoint{X: x, Y: y, Z: feOne}
+	fp.Mul(&p.T, &x, &y)
+	return nil
+}
+
+func (P *decafPoint) String() string {
+	return hex.EncodeToString(decafEncode(&P.ge))
+}
+
+func (P *decafPoint) MarshalSize() int {
+	return decafSize
+}
+
+func (P *decafPoint) MarshalBinary() ([]byte, error) {
+	return decafEncode(&P.ge), nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (P *decafPoint) MarshalID() [8]byte {
+	return marshalDecafID
+}
+
+func (P *decafPoint) UnmarshalBinary(b []byte) error {
+	return decafDecode(&P.ge, b)
+}
+
+func (P *decafPoint) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *decafPoint) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+func (P *decafPoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *decafPoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
+// Equal tests whether two points represent the same group element, in
+// constant time. Two Ed448 points are in the same class if x1*y2 = y1*x2.
+func (P *decafPoint) Equal(P2 kyber.Point) bool {
+	Q := &P2.(*decafPoint).ge
+	var a, b fp.Elt
+	fp.Mul(&a, &P.ge.X, &Q.Y)
+	fp.Mul(&b, &P.ge.Y, &Q.X)
+	return feEqual(&a, &b) == 1
+}
+
+// Set point to be equal to P2.
+func (P *decafPoint) Set(P2 kyber.Point) kyber.Point {
+	P.ge = P2.(*decafPoint).ge
+	return P
+}
+
+// Clone returns a copy of the point.
+func (P *decafPoint) Clone() kyber.Point {
+	return &decafPoint{ge: P.ge}
+}
+
+// Null sets the point to the neutral element.
+func (P *decafPoint) Null() kyber.Point {
+	P.ge.zero()
+	return P
+}
+
+// Base sets the point to the generator of RFC 9496, which is represented
+// by twice the Ed448 base point.
+func (P *decafPoint) Base() kyber.Point {
+	P.ge.double(&baseext)
+	return P
+}
+
+func (P *decafPoint) EmbedLen() int {
+	// Reserve the least-significant byte, whose low bit must be zero,
+	// for pseudo-randomness, the next byte for the embedded data length,
+	// and the most-significant byte for pseudo-randomness.
+	return decafSize - 3
+}
+
+func (P *decafPoint) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		// Pick random bytes with the embedded data until they form
+		// a valid encoding, which happens about once in four tries.
+		var b [decafSize]byte
+		rand.XORKeyStream(b[:], b[:])
+		b[0] &= 0xfe
+		b[1] = byte(dl)
+		copy(b[2:2+dl], data)
+		if P.UnmarshalBinary(b[:]) == nil {
+			return P
+		}
+	}
+}
+
+// Pick sets the point to a uniformly random element, since every element
+// has exactly one encoding.
+func (P *decafPoint) Pick(rand cipher.Stream) kyber.Point {
+	for {
+		var b [decafSize]byte
+		rand.XORKeyStream(b[:], b[:])
+		if P.UnmarshalBinary(b[:]) == nil {
+			return P
+		}
+	}
+}
+
+// Data extracts the data embedded in a point with Embed.
+func (P *decafPoint) Data() ([]byte, error) {
+	b := decafEncode(&P.ge)
+	dl := int(b[1])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[2 : 2+dl], nil
+}
+
+func (P *decafPoint) Add(P1, P2 kyber.Point) kyber.Point {
+	P.ge.add(&P1.(*decafPoint).ge, &P2.(*decafPoint).ge)
+	return P
+}
+
+func (P *decafPoint) Sub(P1, P2 kyber.Point) kyber.Point {
+	P.ge.sub(&P1.(*decafPoint).ge, &P2.(*decafPoint).ge)
+	return P
+}
+
+func (P *decafPoint) Neg(A kyber.Point) kyber.Point {
+	P.ge.neg(&A.(*decafPoint).ge)
+	return P
+}
+
+// Mul multiplies point A by scalar s in constant time, or the base point
+// if A is nil.
+func (P *decafPoint) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	a := [56]byte(s.(*scalar).v)
+	if A == nil {
+		baseMult(&P.ge, &a)
+		P.ge.double(&P.ge)
+	} else {
+		scalarMult(&P.ge, &a, &A.(*decafPoint).ge)
+	}
+	return P
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs in constant time at about the
+// speed of the multiplication of the base point.
+func (P *decafPoint) Precompute() kyber.FixedBase {
+	fb := &decafFixedBase{}
+	fb.t.init(&P.ge)
+	return fb
+}
+
+type decafFixedBase struct {
+	t table
+}
+
+func (fb *decafFixedBase) Mul(s kyber.Scalar) kyber.Point {
+	a := [56]byte(s.(*scalar).v)
+	P := new(decafPoint)
+	fb.t.scalarMult(&P.ge, &a)
+	return P
+}
+
+// IsInCorrectGroup always returns true, since every Decaf448 element
+// belongs to the prime-order group.
+func (P *decafPoint) IsInCorrectGroup() bool {
+	return true
+}
@@ -0,0 +1,167 @@
+package ed448
+
+import (
+	"encoding/hex"
+	"testing"
+
+	circled448 "github.com/cloudflare/circl/sign/ed448"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/util/test"
+)
+
+func TestSuiteEd448(t *testing.T) { test.SuiteTest(t, NewSHAKE256Ed448()) }
+
+func TestSuiteDecaf448(t *testing.T) { test.SuiteTest(t, NewSHAKE256Decaf448()) }
+
+// Multiples 0*B through 3*B of the generator, from RFC 9496, appendix B.1.
+var decafMultiplesOfBase = []string{
+	"0000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+	"6666666666666666666666666666666666666666666666666666666633333333333333333333333333333333333333333333333333333333",
+	"c898eb4f87f97c564c6fd61fc7e49689314a1f818ec85eeb3bd5514ac816d38778f69ef347a89fca817e66defdedce178c7cc709b2116e75",
+	"a0c09bf2ba7208fda0f4bfe3d0f5b29a543012306d43831b5adc6fe7f8596fa308763db15468323b11cf6e4aeb8c18fe44678f44545a69bc",
+}
+
+func TestDecafMultiplesOfBase(t *testing.T) {
+	g := NewSHAKE256Decaf448()
+	B := g.Point().Base()
+	P := g.Point().Null()
+	for i, h := range decafMultiplesOfBase {
+		b, err := P.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, h, hex.EncodeToString(b), "%d*B", i)
+		require.True(t, g.Point().Mul(g.Scalar().SetInt64(int64(i)), nil).Equal(P))
+
+		Q := g.Point()
+		require.NoError(t, Q.UnmarshalBinary(b))
+		require.True(t, Q.Equal(P))
+		P.Add(P, B)
+	}
+}
+
+func TestDecafBadEncodings(t *testing.T) {
+	g := NewSHAKE256Decaf448()
+	bad := []string{
+		// The field prime, which is non-canonical
+		"fffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		// A negative field element
+		"0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		// The negative of the encoding of the generator
+		"99999999999999999999999999999999999999999999999999999999cbcccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+	}
+	for _, h := range bad {
+		b, err := hex.DecodeString(h)
+		require.NoError(t, err)
+		require.Error(t, g.Point().UnmarshalBinary(b), h)
+	}
+	require.Error(t, g.Point().UnmarshalBinary(make([]byte, decafSize-1)))
+}
+
+func TestDecafEquivalence(t *testing.T) {
+	g := NewSHAKE256Decaf448()
+	rng := random.New()
+	P := g.Point().Pick(rng).(*decafPoint)
+
+	// Adding the point of order 2 doesn't change the element nor its
+	// encoding.
+	t2 := extendedPoint{Y: feFromBig(bigFromString("-1")), Z: feOne}
+	Q := &decafPoint{}
+	Q.ge.add(&P.ge, &t2)
+	require.NotEqual(t, 1, Q.ge.equal(&P.ge))
+	require.True(t, P.Equal(Q))
+	pb, _ := P.MarshalBinary()
+	qb, _ := Q.MarshalBinary()
+	require.Equal(t, pb, qb)
+}
+
+func TestEd448BaseAndKeys(t *testing.T) {
+	c := &Curve{}
+	B := c.Point().Base()
+	require.True(t, B.(*point).IsInCorrectGroup())
+	require.False(t, B.(*point).HasSmallOrder())
+	require.True(t, c.Point().Mul(c.Scalar().Zero(), B).Equal(c.Point().Null()))
+
+	rng := random.New()
+	for i := 0; i < 8; i++ {
+		seed := make([]byte, SeedSize)
+		random.Bytes(seed, rng)
+		s, _, _ := c.NewKeyAndSeedWithInput(seed)
+		pub, err := c.Point().Mul(s, nil).MarshalBinary()
+		require.NoError(t, err)
+
+		expected := circled448.NewKeyFromSeed(seed).Public().(circled448.PublicKey)
+		require.Equal(t, []byte(expected), pub)
+		require.True(t, c.Point().Mul(s, B).Equal(c.Point().Mul(s, nil)))
+	}
+}
+
+func TestEd448Torsion(t *testing.T) {
+	c := &Curve{}
+	// (0, -1) has order 2 and (1, 0) has order 4.
+	var y [pointSize]byte
+	y[0] = 1
+	P := c.Point()
+	require.NoError(t, P.UnmarshalBinary(y[:]))
+	require.True(t, P.Equal(c.Point().Null()))
+
+	order2 := &point{ge: extendedPoint{Y: feOne, Z: feOne}}
+	order2.ge.Y = feFromBig(bigFromString("-1"))
+	order4 := &point{ge: extendedPoint{X: feOne, Z: feOne}}
+	for _, T := range []*point{order2, order4} {
+		require.True(t, T.HasSmallOrder())
+		require.False(t, T.IsInCorrectGroup())
+
+		Q := c.Point().Add(c.Point().Base(), T).(*point)
+		require.False(t, Q.HasSmallOrder())
+		require.False(t, Q.IsInCorrectGroup())
+
+		b, err := Q.MarshalBinary()
+		require.NoError(t, err)
+		R := c.Point()
+		require.NoError(t, R.UnmarshalBinary(b))
+		require.True(t, R.Equal(Q))
+	}
+}
+
+func TestEd448BadEncodings(t *testing.T) {
+	c := &Curve{}
+	P := c.Point().Base()
+	b, err := P.MarshalBinary()
+	require.NoError(t, err)
+
+	// Low bits of the last byte must be zero.
+	b[pointSize-1] |= 1
+	require.Error(t, c.Point().UnmarshalBinary(b))
+
+	// y = p is non-canonical.
+	p := make([]byte, pointSize)
+	for i := 0; i < 56; i++ {
+		p[i] = 0xff
+	}
+	p[28] = 0xfe
+	require.False(t, P.(*point).IsCanonical(p))
+	require.Error(t, c.Point().UnmarshalBinary(p))
+
+	// x = 0 with the sign bit set.
+	var zero [pointSize]byte
+	zero[0] = 1
+	zero[pointSize-1] = 0x80
+	require.Error(t, c.Point().UnmarshalBinary(zero[:]))
+}
+
+func TestScalarInv(t *testing.T) {
+	c := &Curve{}
+	rng := random.New()
+	s := c.Scalar().Pick(rng)
+	one := c.Scalar().Mul(s, c.Scalar().Inv(s))
+	require.True(t, one.Equal(c.Scalar().One()))
+
+	// The group order minus one isn't accepted with a higher byte set.
+	b := make([]byte, 56)
+	copy(b, orderBytes[:])
+	require.False(t, s.(*scalar).IsCanonical(b))
+	require.Error(t, c.Scalar().UnmarshalBinary(b))
+	b[0]--
+	require.NoError(t, c.Scalar().UnmarshalBinary(b))
+	require.True(t, c.Scalar().SetInt64(-1).Equal(c.Scalar().SetBytes(b)))
+}
@@ -0,0 +1,103 @@
+package ed448
+
+import (
+	"crypto/subtle"
+	"math/big"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+// The field arithmetic of GF(2^448 - 2^224 - 1) is provided by package
+// github.com/cloudflare/circl/math/fp448, whose operations run in constant
+// time. Its elements are little-endian integers which are only reduced
+// modulo p when required, so every comparison goes through feReduce.
+
+var (
+	prime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 448),
+		new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 224), big.NewInt(1)))
+
+	feOne = fp.One()
+	// d is the parameter -39081 of the curve x^2 + y^2 = 1 + d*x^2*y^2.
+	d = feFromBig(big.NewInt(-39081))
+	// dTimesFour is 4*d.
+	dTimesFour = feFromBig(big.NewInt(-4 * 39081))
+	// oneMinusD is 1-d.
+	oneMinusD = feFromBig(big.NewInt(39082))
+	// sqrtMinusD is the non-negative square root of -d.
+	//nolint:lll // Line not breakable
+	sqrtMinusD = feFromBig(bigFromString("98944233647732219769177004876929019128417576295529901074099889598043702116001257856802131563896515373927712232092845883226922417596214"))
+	// invSqrtMinusD is the non-negative inverse square root of -d.
+	//nolint:lll // Line not breakable
+	invSqrtMinusD = feFromBig(bigFromString("315019913931389607337177038330951043522456072897266928557328499619017160722351061360252776265186336876723201881398623946864393857820716"))
+)
+
+func bigFromString(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("ed448: invalid constant " + s)
+	}
+	return i
+}
+
+// feFromBig returns the field element x mod p.
+func feFromBig(x *big.Int) fp.Elt {
+	var be [fp.Size]byte
+	new(big.Int).Mod(x, prime).FillBytes(be[:])
+	var e fp.Elt
+	for i := range e {
+		e[i] = be[fp.Size-1-i]
+	}
+	return e
+}
+
+// feReduce returns the canonical representative of x.
+func feReduce(x *fp.Elt) fp.Elt {
+	r := *x
+	fp.Modp(&r)
+	return r
+}
+
+// feEqual returns 1 if a == b and 0 otherwise.
+func feEqual(a, b *fp.Elt) int {
+	ra, rb := feReduce(a), feReduce(b)
+	return subtle.ConstantTimeCompare(ra[:], rb[:])
+}
+
+// feIsZero returns 1 if a == 0 and 0 otherwise.
+func feIsZero(a *fp.Elt) int {
+	var zero fp.Elt
+	return feEqual(a, &zero)
+}
+
+// feIsNegative returns the sign of a as defined by RFC 8032 and RFC 9496,
+// i.e. the least significant bit of its canonical representative.
+func feIsNegative(a *fp.Elt) int {
+	r := feReduce(a)
+	return int(r[0] & 1)
+}
+
+// feCNeg sets a to -a if cond == 1 and leaves it unchanged if cond == 0.
+func feCNeg(a *fp.Elt, cond int) {
+	var n fp.Elt
+	fp.Neg(&n, a)
+	fp.Cmov(a, &n, uint(cond))
+}
+
+// feAbs sets a to its non-negative absolute value.
+func feAbs(a *fp.Elt) {
+	feCNeg(a, feIsNegative(a))
+}
+
+// feSqrtRatio implements SQRT_RATIO_M1 of RFC 9496, section 5.2.1: it
+// sets r to the non-negative square root of u/v and returns 1 if u/v is a
+// square, and otherwise sets r to the non-negative square root of -u/v
+// and returns 0.
+func feSqrtRatio(r, u, v *fp.Elt) int {
+	fp.InvSqrt(r, u, v)
+	var check fp.Elt
+	fp.Sqr(&check, r)
+	fp.Mul(&check, &check, v)
+	wasSquare := feEqual(&check, u)
+	feAbs(r)
+	return wasSquare
+}
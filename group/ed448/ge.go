@@ -0,0 +1,251 @@
+package ed448
+
+import (
+	"crypto/subtle"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+// extendedPoint is a point of the Edwards curve x^2 + y^2 = 1 + d*x^2*y^2
+// in extended coordinates (X:Y:Z:T), representing the affine point
+// (X/Z, Y/Z) with X*Y = Z*T. The addition law of this curve is complete,
+// since d is not a square, so the formulas below have no exceptional case.
+type extendedPoint struct {
+	X, Y, Z, T fp.Elt
+}
+
+// cachedPoint holds a point in the form used as second operand of the
+// additions: (X, Y, Z, X+Y, d*T).
+type cachedPoint struct {
+	X, Y, Z, XplusY, dT fp.Elt
+}
+
+// zero sets p to the neutral element (0:1:1:0).
+func (p *extendedPoint) zero() {
+	*p = extendedPoint{Y: feOne, Z: feOne}
+}
+
+// neg sets p = -q, where the negative of (x, y) is (-x, y).
+func (p *extendedPoint) neg(q *extendedPoint) {
+	fp.Neg(&p.X, &q.X)
+	p.Y = q.Y
+	p.Z = q.Z
+	fp.Neg(&p.T, &q.T)
+}
+
+// double sets p = 2*q, using the dbl-2008-hwcd formulas with a = 1.
+func (p *extendedPoint) double(q *extendedPoint) {
+	var a, b, c, e, f, g, h fp.Elt
+	fp.Sqr(&a, &q.X)
+	fp.Sqr(&b, &q.Y)
+	fp.Sqr(&c, &q.Z)
+	fp.Add(&c, &c, &c)
+	fp.Add(&e, &q.X, &q.Y)
+	fp.Sqr(&e, &e)
+	fp.Sub(&e, &e, &a)
+	fp.Sub(&e, &e, &b)
+	fp.Add(&g, &a, &b)
+	fp.Sub(&f, &g, &c)
+	fp.Sub(&h, &a, &b)
+	fp.Mul(&p.X, &e, &f)
+	fp.Mul(&p.Y, &g, &h)
+	fp.Mul(&p.T, &e, &h)
+	fp.Mul(&p.Z, &f, &g)
+}
+
+// addCached sets p = q + c, using the add-2008-hwcd formulas with a = 1.
+func (p *extendedPoint) addCached(q *extendedPoint, c *cachedPoint) {
+	var a, b, cc, dd, e, f, g, h fp.Elt
+	fp.Mul(&a, &q.X, &c.X)
+	fp.Mul(&b, &q.Y, &c.Y)
+	fp.Mul(&cc, &q.T, &c.dT)
+	fp.Mul(&dd, &q.Z, &c.Z)
+	fp.Add(&e, &q.X, &q.Y)
+	fp.Mul(&e, &e, &c.XplusY)
+	fp.Sub(&e, &e, &a)
+	fp.Sub(&e, &e, &b)
+	fp.Sub(&f, &dd, &cc)
+	fp.Add(&g, &dd, &cc)
+	fp.Sub(&h, &b, &a)
+	fp.Mul(&p.X, &e, &f)
+	fp.Mul(&p.Y, &g, &h)
+	fp.Mul(&p.T, &e, &h)
+	fp.Mul(&p.Z, &f, &g)
+}
+
+// add sets p = q + r.
+func (p *extendedPoint) add(q, r *extendedPoint) {
+	var c cachedPoint
+	c.fromExtended(r)
+	p.addCached(q, &c)
+}
+
+// sub sets p = q - r.
+func (p *extendedPoint) sub(q, r *extendedPoint) {
+	var c cachedPoint
+	c.fromExtended(r)
+	c.neg(&c)
+	p.addCached(q, &c)
+}
+
+// equal returns 1 if p and q represent the same point and 0 otherwise.
+func (p *extendedPoint) equal(q *extendedPoint) int {
+	var a, b fp.Elt
+	fp.Mul(&a, &p.X, &q.Z)
+	fp.Mul(&b, &q.X, &p.Z)
+	eq := feEqual(&a, &b)
+	fp.Mul(&a, &p.Y, &q.Z)
+	fp.Mul(&b, &q.Y, &p.Z)
+	return eq & feEqual(&a, &b)
+}
+
+// isZero returns 1 if p is the neutral element and 0 otherwise.
+func (p *extendedPoint) isZero() int {
+	return feIsZero(&p.X) & feEqual(&p.Y, &p.Z)
+}
+
+// affine returns the affine coordinates of p, reduced modulo the prime.
+func (p *extendedPoint) affine() (x, y fp.Elt) {
+	var zInv fp.Elt
+	fp.Inv(&zInv, &p.Z)
+	fp.Mul(&x, &p.X, &zInv)
+	fp.Mul(&y, &p.Y, &zInv)
+	return feReduce(&x), feReduce(&y)
+}
+
+func (c *cachedPoint) fromExtended(p *extendedPoint) {
+	c.X = p.X
+	c.Y = p.Y
+	c.Z = p.Z
+	fp.Add(&c.XplusY, &p.X, &p.Y)
+	fp.Mul(&c.dT, &p.T, &d)
+}
+
+// zero sets c to the neutral element.
+func (c *cachedPoint) zero() {
+	*c = cachedPoint{Y: feOne, Z: feOne, XplusY: feOne}
+}
+
+// neg sets c = -a, where c and a may alias.
+func (c *cachedPoint) neg(a *cachedPoint) {
+	fp.Sub(&c.XplusY, &a.Y, &a.X)
+	fp.Neg(&c.X, &a.X)
+	c.Y = a.Y
+	c.Z = a.Z
+	fp.Neg(&c.dT, &a.dT)
+}
+
+// cmov sets c = a if cond == 1 and leaves c unchanged if cond == 0.
+func (c *cachedPoint) cmov(a *cachedPoint, cond int) {
+	fp.Cmov(&c.X, &a.X, uint(cond))
+	fp.Cmov(&c.Y, &a.Y, uint(cond))
+	fp.Cmov(&c.Z, &a.Z, uint(cond))
+	fp.Cmov(&c.XplusY, &a.XplusY, uint(cond))
+	fp.Cmov(&c.dT, &a.dT, uint(cond))
+}
+
+// selectCached sets c = b*A in constant time, where Ai holds the multiples
+// A through 8*A and -8 <= b <= 8.
+func selectCached(c *cachedPoint, Ai *[8]cachedPoint, b int32) {
+	bNegative := int(uint32(b) >> 31)
+	bAbs := b - (((-int32(bNegative)) & b) << 1)
+
+	c.zero()
+	for i := int32(0); i < 8; i++ {
+		c.cmov(&Ai[i], subtle.ConstantTimeEq(bAbs, i+1))
+	}
+
+	var minusC cachedPoint
+	minusC.neg(c)
+	c.cmov(&minusC, bNegative)
+}
+
+// signedDigits recodes the little-endian integer a into 112 signed radix
+// 16 digits between -8 and 8. It requires a[55] <= 127.
+func signedDigits(a *[56]byte) [112]int8 {
+	var e [112]int8
+	for i, v := range a {
+		e[2*i] = int8(v & 15)
+		e[2*i+1] = int8((v >> 4) & 15)
+	}
+
+	carry := int8(0)
+	for i := 0; i < 111; i++ {
+		e[i] += carry
+		carry = (e[i] + 8) >> 4
+		e[i] -= carry << 4
+	}
+	e[111] += carry
+	return e
+}
+
+// scalarMult sets h = a*A in constant time, where a is a little-endian
+// integer with a[55] <= 127.
+func scalarMult(h *extendedPoint, a *[56]byte, A *extendedPoint) {
+	var Ai [8]cachedPoint
+	var t extendedPoint
+	Ai[0].fromExtended(A)
+	t = *A
+	for i := 1; i < 8; i++ {
+		t.addCached(&t, &Ai[0])
+		Ai[i].fromExtended(&t)
+	}
+
+	e := signedDigits(a)
+	var c cachedPoint
+	var r extendedPoint
+	r.zero()
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			r.double(&r)
+		}
+		selectCached(&c, &Ai, int32(e[i]))
+		r.addCached(&r, &c)
+	}
+	*h = r
+}
+
+// table holds the multiples of a point A laid out for fixed-base
+// multiplications: table[i][j] = (j+1) * 256^i * A.
+type table [56][8]cachedPoint
+
+func (t *table) init(A *extendedPoint) {
+	var a, m extendedPoint
+	a = *A
+	for i := range t {
+		t[i][0].fromExtended(&a)
+		m = a
+		for j := 1; j < 8; j++ {
+			m.addCached(&m, &t[i][0])
+			t[i][j].fromExtended(&m)
+		}
+		for k := 0; k < 8; k++ {
+			a.double(&a)
+		}
+	}
+}
+
+// scalarMult sets h = a*A in constant time, where A is the point of the
+// table and a is a little-endian integer with a[55] <= 127. Like the
+// base point multiplication of the edwards25519 package, it adds the odd
+// digits first and then needs only 4 doublings.
+func (t *table) scalarMult(h *extendedPoint, a *[56]byte) {
+	e := signedDigits(a)
+	var c cachedPoint
+	var r extendedPoint
+	r.zero()
+	for i := 1; i < len(e); i += 2 {
+		selectCached(&c, &t[i/2], int32(e[i]))
+		r.addCached(&r, &c)
+	}
+
+	for j := 0; j < 4; j++ {
+		r.double(&r)
+	}
+
+	for i := 0; i < len(e); i += 2 {
+		selectCached(&c, &t[i/2], int32(e[i]))
+		r.addCached(&r, &c)
+	}
+	*h = r
+}
@@ -0,0 +1,297 @@
+package ed448
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+
+	fp "github.com/cloudflare/circl/math/fp448"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+)
+
+var marshalPointID = [8]byte{'e', 'd', '4', '4', '8', '.', 'p', 't'}
+
+// pointSize is the length of the RFC 8032 encoding of a point: the
+// little-endian y coordinate followed by a byte holding the sign of x.
+const pointSize = 57
+
+var (
+	//nolint:lll // Line not breakable
+	baseX = feFromBig(bigFromString("224580040295924300187604334099896036246789641632564134246125461686950415467406032909029192869357953282578032075146446173674602635247710"))
+	//nolint:lll // Line not breakable
+	baseY = feFromBig(bigFromString("298819210078481492676017930443930673437544040154080242095928241372331506189835876003536878655418784733982303233503462500531545062832660"))
+
+	// baseext is the base point of RFC 8032, generating the prime-order
+	// subgroup.
+	baseext extendedPoint
+
+	// orderBytes is the little-endian encoding of the order of the
+	// prime-order subgroup, which isn't a reduced scalar.
+	orderBytes [56]byte
+
+	baseTableOnce sync.Once
+	baseTable     *table
+)
+
+func init() {
+	baseext = extendedPoint{X: baseX, Y: baseY, Z: feOne}
+	fp.Mul(&baseext.T, &baseX, &baseY)
+
+	be := primeOrder.FillBytes(make([]byte, 56))
+	for i := range orderBytes {
+		orderBytes[i] = be[55-i]
+	}
+}
+
+// baseMult sets h = a*B, using a table of multiples of the base point
+// built on first use.
+func baseMult(h *extendedPoint, a *[56]byte) {
+	baseTableOnce.Do(func() {
+		baseTable = new(table)
+		baseTable.init(&baseext)
+	})
+	baseTable.scalarMult(h, a)
+}
+
+type point struct {
+	ge extendedPoint
+}
+
+func (P *point) String() string {
+	b, _ := P.MarshalBinary()
+	return hex.EncodeToString(b)
+}
+
+func (P *point) MarshalSize() int {
+	return pointSize
+}
+
+func (P *point) MarshalBinary() ([]byte, error) {
+	x, y := P.ge.affine()
+	b := make([]byte, pointSize)
+	copy(b, y[:])
+	b[pointSize-1] = byte(feIsNegative(&x)) << 7
+	return b, nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (P *point) MarshalID() [8]byte {
+	return marshalPointID
+}
+
+// UnmarshalBinary decodes a point as specified in RFC 8032, section 5.2.3.
+// Non-canonical encodings of the y coordinate are rejected, but the point
+// may lie outside of the prime-order subgroup.
+func (P *point) UnmarshalBinary(b []byte) error {
+	if len(b) != pointSize || b[pointSize-1]&0x7f != 0 {
+		return errors.New("invalid Ed448 curve point")
+	}
+	if !P.IsCanonical(b) {
+		return errors.New("non-canonical Ed448 curve point")
+	}
+	var y fp.Elt
+	copy(y[:], b[:fp.Size])
+	xSign := int(b[pointSize-1] >> 7)
+
+	// x^2 = (y^2 - 1) / (d*y^2 - 1)
+	var u, v, x fp.Elt
+	fp.Sqr(&u, &y)
+	fp.Mul(&v, &u, &d)
+	fp.Sub(&u, &u, &feOne)
+	fp.Sub(&v, &v, &feOne)
+	if feSqrtRatio(&x, &u, &v) == 0 {
+		return errors.New("invalid Ed448 curve point")
+	}
+	if feIsZero(&x) == 1 && xSign == 1 {
+		return errors.New("invalid Ed448 curve point")
+	}
+	feCNeg(&x, xSign)
+
+	P.ge = extendedPoint{X: x, Y: y, Z: feOne}
+	fp.Mul(&P.ge.T, &x, &y)
+	return nil
+}
+
+func (P *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+func (P *point) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *point) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
+// Equal tests whether two points are equal, in constant time.
+func (P *point) Equal(P2 kyber.Point) bool {
+	return P.ge.equal(&P2.(*point).ge) == 1
+}
+
+// Set point to be equal to P2.
+func (P *point) Set(P2 kyber.Point) kyber.Point {
+	P.ge = P2.(*point).ge
+	return P
+}
+
+// Clone returns a copy of the point.
+func (P *point) Clone() kyber.Point {
+	return &point{ge: P.ge}
+}
+
+// Null sets the point to the neutral element, which is (0,1) for Edwards
+// curves.
+func (P *point) Null() kyber.Point {
+	P.ge.zero()
+	return P
+}
+
+// Base sets the point to the base point of RFC 8032.
+func (P *point) Base() kyber.Point {
+	P.ge = baseext
+	return P
+}
+
+func (P *point) EmbedLen() int {
+	// Reserve the least-significant byte for the embedded data length,
+	// and the most-significant one for pseudo-randomness.
+	return (448 - 8 - 8) / 8
+}
+
+func (P *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		var b [pointSize]byte
+		rand.XORKeyStream(b[:], b[:])
+		b[pointSize-1] &= 0x80
+		if data != nil {
+			b[0] = byte(dl)
+			copy(b[1:1+dl], data)
+		}
+		if P.UnmarshalBinary(b[:]) != nil {
+			continue
+		}
+
+		// Without data to embed, multiplying by the cofactor maps the
+		// point to the prime-order subgroup. Otherwise the y coordinate
+		// holds the data, so retry until the point is in the subgroup.
+		if data == nil {
+			P.ge.double(&P.ge)
+			P.ge.double(&P.ge)
+			if P.ge.isZero() == 1 {
+				continue
+			}
+			return P
+		}
+		if P.IsInCorrectGroup() {
+			return P
+		}
+	}
+}
+
+func (P *point) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+// Data extracts the data embedded in a point with Embed.
+func (P *point) Data() ([]byte, error) {
+	b, _ := P.MarshalBinary()
+	dl := int(b[0])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[1 : 1+dl], nil
+}
+
+func (P *point) Add(P1, P2 kyber.Point) kyber.Point {
+	P.ge.add(&P1.(*point).ge, &P2.(*point).ge)
+	return P
+}
+
+func (P *point) Sub(P1, P2 kyber.Point) kyber.Point {
+	P.ge.sub(&P1.(*point).ge, &P2.(*point).ge)
+	return P
+}
+
+// Neg finds the negative of point A.
+// For Edwards curves, the negative of (x,y) is (-x,y).
+func (P *point) Neg(A kyber.Point) kyber.Point {
+	P.ge.neg(&A.(*point).ge)
+	return P
+}
+
+// Mul multiplies point A by scalar s in constant time, or the base point
+// if A is nil.
+func (P *point) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	a := [56]byte(s.(*scalar).v)
+	if A == nil {
+		baseMult(&P.ge, &a)
+	} else {
+		scalarMult(&P.ge, &a, &A.(*point).ge)
+	}
+	return P
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs in constant time at about the
+// speed of the multiplication of the base point.
+func (P *point) Precompute() kyber.FixedBase {
+	fb := &fixedBase{}
+	fb.t.init(&P.ge)
+	return fb
+}
+
+type fixedBase struct {
+	t table
+}
+
+func (fb *fixedBase) Mul(s kyber.Scalar) kyber.Point {
+	a := [56]byte(s.(*scalar).v)
+	P := new(point)
+	fb.t.scalarMult(&P.ge, &a)
+	return P
+}
+
+// HasSmallOrder determines whether the group element has small order, that
+// is whether it is one of the 4 points killed by the cofactor.
+func (P *point) HasSmallOrder() bool {
+	var q extendedPoint
+	q.double(&P.ge)
+	q.double(&q)
+	return q.isZero() == 1
+}
+
+// IsCanonical determines whether the buffer is the canonical encoding of a
+// group element, i.e. whether the encoded y coordinate is less than the
+// field prime, according to RFC 8032, section 5.2.3.
+func (P *point) IsCanonical(b []byte) bool {
+	if len(b) != pointSize {
+		return false
+	}
+	var y fp.Elt
+	copy(y[:], b[:fp.Size])
+	r := feReduce(&y)
+	return subtle.ConstantTimeCompare(r[:], y[:]) == 1
+}
+
+// IsInCorrectGroup tells whether the point lies in the prime-order
+// subgroup, by checking that its multiple by the group order is the
+// neutral element.
+func (P *point) IsInCorrectGroup() bool {
+	var q extendedPoint
+	scalarMult(&q, &orderBytes, &P.ge)
+	return q.isZero() == 1
+}
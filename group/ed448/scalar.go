@@ -0,0 +1,232 @@
+package ed448
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/ecc/goldilocks"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// The scalar arithmetic modulo the prime order of the group is provided
+// by package github.com/cloudflare/circl/ecc/goldilocks, whose operations
+// run in constant time and always return fully reduced values.
+
+var marshalScalarID = [8]byte{'e', 'd', '4', '4', '8', '.', 's', 'c'}
+
+// primeOrder is 2^446 - 13818066809895115352007386748515426880336692474882178609894547503885.
+//
+//nolint:lll // Line not breakable
+var primeOrder = bigFromString("181709681073901722637330951972001133588410340171829515070372549795146003961539585716195755291692375963310293709091662304773755859649779")
+
+// orderMinus2 is the exponent of the scalar inversion.
+var orderMinus2 = new(big.Int).Sub(primeOrder, big.NewInt(2))
+
+type scalar struct {
+	v goldilocks.Scalar
+}
+
+// Equal tests two scalars for equality in constant time.
+func (s *scalar) Equal(s2 kyber.Scalar) bool {
+	return subtle.ConstantTimeCompare(s.v[:], s2.(*scalar).v[:]) == 1
+}
+
+// Set sets the scalar equal to a.
+func (s *scalar) Set(a kyber.Scalar) kyber.Scalar {
+	s.v = a.(*scalar).v
+	return s
+}
+
+// Clone returns a duplicate of the scalar s.
+func (s *scalar) Clone() kyber.Scalar {
+	return &scalar{v: s.v}
+}
+
+// SetInt64 sets the scalar to a small integer value.
+func (s *scalar) SetInt64(v int64) kyber.Scalar {
+	abs := uint64(v)
+	if v < 0 {
+		abs = -abs
+	}
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(abs >> (8 * i))
+	}
+	s.v.FromBytes(b[:])
+	if v < 0 {
+		s.v.Neg()
+	}
+	return s
+}
+
+// Zero sets the scalar to the additive identity.
+func (s *scalar) Zero() kyber.Scalar {
+	s.v = goldilocks.Scalar{}
+	return s
+}
+
+// One sets the scalar to the multiplicative identity.
+func (s *scalar) One() kyber.Scalar {
+	s.v = goldilocks.Scalar{1}
+	return s
+}
+
+// Add sets the scalar to the modular sum of a and b.
+func (s *scalar) Add(a, b kyber.Scalar) kyber.Scalar {
+	s.v.Add(&a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+// Sub sets the scalar to the modular difference a - b.
+func (s *scalar) Sub(a, b kyber.Scalar) kyber.Scalar {
+	s.v.Sub(&a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+// Neg sets the scalar to the modular negation of a.
+func (s *scalar) Neg(a kyber.Scalar) kyber.Scalar {
+	s.v = a.(*scalar).v
+	s.v.Neg()
+	return s
+}
+
+// Mul sets the scalar to the modular product of a and b.
+func (s *scalar) Mul(a, b kyber.Scalar) kyber.Scalar {
+	s.v.Mul(&a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+// Div sets the scalar to the modular division of a by b.
+func (s *scalar) Div(a, b kyber.Scalar) kyber.Scalar {
+	var i scalar
+	i.Inv(b)
+	s.v.Mul(&a.(*scalar).v, &i.v)
+	return s
+}
+
+// Inv sets the scalar to the modular inverse of a, computed as a^(l-2).
+// The sequence of operations only depends on the public group order, and
+// the inverse of zero is zero.
+func (s *scalar) Inv(a kyber.Scalar) kyber.Scalar {
+	ac := a.(*scalar).v
+	res := goldilocks.Scalar{1}
+	for i := orderMinus2.BitLen() - 1; i >= 0; i-- {
+		res.Mul(&res, &res)
+		if orderMinus2.Bit(i) == 1 {
+			res.Mul(&res, &ac)
+		}
+	}
+	s.v = res
+	return s
+}
+
+// Pick sets the scalar to a fresh random or pseudo-random value.
+func (s *scalar) Pick(rand cipher.Stream) kyber.Scalar {
+	return s.setBig(random.Int(primeOrder, rand))
+}
+
+// SetBytes sets the scalar to b, interpreted as a little-endian integer of
+// any length, reduced modulo the group order.
+func (s *scalar) SetBytes(b []byte) kyber.Scalar {
+	s.v.FromBytes(b)
+	return s
+}
+
+func (s *scalar) setBig(i *big.Int) kyber.Scalar {
+	var b [goldilocks.ScalarSize]byte
+	i.FillBytes(b[:])
+	for l, r := 0, len(b)-1; l < r; l, r = l+1, r-1 {
+		b[l], b[r] = b[r], b[l]
+	}
+	s.v.FromBytes(b[:])
+	return s
+}
+
+// ByteOrder returns the byte representation type, which is little-endian
+// for compatibility with RFC 8032.
+func (s *scalar) ByteOrder() kyber.ByteOrder {
+	return kyber.LittleEndian
+}
+
+// GroupOrder returns the order of the prime-order subgroup.
+func (s *scalar) GroupOrder() *big.Int {
+	return new(big.Int).Set(primeOrder)
+}
+
+// String returns the hexadecimal encoding of the 56 bytes little-endian
+// representation of the scalar.
+func (s *scalar) String() string {
+	return hex.EncodeToString(s.v[:])
+}
+
+// MarshalSize returns 56, the length of an encoded scalar.
+func (s *scalar) MarshalSize() int {
+	return goldilocks.ScalarSize
+}
+
+// MarshalBinary returns the 56 bytes little-endian encoding of the scalar.
+func (s *scalar) MarshalBinary() ([]byte, error) {
+	b := make([]byte, goldilocks.ScalarSize)
+	copy(b, s.v[:])
+	return b, nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (s *scalar) MarshalID() [8]byte {
+	return marshalScalarID
+}
+
+// UnmarshalBinary sets the scalar from its 56 bytes little-endian encoding,
+// which must be reduced modulo the group order.
+func (s *scalar) UnmarshalBinary(buf []byte) error {
+	if len(buf) != goldilocks.ScalarSize {
+		return errors.New("wrong size buffer")
+	}
+	if !s.IsCanonical(buf) {
+		return errors.New("scalar out of range")
+	}
+	copy(s.v[:], buf)
+	return nil
+}
+
+// MarshalTo writes the binary representation of this scalar to the given
+// writer.
+func (s *scalar) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.ScalarMarshalTo(s, w)
+}
+
+// UnmarshalFrom reads the binary representation of a scalar from the given
+// reader.
+func (s *scalar) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.ScalarUnmarshalFrom(s, r)
+}
+
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (s *scalar) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(s)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *scalar) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(s, text)
+}
+
+// IsCanonical tells whether sb is the 56 bytes little-endian encoding of
+// an integer lower than the group order, as required from the S part of
+// the signatures by RFC 8032, section 5.2.7.
+func (s *scalar) IsCanonical(sb []byte) bool {
+	if len(sb) != goldilocks.ScalarSize {
+		return false
+	}
+	var v goldilocks.Scalar
+	copy(v[:], sb)
+	v.Red()
+	return subtle.ConstantTimeCompare(v[:], sb) == 1
+}
@@ -0,0 +1,127 @@
+package ed448
+
+import (
+	"crypto/cipher"
+	"hash"
+	"io"
+	"reflect"
+
+	"go.dedis.ch/fixbuf"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/xof/keccak"
+	"golang.org/x/crypto/sha3"
+)
+
+// SuiteEd448 implements some basic functionalities such as Group, HashFactory,
+// and XOFFactory.
+type SuiteEd448 struct {
+	Curve
+	r cipher.Stream
+}
+
+// Hash returns a newly instanciated SHA3-512 hash function.
+func (s *SuiteEd448) Hash() hash.Hash {
+	return sha3.New512()
+}
+
+// XOF returns an XOF which is implemented via the SHAKE256 hash.
+func (s *SuiteEd448) XOF(key []byte) kyber.XOF {
+	return keccak.New(key)
+}
+
+func (s *SuiteEd448) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *SuiteEd448) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs...)
+}
+
+// New implements the kyber.Encoding interface
+func (s *SuiteEd448) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteEd448) RandomStream() cipher.Stream {
+	if s.r != nil {
+		return s.r
+	}
+	return random.New()
+}
+
+// NewSHAKE256Ed448 returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/keccak, SHA3-512, and the Ed448 curve.
+// It produces cryptographically random numbers via package crypto/rand.
+func NewSHAKE256Ed448() *SuiteEd448 {
+	suite := new(SuiteEd448)
+	return suite
+}
+
+// NewSHAKE256Ed448WithRand returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/keccak, SHA3-512, and the Ed448 curve.
+// It produces cryptographically random numbers via the provided stream r.
+func NewSHAKE256Ed448WithRand(r cipher.Stream) *SuiteEd448 {
+	suite := new(SuiteEd448)
+	suite.r = r
+	return suite
+}
+
+// SuiteDecaf448 implements some basic functionalities such as Group,
+// HashFactory, and XOFFactory.
+type SuiteDecaf448 struct {
+	Decaf448
+	r cipher.Stream
+}
+
+// Hash returns a newly instanciated SHA3-512 hash function.
+func (s *SuiteDecaf448) Hash() hash.Hash {
+	return sha3.New512()
+}
+
+// XOF returns an XOF which is implemented via the SHAKE256 hash.
+func (s *SuiteDecaf448) XOF(key []byte) kyber.XOF {
+	return keccak.New(key)
+}
+
+func (s *SuiteDecaf448) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *SuiteDecaf448) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs...)
+}
+
+// New implements the kyber.Encoding interface
+func (s *SuiteDecaf448) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteDecaf448) RandomStream() cipher.Stream {
+	if s.r != nil {
+		return s.r
+	}
+	return random.New()
+}
+
+// NewSHAKE256Decaf448 returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/keccak, SHA3-512, and the Decaf448 group.
+// It produces cryptographically random numbers via package crypto/rand.
+func NewSHAKE256Decaf448() *SuiteDecaf448 {
+	suite := new(SuiteDecaf448)
+	return suite
+}
+
+// NewSHAKE256Decaf448WithRand returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/keccak, SHA3-512, and the Decaf448 group.
+// It produces cryptographically random numbers via the provided stream r.
+func NewSHAKE256Decaf448WithRand(r cipher.Stream) *SuiteDecaf448 {
+	suite := new(SuiteDecaf448)
+	suite.r = r
+	return suite
+}
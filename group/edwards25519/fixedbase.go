@@ -0,0 +1,100 @@
+package edwards25519
+
+import (
+	"go.dedis.ch/kyber/v4"
+)
+
+// fixedBase holds the multiples of a point laid out like the table of the
+// base point: table[i][j] = (j+1) * 256^i * A, so that a multiplication
+// follows geScalarMultBase with cached instead of precomputed elements.
+type fixedBase struct {
+	table [32][8]cachedGroupElement
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs in constant time at about the
+// speed of the multiplication of the base point.
+func (P *point) Precompute() kyber.FixedBase {
+	fb := new(fixedBase)
+	var A, t extendedGroupElement
+	var r completedGroupElement
+	var s projectiveGroupElement
+
+	A = P.ge
+	for i := range fb.table {
+		A.ToCached(&fb.table[i][0])
+		for j := 1; j < 8; j++ {
+			r.Add(&A, &fb.table[i][j-1])
+			r.ToExtended(&t)
+			t.ToCached(&fb.table[i][j])
+		}
+
+		// A = 256 * A
+		A.Double(&r)
+		for k := 0; k < 7; k++ {
+			r.ToProjective(&s)
+			s.Double(&r)
+		}
+		r.ToExtended(&A)
+	}
+	return fb
+}
+
+// Mul returns s times the precomputed point.
+func (fb *fixedBase) Mul(s kyber.Scalar) kyber.Point {
+	P := new(point)
+	geScalarMultPrecomputed(&P.ge, &s.(*scalar).v, &fb.table)
+	return P
+}
+
+// geScalarMultPrecomputed computes h = a*A, where the table holds the
+// multiples of A as described for fixedBase.
+//
+// Preconditions:
+//
+//	a[31] <= 127
+func geScalarMultPrecomputed(h *extendedGroupElement, a *[32]byte, table *[32][8]cachedGroupElement) {
+	var e [64]int8
+
+	for i, v := range a {
+		e[2*i] = int8(v & 15)
+		e[2*i+1] = int8((v >> 4) & 15)
+	}
+
+	// each e[i] is between 0 and 15 and e[63] is between 0 and 7.
+
+	carry := int8(0)
+	for i := 0; i < 63; i++ {
+		e[i] += carry
+		carry = (e[i] + 8) >> 4
+		e[i] -= carry << 4
+	}
+	e[63] += carry
+	// each e[i] is between -8 and 8.
+
+	h.Zero()
+	var t cachedGroupElement
+	var r completedGroupElement
+	for i := 1; i < 64; i += 2 {
+		selectCached(&t, &table[i/2], int32(e[i]))
+		r.Add(h, &t)
+		r.ToExtended(h)
+	}
+
+	var s projectiveGroupElement
+
+	h.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToProjective(&s)
+	s.Double(&r)
+	r.ToExtended(h)
+
+	for i := 0; i < 64; i += 2 {
+		selectCached(&t, &table[i/2], int32(e[i]))
+		r.Add(h, &t)
+		r.ToExtended(h)
+	}
+}
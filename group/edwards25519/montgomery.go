@@ -0,0 +1,58 @@
+package edwards25519
+
+import (
+	"errors"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// The functions of this file convert the Ed25519 points to and from the
+// u-coordinates of the birationally equivalent Montgomery curve25519 of
+// X25519, with u = (1+y)/(1-y) and y = (u-1)/(u+1).
+
+// MontgomeryEncode returns the 32 bytes little-endian u-coordinate of the
+// Ed25519 point P on curve25519, computed in constant time. The neutral
+// element, which maps to the point at infinity, is encoded as 0 as X25519
+// does.
+func MontgomeryEncode(P kyber.Point) []byte {
+	ge := &P.(*point).ge
+	// u = (Z+Y)/(Z-Y) in projective coordinates
+	var num, den, inv fieldElement
+	feAdd(&num, &ge.Z, &ge.Y)
+	feSub(&den, &ge.Z, &ge.Y)
+	feInvert(&inv, &den)
+	feMul(&num, &num, &inv)
+	var u [32]byte
+	feToBytes(&u, &num)
+	return u[:]
+}
+
+// MontgomeryDecode sets the Ed25519 point P to the point of u-coordinate u
+// whose x-coordinate is non-negative. The most significant bit of u is
+// ignored and u is reduced modulo the prime as in X25519. It returns an
+// error, and leaves P unchanged, if u is on the twist of curve25519 or is
+// the u-coordinate of no Ed25519 point.
+func MontgomeryDecode(P kyber.Point, u []byte) error {
+	if len(u) != 32 {
+		return errors.New("invalid curve25519 u-coordinate length")
+	}
+	var fu, num, den, inv, one fieldElement
+	feFromBytes(&fu, u)
+	feOne(&one)
+	feSub(&num, &fu, &one)
+	feAdd(&den, &fu, &one)
+	if feIsNonZero(&den) == 0 {
+		return errors.New("invalid curve25519 u-coordinate")
+	}
+	feInvert(&inv, &den)
+	feMul(&num, &num, &inv)
+	var y [32]byte
+	feToBytes(&y, &num)
+
+	var h extendedGroupElement
+	if !h.FromBytes(y[:]) {
+		return errors.New("curve25519 u-coordinate on the twist")
+	}
+	P.(*point).ge = h
+	return nil
+}
@@ -0,0 +1,48 @@
+package edwards25519
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestMontgomery(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		k := make([]byte, 32)
+		_, err := rand.Read(k)
+		require.NoError(t, err)
+		k[0] &= 248
+		k[31] &= 127
+		k[31] |= 64
+		expected, err := curve25519.X25519(k, curve25519.Basepoint)
+		require.NoError(t, err)
+
+		P := tSuite.Point().Mul(tSuite.Scalar().SetBytes(k), nil)
+		require.Equal(t, expected, MontgomeryEncode(P))
+
+		// The decoded point is P or -P, as u doesn't tell the sign of x.
+		Q := tSuite.Point()
+		require.NoError(t, MontgomeryDecode(Q, expected))
+		require.True(t, Q.Equal(P) || Q.Equal(P.Clone().Neg(P)))
+		require.Equal(t, expected, MontgomeryEncode(Q))
+
+		// The most significant bit is ignored.
+		expected[31] |= 0x80
+		require.NoError(t, MontgomeryDecode(Q, expected))
+		require.Equal(t, MontgomeryEncode(P), MontgomeryEncode(Q))
+	}
+
+	require.Equal(t, make([]byte, 32), MontgomeryEncode(tSuite.Point().Null()))
+
+	// u = -1 has no Ed25519 point, and u = 2 is on the twist.
+	minusOne := []byte{0xec, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}
+	require.Error(t, MontgomeryDecode(tSuite.Point(), minusOne))
+	two := make([]byte, 32)
+	two[0] = 2
+	require.Error(t, MontgomeryDecode(tSuite.Point(), two))
+	require.Error(t, MontgomeryDecode(tSuite.Point(), two[:31]))
+}
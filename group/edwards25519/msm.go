@@ -0,0 +1,148 @@
+package edwards25519
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// MultiScalarMul sets P to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It uses Straus' method for short sums
+// and Pippenger's bucket method for long ones. It runs in variable time,
+// thus it must only be used on public Scalars and Points.
+func (P *point) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return P.Null()
+	}
+
+	ks := make([][]byte, len(scalars))
+	As := make([]*extendedGroupElement, len(points))
+	for i := range points {
+		ks[i] = scalars[i].(*scalar).v[:]
+		if points[i] == nil {
+			As[i] = &baseext
+		} else {
+			As[i] = &points[i].(*point).ge
+		}
+	}
+
+	if len(As) < msm.StrausThreshold {
+		geMultiScalarMultStraus(&P.ge, ks, As)
+	} else {
+		geMultiScalarMultPippenger(&P.ge, ks, As)
+	}
+	return P
+}
+
+// geDoubleN computes h = 2^n*h, with n > 0.
+func geDoubleN(h *extendedGroupElement, n int) {
+	var r projectiveGroupElement
+	var t completedGroupElement
+
+	h.ToProjective(&r)
+	for i := 0; i < n-1; i++ {
+		r.Double(&t)
+		t.ToProjective(&r)
+	}
+	r.Double(&t)
+	t.ToExtended(h)
+}
+
+// geMultiScalarMultStraus computes h = sum ks[i]*As[i], where the ks are
+// little-endian scalars of the same length, by interleaving signed
+// windows of every scalar over a single chain of doublings.
+func geMultiScalarMultStraus(h *extendedGroupElement, ks [][]byte,
+	As []*extendedGroupElement) {
+
+	const w = msm.StrausWindow
+	var t completedGroupElement
+	var u extendedGroupElement
+
+	// Form, for every point, the table of its multiples 1A through 16A
+	// in addition-ready cached group element form.
+	tables := make([][1 << (w - 1)]cachedGroupElement, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		A.ToCached(&tables[i][0])
+		for j := 1; j < len(tables[i]); j++ {
+			t.Add(A, &tables[i][j-1])
+			t.ToExtended(&u)
+			u.ToCached(&tables[i][j])
+		}
+		digits[i] = msm.SignedDigits(ks[i], w)
+	}
+
+	u.Zero()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		geDoubleN(&u, w)
+		for i := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(&u, &tables[i][d-1])
+				t.ToExtended(&u)
+			case d < 0:
+				t.Sub(&u, &tables[i][-d-1])
+				t.ToExtended(&u)
+			}
+		}
+	}
+	*h = u
+}
+
+// geMultiScalarMultPippenger computes h = sum ks[i]*As[i], where the ks
+// are little-endian scalars of the same length, by sorting the points into
+// buckets according to their signed digits in every window.
+func geMultiScalarMultPippenger(h *extendedGroupElement, ks [][]byte,
+	As []*extendedGroupElement) {
+
+	c := msm.PippengerWindow(len(As))
+	var t completedGroupElement
+	var tc cachedGroupElement
+	var acc, run, sum extendedGroupElement
+
+	cached := make([]cachedGroupElement, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		A.ToCached(&cached[i])
+		digits[i] = msm.SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]extendedGroupElement, 1<<(c-1))
+	acc.Zero()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		geDoubleN(&acc, c)
+
+		for b := range buckets {
+			buckets[b].Zero()
+		}
+		for i := range cached {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(&buckets[d-1], &cached[i])
+				t.ToExtended(&buckets[d-1])
+			case d < 0:
+				t.Sub(&buckets[-d-1], &cached[i])
+				t.ToExtended(&buckets[-d-1])
+			}
+		}
+
+		// sum = 1*buckets[0] + 2*buckets[1] + ... computed as a sum of
+		// running sums from the highest bucket downward.
+		run.Zero()
+		sum.Zero()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			buckets[b].ToCached(&tc)
+			t.Add(&run, &tc)
+			t.ToExtended(&run)
+			run.ToCached(&tc)
+			t.Add(&sum, &tc)
+			t.ToExtended(&sum)
+		}
+		sum.ToCached(&tc)
+		t.Add(&acc, &tc)
+		t.ToExtended(&acc)
+	}
+	*h = acc
+}
@@ -19,19 +19,15 @@ import (
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
-	"fmt"
-	"hash"
 	"io"
-	"math"
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
-	"golang.org/x/crypto/sha3"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
 var marshalPointID = [8]byte{'e', 'd', '.', 'p', 'o', 'i', 'n', 't'}
-var longDomainSeparator = "H2C-OVERSIZE-DST-"
 
 type point struct {
 	ge      extendedGroupElement
@@ -74,6 +70,14 @@ func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *point) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *point) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 // Equality test for two Points on the same curve
 func (P *point) Equal(P2 kyber.Point) bool {
 
@@ -314,12 +318,26 @@ func (P *point) Hash(m []byte, dst string) kyber.Point {
 	return P
 }
 
+// EncodeToCurve sets the point to the nonuniform encoding of m, using the
+// edwards25519_XMD:SHA-512_ELL2_NU_ suite of RFC 9380 with the domain
+// separation tag dst. It is cheaper than Hash, but its output isn't
+// uniformly distributed, e.g. as required by ECVRF-EDWARDS25519-SHA512-ELL2.
+func (P *point) EncodeToCurve(m []byte, dst string) kyber.Point {
+	u := hashToField(m, dst, 1)
+	P.Set(mapToCurveElligator2Ed25519(u[0]))
+
+	// Clear cofactor
+	P.Mul(cofactorScalar, P)
+
+	return P
+}
+
 func hashToField(m []byte, dst string, count int) []fieldElement {
 	// L param in RFC9380 section 5
 	// https://datatracker.ietf.org/doc/html/rfc9380#name-hashing-to-a-finite-field
 	l := 48
 	byteLen := count * l
-	uniformBytes, _ := expandMessageXMD(sha512.New(), m, dst, byteLen)
+	uniformBytes, _ := h2c.ExpandMessageXMD(sha512.New(), m, dst, byteLen)
 
 	u := make([]fieldElement, count)
 	for i := 0; i < count; i++ {
@@ -334,152 +352,6 @@ func hashToField(m []byte, dst string, count int) []fieldElement {
 	return u
 }
 
-func expandMessageXMD(h hash.Hash, m []byte, domainSeparator string, byteLen int) ([]byte, error) {
-	r := float64(byteLen) / float64(h.Size()>>3)
-	ell := int(math.Ceil(r))
-	if ell > 255 || ell < 0 || byteLen > 65535 || len(domainSeparator) == 0 {
-		return nil, errors.New("invalid parameters")
-	}
-
-	if len(domainSeparator) > 255 {
-		h.Reset()
-		h.Write([]byte(longDomainSeparator))
-		h.Write([]byte(domainSeparator))
-
-		domainSeparator = string(h.Sum(nil))
-	}
-
-	padDom, err := i2OSP(len(domainSeparator), 1)
-	if err != nil {
-		return nil, err
-	}
-
-	dstPrime := append([]byte(domainSeparator), padDom...)
-	byteLenStr, _ := i2OSP(byteLen, 2)
-	zeroPad, _ := i2OSP(0, 1)
-	zPad, _ := i2OSP(0, h.BlockSize())
-
-	// mPrime = Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prim
-	mPrime := make([]byte, 0, len(zPad)+len(m)+len(byteLenStr)+len(zeroPad)+len(dstPrime))
-	mPrime = append(mPrime, zPad...)
-	mPrime = append(mPrime, m...)
-	mPrime = append(mPrime, byteLenStr...)
-	mPrime = append(mPrime, zeroPad...)
-	mPrime = append(mPrime, dstPrime...)
-
-	// b0 = H(msg_prime)
-	h.Reset()
-	h.Write([]byte(mPrime))
-	b0 := h.Sum(nil)
-
-	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
-	h.Reset()
-	h.Write(b0)
-	onePad, _ := i2OSP(1, 1)
-	h.Write([]byte(onePad))
-	h.Write([]byte(dstPrime))
-	b1 := h.Sum(nil)
-
-	bFinal := make([]byte, 0, len(b1)*(ell+1))
-	bFinal = append(bFinal, b1...)
-	bPred := b1
-	for i := 2; i <= ell; i++ {
-		x, err := byteXor(bPred, b0, bPred)
-		if err != nil {
-			return nil, err
-		}
-		ithPad, _ := i2OSP(i, 1)
-
-		h.Reset()
-		h.Write(x)
-		h.Write(ithPad)
-		h.Write(dstPrime)
-
-		bPred = h.Sum(nil)
-		bFinal = append(bFinal, bPred...)
-	}
-
-	return bFinal[:byteLen], nil
-}
-
-func expandMessageXOF(h sha3.ShakeHash, m []byte, domainSeparator string, byteLen int) ([]byte, error) {
-	if byteLen > 65535 || len(domainSeparator) == 0 {
-		return nil, errors.New("invalid parameters")
-	}
-
-	if len(domainSeparator) > 255 {
-		outputSize := h.Size()
-
-		h.Reset()
-		h.Write([]byte(longDomainSeparator))
-		h.Write([]byte(domainSeparator))
-
-		dst := make([]byte, outputSize)
-		n, err := h.Read(dst)
-		if err != nil {
-			return nil, err
-		}
-
-		if n != outputSize {
-			return nil, fmt.Errorf("read %d byte instead of expected %d from xof", n, byteLen)
-		}
-
-		domainSeparator = string(dst)
-	}
-
-	dstPad, err := i2OSP(len(domainSeparator), 1)
-	if err != nil {
-		return nil, err
-	}
-
-	lenPad, err := i2OSP(byteLen, 2)
-	if err != nil {
-		return nil, err
-	}
-
-	dstPrime := append([]byte(domainSeparator), dstPad...)
-
-	h.Reset()
-	h.Write(m)
-	h.Write(lenPad)
-	h.Write(dstPrime)
-
-	uniformBytes := make([]byte, byteLen)
-	n, err := h.Read(uniformBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	if n != byteLen {
-		return nil, fmt.Errorf("read %d byte instead of expected %d from xof", n, byteLen)
-	}
-
-	return uniformBytes, nil
-}
-
-func i2OSP(x int, xLen int) ([]byte, error) {
-	b := big.NewInt(int64(x))
-	s := b.Bytes()
-	if len(s) > xLen {
-		return nil, fmt.Errorf("input %d superior to max length %d", len(s), xLen)
-	}
-
-	pad := make([]byte, (xLen - len(s)))
-	return append(pad, s...), nil
-}
-
-func byteXor(dst, b1, b2 []byte) ([]byte, error) {
-	if !(len(dst) == len(b1) && len(b2) == len(b1)) {
-		return nil, errors.New("incompatible lengths")
-	}
-
-	for i := 0; i < len(dst); i++ {
-		dst[i] = b1[i] ^ b2[i]
-	}
-
-	return dst, nil
-}
-
 // curve25519Elligator2 implements a map from fieldElement to a point on Curve25519
 // as defined in section G.2.1. of [RFC9380]
 // [RFC9380]: https://datatracker.ietf.org/doc/html/rfc9380#ell2-opt
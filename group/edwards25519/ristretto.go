@@ -0,0 +1,307 @@
+package edwards25519
+
+import (
+	"crypto/sha512"
+	"errors"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+)
+
+// The functions of this file implement the ristretto255 encoding, decoding,
+// equality and one-way map of RFC 9496 on top of the Ed25519 arithmetic.
+// They are the building blocks of the go.dedis.ch/kyber/v4/group/ristretto255
+// package, which should be used instead of calling them directly.
+// [RFC9496]: https://datatracker.ietf.org/doc/html/rfc9496
+
+// sqrtADMinusOne = sqrt(a*d - 1), computed with sagemath
+var sqrtADMinusOne = fieldElement{
+	24849947, -153582, -23613485, 6347715, -21072328, -667138, -25271143, -15367704, -870347, 14525639,
+}
+
+// invSqrtAMinusD = 1/sqrt(a - d), computed with sagemath
+var invSqrtAMinusD = fieldElement{
+	6111485, 4156064, -27798727, 12243468, -25904040, 120897, 20826367, -7060776, 6093568, -1986012,
+}
+
+// oneMinusDSq = 1 - d^2, computed with sagemath
+var oneMinusDSq = fieldElement{
+	6275446, -16617371, -22938544, -3773710, 11667077, 7397348, -27922721, 1766195, -24433858, 672203,
+}
+
+// dMinusOneSq = (d - 1)^2, computed with sagemath
+var dMinusOneSq = fieldElement{
+	15551795, -11097455, -13425098, -10125071, -11896535, 10178284, -26634327, 4729244, -5282110, -10116402,
+}
+
+// feEqual returns 1 if f == g and 0 otherwise, in constant time.
+func feEqual(f, g *fieldElement) int32 {
+	var t fieldElement
+	feSub(&t, f, g)
+	return 1 - feIsNonZero(&t)
+}
+
+// feCNeg sets f = -f if b == 1 and leaves it unchanged if b == 0.
+func feCNeg(f *fieldElement, b int32) {
+	var t fieldElement
+	feNeg(&t, f)
+	feCMove(f, &t, b)
+}
+
+// feAbs sets h = |f|, i.e. the non-negative one of f and -f.
+func feAbs(h, f *fieldElement) {
+	feCopy(h, f)
+	feCNeg(h, int32(feIsNegative(f)))
+}
+
+// feSqrtRatioM1 sets r to the non-negative square root of u/v if it exists,
+// and to the non-negative square root of sqrt(-1)*u/v otherwise. It returns
+// 1 in the first case and 0 in the second one, as SQRT_RATIO_M1 in
+// section 4.2 of RFC 9496.
+func feSqrtRatioM1(r, u, v *fieldElement) int32 {
+	var v3, v7, uv3, uv7, check, uNeg, uNegI, rPrime fieldElement
+
+	feSquare(&v3, v)
+	feMul(&v3, &v3, v) // v3 = v^3
+	feSquare(&v7, &v3)
+	feMul(&v7, &v7, v) // v7 = v^7
+	feMul(&uv3, u, &v3)
+	feMul(&uv7, u, &v7)
+	fePow22523(r, &uv7)
+	feMul(r, r, &uv3) // r = (u*v^3) * (u*v^7)^((p-5)/8)
+
+	feSquare(&check, r)
+	feMul(&check, &check, v) // check = v * r^2
+
+	feNeg(&uNeg, u)
+	feMul(&uNegI, &uNeg, &sqrtM1)
+	correctSignSqrt := feEqual(&check, u)
+	flippedSignSqrt := feEqual(&check, &uNeg)
+	flippedSignSqrtI := feEqual(&check, &uNegI)
+
+	feMul(&rPrime, r, &sqrtM1)
+	feCMove(r, &rPrime, flippedSignSqrt|flippedSignSqrtI)
+	feAbs(r, r)
+
+	return correctSignSqrt | flippedSignSqrt
+}
+
+// ristrettoEncode writes the canonical ristretto255 encoding of h to s.
+func ristrettoEncode(s *[32]byte, h *extendedGroupElement) {
+	var u1, u2, t, invSqrt, den1, den2, zInv fieldElement
+	var ix0, iy0, enchantedDen, x, y, denInv, one fieldElement
+	feOne(&one)
+
+	feAdd(&u1, &h.Z, &h.Y)
+	feSub(&t, &h.Z, &h.Y)
+	feMul(&u1, &u1, &t)    // u1 = (Z0 + Y0) * (Z0 - Y0)
+	feMul(&u2, &h.X, &h.Y) // u2 = X0 * Y0
+
+	feSquare(&t, &u2)
+	feMul(&t, &t, &u1)
+	feSqrtRatioM1(&invSqrt, &one, &t) // invsqrt = 1/sqrt(u1 * u2^2)
+
+	feMul(&den1, &invSqrt, &u1)
+	feMul(&den2, &invSqrt, &u2)
+	feMul(&zInv, &den1, &den2)
+	feMul(&zInv, &zInv, &h.T) // z_inv = den1 * den2 * T0
+
+	feMul(&ix0, &h.X, &sqrtM1)
+	feMul(&iy0, &h.Y, &sqrtM1)
+	feMul(&enchantedDen, &den1, &invSqrtAMinusD)
+
+	feMul(&t, &h.T, &zInv)
+	rotate := int32(feIsNegative(&t))
+
+	feCopy(&x, &h.X)
+	feCMove(&x, &iy0, rotate)
+	feCopy(&y, &h.Y)
+	feCMove(&y, &ix0, rotate)
+	feCopy(&denInv, &den2)
+	feCMove(&denInv, &enchantedDen, rotate)
+
+	feMul(&t, &x, &zInv)
+	feCNeg(&y, int32(feIsNegative(&t)))
+
+	feSub(&t, &h.Z, &y)
+	feMul(&t, &t, &denInv)
+	feAbs(&t, &t) // s = |den_inv * (Z0 - Y)|
+	feToBytes(s, &t)
+}
+
+// ristrettoDecode sets h to the point of the canonical ristretto255
+// encoding s and reports whether s was valid.
+func ristrettoDecode(h *extendedGroupElement, s []byte) bool {
+	if len(s) != 32 {
+		return false
+	}
+
+	// s must be the canonical encoding of a non-negative field element.
+	var f fieldElement
+	var c [32]byte
+	feFromBytes(&f, s)
+	feToBytes(&c, &f)
+	var diff byte
+	for i := range c {
+		diff |= c[i] ^ s[i]
+	}
+	if diff != 0 || feIsNegative(&f) == 1 {
+		return false
+	}
+
+	var ss, u1, u2, u2Sqr, v, t, invSqrt, denX, denY, one fieldElement
+	feOne(&one)
+
+	feSquare(&ss, &f)
+	feSub(&u1, &one, &ss) // u1 = 1 - s^2
+	feAdd(&u2, &one, &ss) // u2 = 1 + s^2
+	feSquare(&u2Sqr, &u2)
+
+	feSquare(&v, &u1)
+	feMul(&v, &v, &d)
+	feNeg(&v, &v)
+	feSub(&v, &v, &u2Sqr) // v = -(d * u1^2) - u2^2
+
+	feMul(&t, &v, &u2Sqr)
+	wasSquare := feSqrtRatioM1(&invSqrt, &one, &t)
+
+	feMul(&denX, &invSqrt, &u2)
+	feMul(&denY, &invSqrt, &denX)
+	feMul(&denY, &denY, &v)
+
+	feAdd(&t, &f, &f)
+	feMul(&t, &t, &denX)
+	feAbs(&h.X, &t) // x = |2 * s * den_x|
+	feMul(&h.Y, &u1, &denY)
+	feOne(&h.Z)
+	feMul(&h.T, &h.X, &h.Y)
+
+	return wasSquare == 1 && feIsNegative(&h.T) == 0 && feIsNonZero(&h.Y) == 1
+}
+
+// ristrettoEqual reports whether h and g represent the same ristretto255
+// element, i.e. whether they differ by a point of the 4-torsion.
+func ristrettoEqual(h, g *extendedGroupElement) bool {
+	var a, b fieldElement
+	feMul(&a, &h.X, &g.Y)
+	feMul(&b, &h.Y, &g.X)
+	e1 := feEqual(&a, &b)
+	feMul(&a, &h.Y, &g.Y)
+	feMul(&b, &h.X, &g.X)
+	e2 := feEqual(&a, &b)
+	return e1|e2 == 1
+}
+
+// ristrettoElligator sets h to the image of the field element t by the
+// MAP function of section 4.3.4 of RFC 9496.
+func ristrettoElligator(h *extendedGroupElement, t *fieldElement) {
+	var r, u, v, s, sPrime, c, n, w0, w1, w2, w3, tmp, one fieldElement
+	feOne(&one)
+
+	feSquare(&r, t)
+	feMul(&r, &r, &sqrtM1) // r = sqrt(-1) * t^2
+
+	feAdd(&u, &r, &one)
+	feMul(&u, &u, &oneMinusDSq) // u = (r + 1) * (1 - d^2)
+
+	feMul(&v, &r, &d)
+	feNeg(&v, &v)
+	feSub(&v, &v, &one)
+	feAdd(&tmp, &r, &d)
+	feMul(&v, &v, &tmp) // v = (-1 - r*d) * (r + d)
+
+	wasSquare := feSqrtRatioM1(&s, &u, &v)
+
+	feMul(&sPrime, &s, t)
+	feAbs(&sPrime, &sPrime)
+	feNeg(&sPrime, &sPrime) // s_prime = -|s * t|
+	feCMove(&s, &sPrime, 1-wasSquare)
+
+	feNeg(&c, &one)
+	feCMove(&c, &r, 1-wasSquare)
+
+	feSub(&n, &r, &one)
+	feMul(&n, &n, &c)
+	feMul(&n, &n, &dMinusOneSq)
+	feSub(&n, &n, &v) // N = c * (r - 1) * (d - 1)^2 - v
+
+	feSquare(&tmp, &s)
+	feAdd(&w0, &s, &s)
+	feMul(&w0, &w0, &v)             // w0 = 2 * s * v
+	feMul(&w1, &n, &sqrtADMinusOne) // w1 = N * sqrt(a*d - 1)
+	feSub(&w2, &one, &tmp)          // w2 = 1 - s^2
+	feAdd(&w3, &one, &tmp)          // w3 = 1 + s^2
+
+	feMul(&h.X, &w0, &w3)
+	feMul(&h.Y, &w2, &w1)
+	feMul(&h.Z, &w1, &w3)
+	feMul(&h.T, &w0, &w2)
+}
+
+// ristrettoFromUniformBytes sets h to the element derived from the 64
+// uniformly random bytes b, as FROM_UNIFORM_BYTES in RFC 9496.
+func ristrettoFromUniformBytes(h *extendedGroupElement, b []byte) {
+	var t1, t2 fieldElement
+	var p1, p2 extendedGroupElement
+	var c cachedGroupElement
+	var r completedGroupElement
+
+	// feFromBytes ignores the most significant bit, as required.
+	feFromBytes(&t1, b[:32])
+	feFromBytes(&t2, b[32:64])
+	ristrettoElligator(&p1, &t1)
+	ristrettoElligator(&p2, &t2)
+
+	p2.ToCached(&c)
+	r.Add(&p1, &c)
+	r.ToExtended(h)
+}
+
+// RistrettoEncode returns the 32 bytes canonical ristretto255 encoding of the
+// Ed25519 point P. All the points of P + E[4] have the same encoding.
+func RistrettoEncode(P kyber.Point) []byte {
+	var b [32]byte
+	ristrettoEncode(&b, &P.(*point).ge)
+	return b[:]
+}
+
+// RistrettoDecode sets the Ed25519 point P to a representative of the
+// ristretto255 element encoded in b. It returns an error, and leaves P
+// unchanged, if b is not a canonical encoding.
+func RistrettoDecode(P kyber.Point, b []byte) error {
+	var h extendedGroupElement
+	if !ristrettoDecode(&h, b) {
+		return errors.New("invalid ristretto255 encoding")
+	}
+	P.(*point).ge = h
+	return nil
+}
+
+// RistrettoEqual reports whether the Ed25519 points P and Q represent the same
+// ristretto255 element.
+func RistrettoEqual(P, Q kyber.Point) bool {
+	return ristrettoEqual(&P.(*point).ge, &Q.(*point).ge)
+}
+
+// RistrettoFromUniformBytes sets the Ed25519 point P to a representative of
+// the ristretto255 element derived from 64 uniformly random bytes with the
+// one-way map of RFC 9496. It panics if b is not 64 bytes long.
+func RistrettoFromUniformBytes(P kyber.Point, b []byte) kyber.Point {
+	if len(b) != 64 {
+		panic("edwards25519: ristretto255 one-way map needs 64 bytes")
+	}
+	ristrettoFromUniformBytes(&P.(*point).ge, b)
+	return P
+}
+
+// RistrettoHash sets the Ed25519 point P to a representative of the
+// ristretto255 element obtained by hashing m with the domain separation tag
+// dst, using expand_message_xmd with SHA-512 followed by the one-way map, as
+// the ristretto255_XMD:SHA-512_R255MAP_RO_ suite of RFC 9380 and 9496.
+func RistrettoHash(P kyber.Point, m []byte, dst string) kyber.Point {
+	b, err := h2c.ExpandMessageXMD(sha512.New(), m, dst, 64)
+	if err != nil {
+		panic("edwards25519: " + err.Error())
+	}
+	return RistrettoFromUniformBytes(P, b)
+}
@@ -12,8 +12,8 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
@@ -198,6 +198,17 @@ func (s *scalar) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.ScalarUnmarshalFrom(s, r)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (s *scalar) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(s)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *scalar) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(s, text)
+}
+
 func newScalarInt(i *big.Int) *scalar {
 	s := scalar{}
 	s.setInt(mod.NewInt(i, fullOrder))
@@ -9,8 +9,8 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
 type basicPoint struct {
@@ -60,6 +60,14 @@ func (P *basicPoint) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *basicPoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *basicPoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 // Equal tests for two Points on the same curve
 func (P *basicPoint) Equal(P2 kyber.Point) bool {
 	E2 := P2.(*basicPoint)
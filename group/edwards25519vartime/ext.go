@@ -7,8 +7,8 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
 type extPoint struct {
@@ -62,6 +62,14 @@ func (P *extPoint) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *extPoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *extPoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 // Equality test for two Points on the same curve.
 // We can avoid inversions here because:
 //
@@ -6,8 +6,8 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
 type projPoint struct {
@@ -54,6 +54,14 @@ func (P *projPoint) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *projPoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *projPoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 // Equality test for two Points on the same curve.
 // We can avoid inversions here because:
 //
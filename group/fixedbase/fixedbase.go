@@ -0,0 +1,103 @@
+// Package fixedbase implements precomputed tables of multiples of a point,
+// speeding up the repeated multiplication of the same point by different
+// scalars, for any kyber.Group.
+//
+// Groups whose points implement kyber.FixedBasePrecomputer provide their
+// own tables, which run in constant time if the multiplications of the
+// group do, and can thus be used on secret Scalars, as returned by Native.
+// For every other group Precompute falls back to a generic table of signed
+// windows working on the kyber.Point interface. Its multiplications run in
+// variable time, thus it must only be used on public Scalars, never on
+// secret keys, nonces or coefficients.
+//
+// Building a table costs a few scalar multiplications, thus it only pays
+// off for points multiplied more than a handful of times, such as a
+// long-lived public key checking many signatures.
+package fixedbase
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// Threshold is the number of multiplications of the same point from which
+// precomputing a table pays off on most groups.
+const Threshold = 8
+
+// Window is the width of the signed windows of the generic tables.
+const Window = 4
+
+// Precompute returns the table of multiples of P, or of the standard base
+// point of g if P is nil. The table doesn't depend on P once built, so P
+// may be modified afterwards. It is the native table of the group if there
+// is one, or else a generic table, which must only be used on public
+// Scalars.
+func Precompute(g kyber.Group, P kyber.Point) kyber.FixedBase {
+	if fb, ok := Native(g, P); ok {
+		return fb
+	}
+	return Generic(g, P)
+}
+
+// Native returns the table of multiples of P, or of the standard base point
+// of g if P is nil, provided by the group, and false if the group has none.
+// The native tables run in constant time if the multiplications of their
+// group do, thus they can be used wherever Mul is, on secret Scalars too.
+func Native(g kyber.Group, P kyber.Point) (kyber.FixedBase, bool) {
+	if P == nil {
+		P = g.Point().Base()
+	}
+	if p, ok := P.(kyber.FixedBasePrecomputer); ok {
+		return p.Precompute(), true
+	}
+	return nil, false
+}
+
+// Generic returns the table of multiples of P, using only the methods of
+// the kyber.Point interface. It is the fallback of Precompute and a
+// reference for the group specific implementations. The multiplications
+// of the returned table run in variable time, thus they must only be used
+// on public Scalars.
+func Generic(g kyber.Group, P kyber.Point) kyber.FixedBase {
+	if P == nil {
+		P = g.Point().Base()
+	}
+
+	// One more window than the bits of a scalar for the final carry.
+	windows := (8*g.Scalar().MarshalSize() + Window) / Window
+	half := 1 << (Window - 1)
+	table := make([][]kyber.Point, windows)
+	base := P.Clone()
+	for i := range table {
+		t := make([]kyber.Point, half)
+		t[0] = base
+		for j := 1; j < half; j++ {
+			t[j] = g.Point().Add(t[j-1], base)
+		}
+		table[i] = t
+		// 2^Window times the base of this window is twice its largest entry.
+		base = g.Point().Add(t[half-1], t[half-1])
+	}
+	return &generic{g: g, table: table}
+}
+
+// generic holds table[i][j] = (j+1) * 2^(Window*i) * P, so that a
+// multiplication only needs one addition per window and no doubling.
+type generic struct {
+	g     kyber.Group
+	table [][]kyber.Point
+}
+
+func (fb *generic) Mul(s kyber.Scalar) kyber.Point {
+	digits := msm.SignedDigits(msm.ScalarsBytes([]kyber.Scalar{s})[0], Window)
+	acc := fb.g.Point().Null()
+	for i, d := range digits {
+		switch {
+		case d > 0:
+			acc.Add(acc, fb.table[i][d-1])
+		case d < 0:
+			acc.Sub(acc, fb.table[i][-d-1])
+		}
+	}
+	return acc
+}
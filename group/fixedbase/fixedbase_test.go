@@ -0,0 +1,58 @@
+package fixedbase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/ed448"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/group/p256ct"
+	"go.dedis.ch/kyber/v4/group/s256"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var groups = []kyber.Group{
+	edwards25519.NewBlakeSHA256Ed25519(),
+	p256.NewBlakeSHA256P256(),
+	p256ct.NewBlakeSHA256P256(),
+	s256.NewSuite(),
+	ed448.NewSHAKE256Ed448(),
+}
+
+func TestPrecompute(t *testing.T) {
+	rng := random.New()
+	for _, g := range groups {
+		P := g.Point().Pick(rng)
+		fb := Precompute(g, P)
+		for i := 0; i < 10; i++ {
+			s := g.Scalar().Pick(rng)
+			require.True(t, g.Point().Mul(s, P).Equal(fb.Mul(s)), g.String())
+		}
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	rng := random.New()
+	for _, g := range groups {
+		P := g.Point().Pick(rng)
+		s := g.Scalar().Pick(rng)
+		fb := Precompute(g, P)
+		b.Run(g.String()+"/variable-base", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				g.Point().Mul(s, P)
+			}
+		})
+		b.Run(g.String()+"/fixed-base", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				fb.Mul(s)
+			}
+		})
+		b.Run(g.String()+"/precompute", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Precompute(g, P)
+			}
+		})
+	}
+}
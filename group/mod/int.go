@@ -10,7 +10,7 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
@@ -360,6 +360,17 @@ func (i *Int) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.ScalarUnmarshalFrom(i, r)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (i *Int) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(i)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Int) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(i, text)
+}
+
 // BigEndian encodes the value of this Int into a big-endian byte-slice
 // at least min bytes but no more than max bytes long.
 // Panics if max != 0 and the Int cannot be represented in max bytes.
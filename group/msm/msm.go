@@ -0,0 +1,219 @@
+// Package msm implements multi-scalar multiplication, i.e. the computation
+// of s_1*P_1 + ... + s_n*P_n, for any kyber.Group.
+//
+// Groups whose points implement kyber.MultiScalarMultiplier provide their
+// own optimized implementation, for every other group a generic Straus or
+// Pippenger algorithm working on the kyber.Point interface is used.
+//
+// All the algorithms of this package run in variable time, they must only be
+// used on public values, e.g. when verifying proofs or signatures.
+package msm
+
+import (
+	"math/bits"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// StrausThreshold is the number of terms from which Pippenger's bucket
+// method becomes faster than Straus' interleaved windowed method.
+const StrausThreshold = 64
+
+// StrausWindow is the width of the signed windows used by Straus' method.
+const StrausWindow = 5
+
+// MultiScalarMul returns a new point of g set to the sum of
+// scalars[i]*points[i]. A nil point stands for the standard base point.
+// It panics if the number of scalars and points differ.
+func MultiScalarMul(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	checkLengths(scalars, points)
+	P := g.Point()
+	if m, ok := P.(kyber.MultiScalarMultiplier); ok {
+		return m.MultiScalarMul(scalars, points)
+	}
+	return Generic(g, scalars, points)
+}
+
+// Generic returns a new point of g set to the sum of scalars[i]*points[i],
+// using only the methods of the kyber.Point interface. It is the fallback
+// of MultiScalarMul and a reference for the group specific implementations.
+func Generic(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	checkLengths(scalars, points)
+	switch len(points) {
+	case 0:
+		return g.Point().Null()
+	case 1:
+		return g.Point().Mul(scalars[0], points[0])
+	}
+
+	ps := make([]kyber.Point, len(points))
+	for i, p := range points {
+		if p == nil {
+			p = g.Point().Base()
+		}
+		ps[i] = p
+	}
+	ks := ScalarsBytes(scalars)
+	if len(ps) < StrausThreshold {
+		return straus(g, ks, ps)
+	}
+	return pippenger(g, ks, ps)
+}
+
+func checkLengths(scalars []kyber.Scalar, points []kyber.Point) {
+	if len(scalars) != len(points) {
+		panic("msm: mismatched number of scalars and points")
+	}
+}
+
+// straus computes the sum using one table of small multiples per point
+// and a single shared chain of doublings.
+func straus(g kyber.Group, ks [][]byte, points []kyber.Point) kyber.Point {
+	half := 1 << (StrausWindow - 1)
+	tables := make([][]kyber.Point, len(points))
+	digits := make([][]int, len(points))
+	for i, p := range points {
+		t := make([]kyber.Point, half)
+		t[0] = p.Clone()
+		for j := 1; j < half; j++ {
+			t[j] = g.Point().Add(t[j-1], p)
+		}
+		tables[i] = t
+		digits[i] = SignedDigits(ks[i], StrausWindow)
+	}
+
+	acc := g.Point().Null()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < StrausWindow; d++ {
+			acc.Add(acc, acc)
+		}
+		for i, t := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				acc.Add(acc, t[d-1])
+			case d < 0:
+				acc.Sub(acc, t[-d-1])
+			}
+		}
+	}
+	return acc
+}
+
+// pippenger computes the sum with the bucket method: for every window,
+// the points are sorted into buckets according to their digit, and the
+// buckets are then summed with their weights using a running sum.
+func pippenger(g kyber.Group, ks [][]byte, points []kyber.Point) kyber.Point {
+	c := PippengerWindow(len(points))
+	digits := make([][]int, len(points))
+	for i := range points {
+		digits[i] = SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]kyber.Point, 1<<(c-1))
+	acc := g.Point().Null()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < c; d++ {
+			acc.Add(acc, acc)
+		}
+		for b := range buckets {
+			buckets[b] = g.Point().Null()
+		}
+		for i, p := range points {
+			switch d := digits[i][j]; {
+			case d > 0:
+				buckets[d-1].Add(buckets[d-1], p)
+			case d < 0:
+				buckets[-d-1].Sub(buckets[-d-1], p)
+			}
+		}
+		run := g.Point().Null()
+		sum := g.Point().Null()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			run.Add(run, buckets[b])
+			sum.Add(sum, run)
+		}
+		acc.Add(acc, sum)
+	}
+	return acc
+}
+
+// PippengerWindow returns the window width used by Pippenger's method
+// for a sum of n terms.
+func PippengerWindow(n int) int {
+	c := bits.Len(uint(n)) - 2
+	switch {
+	case c < 2:
+		return 2
+	case c > 16:
+		return 16
+	}
+	return c
+}
+
+// ScalarsBytes returns the little-endian encodings of the given scalars, all
+// truncated to the length of the longest significant one so that the
+// recodings of small scalars do not waste time on leading zero windows.
+func ScalarsBytes(scalars []kyber.Scalar) [][]byte {
+	ks := make([][]byte, len(scalars))
+	l := 0
+	for i, s := range scalars {
+		k, err := s.MarshalBinary()
+		if err != nil {
+			panic("msm: cannot marshal scalar: " + err.Error())
+		}
+		if s.ByteOrder() == kyber.BigEndian {
+			for a, b := 0, len(k)-1; a < b; a, b = a+1, b-1 {
+				k[a], k[b] = k[b], k[a]
+			}
+		}
+		n := len(k)
+		for n > 0 && k[n-1] == 0 {
+			n--
+		}
+		if n > l {
+			l = n
+		}
+		ks[i] = k
+	}
+	for i, k := range ks {
+		ks[i] = k[:l]
+	}
+	return ks
+}
+
+// SignedDigits recodes the little-endian integer k into signed digits d_i of
+// c bits, 2 <= c <= 16, such that k = sum d_i*2^(c*i) and
+// -2^(c-1) <= d_i < 2^(c-1). Compared to unsigned digits, this halves the
+// number of multiples a table or a set of buckets has to hold, since
+// negating a point is almost free.
+func SignedDigits(k []byte, c int) []int {
+	if c < 2 || c > 16 {
+		panic("msm: invalid window size")
+	}
+	// One more bit than the length of k is needed for the final carry.
+	digits := make([]int, (8*len(k)+c)/c)
+	carry := 0
+	for i := range digits {
+		d := window(k, i*c, c) + carry
+		carry = 0
+		if d >= 1<<(c-1) {
+			d -= 1 << c
+			carry = 1
+		}
+		digits[i] = d
+	}
+	return digits
+}
+
+// window returns the c bits of the little-endian integer k starting at bit pos.
+func window(k []byte, pos, c int) int {
+	v := 0
+	for j := 0; j < c; j++ {
+		b := pos + j
+		if b >= 8*len(k) {
+			break
+		}
+		v |= int(k[b>>3]>>(b&7)&1) << j
+	}
+	return v
+}
@@ -0,0 +1,52 @@
+package msm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestSignedDigits(t *testing.T) {
+	rng := random.New()
+	for c := 2; c <= 16; c++ {
+		k := random.Bits(256, false, rng)
+		digits := SignedDigits(k, c)
+
+		// reconstruct the little-endian integer from its digits
+		sum := new(big.Int)
+		for i := len(digits) - 1; i >= 0; i-- {
+			require.True(t, digits[i] >= -(1<<(c-1)) && digits[i] < 1<<(c-1))
+			sum.Lsh(sum, uint(c))
+			sum.Add(sum, big.NewInt(int64(digits[i])))
+		}
+		be := make([]byte, len(k))
+		for i := range k {
+			be[len(k)-1-i] = k[i]
+		}
+		require.Equal(t, new(big.Int).SetBytes(be), sum, "window %d", c)
+	}
+}
+
+func TestGeneric(t *testing.T) {
+	g := p256.NewBlakeSHA256P256()
+	rng := random.New()
+	for _, n := range []int{2, StrausThreshold + 1} {
+		scalars := make([]kyber.Scalar, n)
+		points := make([]kyber.Point, n)
+		expected := g.Point().Null()
+		for i := range points {
+			scalars[i] = g.Scalar().Pick(rng)
+			points[i] = g.Point().Pick(rng)
+			expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+		}
+		require.True(t, expected.Equal(MultiScalarMul(g, scalars, points)))
+	}
+
+	require.Panics(t, func() {
+		MultiScalarMul(g, []kyber.Scalar{g.Scalar()}, nil)
+	})
+}
@@ -8,11 +8,13 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
+var marshalPointID = [8]byte{'p', '2', '5', '6', '.', 'p', 'n', 't'}
+
 type curvePoint struct {
 	x, y *big.Int
 	c    *curve
@@ -22,6 +24,11 @@ func (P *curvePoint) String() string {
 	return "(" + P.x.String() + "," + P.y.String() + ")"
 }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (P *curvePoint) MarshalID() [8]byte {
+	return marshalPointID
+}
+
 func (P *curvePoint) Equal(P2 kyber.Point) bool {
 	cp2 := P2.(*curvePoint) //nolint:errcheck // Design pattern to emulate generics
 
@@ -204,6 +211,14 @@ func (P *curvePoint) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *curvePoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *curvePoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 // interface for curve-specifc mathematical functions
 type curveOps interface {
 	sqrt(y *big.Int) *big.Int
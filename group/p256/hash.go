@@ -0,0 +1,40 @@
+package p256
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+)
+
+// DefaultDST is the domain separation tag used by Hash. Applications
+// should pick their own tag and call HashWithDST.
+const DefaultDST = "P256_XMD:SHA-256_SSWU_RO_"
+
+// Hash sets the point to the hash of m, using the P256_XMD:SHA-256_SSWU_RO_
+// suite with DefaultDST.
+func (P *curvePoint) Hash(m []byte) kyber.Point {
+	return P.HashWithDST(m, DefaultDST)
+}
+
+// HashWithDST sets the point to the hash of m, using the
+// P256_XMD:SHA-256_SSWU_RO_ suite of RFC 9380, section 8.2, with the domain
+// separation tag dst, which must not be empty.
+func (P *curvePoint) HashWithDST(m []byte, dst string) kyber.Point {
+	p := P.c.p.P
+	u, err := h2c.HashToField(sha256.New(), m, dst, p, 128, 2)
+	if err != nil {
+		panic("p256: " + err.Error())
+	}
+
+	// The curve has a = -3 and the suite uses Z = -10.
+	A := new(big.Int).Sub(p, big.NewInt(3))
+	Z := new(big.Int).Sub(p, big.NewInt(10))
+	x0, y0 := h2c.SSWU(u[0], A, P.c.p.B, Z, p)
+	x1, y1 := h2c.SSWU(u[1], A, P.c.p.B, Z, p)
+
+	// The cofactor is 1, there is nothing to clear.
+	P.x, P.y = P.c.Add(x0, y0, x1, y1)
+	return P
+}
@@ -9,13 +9,14 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
-	"go.dedis.ch/kyber/v4/group/internal/marshalling"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
 var one = big.NewInt(1)
 var two = big.NewInt(2)
+var marshalResidueID = [8]byte{'r', 'e', 's', '.', 'p', 'n', 't', ' '}
 
 type residuePoint struct {
 	big.Int
@@ -32,6 +33,11 @@ func isPrime(i *big.Int) bool {
 
 func (P *residuePoint) String() string { return P.Int.String() }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (P *residuePoint) MarshalID() [8]byte {
+	return marshalResidueID
+}
+
 func (P *residuePoint) Equal(p2 kyber.Point) bool {
 	return P.Int.Cmp(&p2.(*residuePoint).Int) == 0
 }
@@ -166,6 +172,14 @@ func (P *residuePoint) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
+func (P *residuePoint) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *residuePoint) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
 /*
 A ResidueGroup represents a DSA-style modular integer arithmetic group,
 defined by two primes P and Q and an integer R, such that P = Q*R+1.
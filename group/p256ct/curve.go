@@ -0,0 +1,87 @@
+// Package p256ct implements the NIST P-256 elliptic curve group with
+// constant time arithmetic.
+//
+// Field and scalar elements are fixed-width 256-bit integers in Montgomery
+// form, points use projective coordinates with the complete addition
+// formulas of Renes, Costello and Batina (https://eprint.iacr.org/2015/1060),
+// and scalar multiplications use a fixed window with constant time table
+// lookups, so that no secret value affects the timing of the operations.
+//
+// Points and scalars have the same encodings as the variable time
+// implementation of package go.dedis.ch/kyber/v4/group/p256: points are
+// uncompressed ANSI X9.62 encodings, the neutral element being encoded by
+// zero coordinates, and scalars are 32 bytes big-endian integers.
+package p256ct
+
+import (
+	"crypto/elliptic"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+var (
+	params = elliptic.P256().Params()
+
+	// Arithmetic modulo the field prime p and modulo the group order n
+	field       = newMontgomery(params.P)
+	scalarField = newMontgomery(params.N)
+
+	order       = params.N
+	orderMinus2 = new(big.Int).Sub(params.N, big.NewInt(2)).Bytes()
+	primeMinus2 = new(big.Int).Sub(params.P, big.NewInt(2)).Bytes()
+	// (p + 1) / 4, the exponent of the square root since p = 3 mod 4
+	sqrtExp = new(big.Int).Rsh(new(big.Int).Add(params.P, big.NewInt(1)), 2).Bytes()
+
+	curveB    = montgomeryFromBig(field, params.B)
+	scalar256 = montgomeryFromBig(scalarField, big.NewInt(256))
+	generator = point{
+		x: montgomeryFromBig(field, params.Gx),
+		y: montgomeryFromBig(field, params.Gy),
+		z: field.one,
+	}
+)
+
+func montgomeryFromBig(md *montgomery, x *big.Int) limbs {
+	l := limbsFromBig(x)
+	md.toMontgomery(&l, &l)
+	return l
+}
+
+// Curve represents the P-256 group. There are no parameters and no
+// initialization is required.
+type Curve struct {
+}
+
+// String returns the name of the group, "P256-CT".
+func (c *Curve) String() string {
+	return "P256-CT"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (c *Curve) ScalarLen() int {
+	return 32
+}
+
+// Scalar creates a new Scalar modulo the order of the P-256 group. The
+// scalars of this package interpret the bytes given to SetBytes as a
+// big-endian integer.
+func (c *Curve) Scalar() kyber.Scalar {
+	return &scalar{}
+}
+
+// PointLen returns 65, the size in bytes of an uncompressed encoded Point.
+func (c *Curve) PointLen() int {
+	return 65
+}
+
+// Point creates a new Point, set to the neutral element.
+func (c *Curve) Point() kyber.Point {
+	P := new(point)
+	return P.Null()
+}
+
+// Order returns the order of the P-256 group.
+func (c *Curve) Order() *big.Int {
+	return new(big.Int).Set(order)
+}
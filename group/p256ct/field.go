@@ -0,0 +1,210 @@
+package p256ct
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// limbs is a 256-bit integer stored as four little-endian 64-bit words.
+type limbs [4]uint64
+
+// montgomery implements constant time arithmetic modulo an odd 256-bit
+// modulus m. Elements are kept in the Montgomery domain, i.e. x is
+// represented by x*R mod m with R = 2^256, and are always fully reduced.
+type montgomery struct {
+	m    limbs
+	mInv uint64 // -m^-1 mod 2^64
+	r2   limbs  // R^2 mod m
+	one  limbs  // R mod m, the Montgomery form of 1
+}
+
+func newMontgomery(m *big.Int) *montgomery {
+	md := &montgomery{m: limbsFromBig(m)}
+
+	// Newton iteration for m^-1 mod 2^64, doubling the correct bits
+	// at every step starting from 3 correct bits.
+	inv := md.m[0]
+	for i := 0; i < 5; i++ {
+		inv *= 2 - md.m[0]*inv
+	}
+	md.mInv = -inv
+
+	R := new(big.Int).Lsh(big.NewInt(1), 256)
+	md.one = limbsFromBig(new(big.Int).Mod(R, m))
+	md.r2 = limbsFromBig(new(big.Int).Mod(new(big.Int).Mul(R, R), m))
+	return md
+}
+
+func limbsFromBig(x *big.Int) limbs {
+	var b [32]byte
+	x.FillBytes(b[:])
+	return limbsFromBytes(&b)
+}
+
+// limbsFromBytes reads a 32 bytes big-endian integer.
+func limbsFromBytes(b *[32]byte) limbs {
+	var l limbs
+	for i := range l {
+		for j := 0; j < 8; j++ {
+			l[i] |= uint64(b[31-8*i-j]) << (8 * j)
+		}
+	}
+	return l
+}
+
+// bytes returns the 32 bytes big-endian encoding of x.
+func (x *limbs) bytes() [32]byte {
+	var b [32]byte
+	for i := range x {
+		for j := 0; j < 8; j++ {
+			b[31-8*i-j] = byte(x[i] >> (8 * j))
+		}
+	}
+	return b
+}
+
+// isZero returns 1 if x == 0 and 0 otherwise.
+func (x *limbs) isZero() uint64 {
+	v := x[0] | x[1] | x[2] | x[3]
+	return 1 ^ (v|-v)>>63
+}
+
+// equal returns 1 if x == y and 0 otherwise.
+func (x *limbs) equal(y *limbs) uint64 {
+	d := limbs{x[0] ^ y[0], x[1] ^ y[1], x[2] ^ y[2], x[3] ^ y[3]}
+	return d.isZero()
+}
+
+// selectLimbs sets z = a if c == 1 and z = b if c == 0.
+func (z *limbs) selectLimbs(a, b *limbs, c uint64) {
+	mask := -c
+	for i := range z {
+		z[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+}
+
+// lessThan returns 1 if x < y and 0 otherwise.
+func (x *limbs) lessThan(y *limbs) uint64 {
+	var b uint64
+	_, b = bits.Sub64(x[0], y[0], b)
+	_, b = bits.Sub64(x[1], y[1], b)
+	_, b = bits.Sub64(x[2], y[2], b)
+	_, b = bits.Sub64(x[3], y[3], b)
+	return b
+}
+
+// reduce sets z = c:t mod m, assuming that c:t < 2m.
+func (md *montgomery) reduce(z *limbs, t *limbs, c uint64) {
+	var u limbs
+	var b uint64
+	u[0], b = bits.Sub64(t[0], md.m[0], 0)
+	u[1], b = bits.Sub64(t[1], md.m[1], b)
+	u[2], b = bits.Sub64(t[2], md.m[2], b)
+	u[3], b = bits.Sub64(t[3], md.m[3], b)
+	_, b = bits.Sub64(c, 0, b)
+	// A final borrow means that c:t < m, which must then be kept.
+	z.selectLimbs(t, &u, b)
+}
+
+// add sets z = x + y mod m.
+func (md *montgomery) add(z, x, y *limbs) {
+	var t limbs
+	var c uint64
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], c = bits.Add64(x[3], y[3], c)
+	md.reduce(z, &t, c)
+}
+
+// sub sets z = x - y mod m.
+func (md *montgomery) sub(z, x, y *limbs) {
+	var t limbs
+	var b, c uint64
+	t[0], b = bits.Sub64(x[0], y[0], 0)
+	t[1], b = bits.Sub64(x[1], y[1], b)
+	t[2], b = bits.Sub64(x[2], y[2], b)
+	t[3], b = bits.Sub64(x[3], y[3], b)
+	// Add m back if the subtraction borrowed.
+	mask := -b
+	z[0], c = bits.Add64(t[0], md.m[0]&mask, 0)
+	z[1], c = bits.Add64(t[1], md.m[1]&mask, c)
+	z[2], c = bits.Add64(t[2], md.m[2]&mask, c)
+	z[3], _ = bits.Add64(t[3], md.m[3]&mask, c)
+}
+
+// neg sets z = -x mod m.
+func (md *montgomery) neg(z, x *limbs) {
+	var zero limbs
+	md.sub(z, &zero, x)
+}
+
+// mul sets z = x * y / R mod m, which is the Montgomery form of the
+// product of two elements in Montgomery form, using the coarsely
+// integrated operand scanning method.
+func (md *montgomery) mul(z, x, y *limbs) {
+	var t [6]uint64
+	var c, cc, hi, lo uint64
+	for i := 0; i < 4; i++ {
+		// t += x * y[i]
+		c = 0
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+
+		// t = (t + q*m) / 2^64, with q chosen so that the division is exact.
+		q := t[0] * md.mInv
+		hi, lo = bits.Mul64(q, md.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(q, md.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+	md.reduce(z, &limbs{t[0], t[1], t[2], t[3]}, t[4])
+}
+
+// square sets z = x * x / R mod m.
+func (md *montgomery) square(z, x *limbs) {
+	md.mul(z, x, x)
+}
+
+// toMontgomery sets z to the Montgomery form of the reduced integer x.
+func (md *montgomery) toMontgomery(z, x *limbs) {
+	md.mul(z, x, &md.r2)
+}
+
+// fromMontgomery sets z to the reduced integer of Montgomery form x.
+func (md *montgomery) fromMontgomery(z, x *limbs) {
+	md.mul(z, x, &limbs{1})
+}
+
+// exp sets z = x^e mod m. The exponent e is a public big-endian integer,
+// its bits decide the sequence of operations but x remains secret.
+func (md *montgomery) exp(z, x *limbs, e []byte) {
+	r := md.one
+	base := *x
+	for _, b := range e {
+		for i := 7; i >= 0; i-- {
+			md.square(&r, &r)
+			if b>>i&1 == 1 {
+				md.mul(&r, &r, &base)
+			}
+		}
+	}
+	*z = r
+}
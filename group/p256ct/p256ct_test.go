@@ -0,0 +1,96 @@
+package p256ct
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/util/test"
+)
+
+var tSuite = NewBlakeSHA256P256()
+var groupBench = test.NewGroupBench(tSuite)
+
+func TestSuite(t *testing.T) { test.SuiteTest(t, tSuite) }
+
+// The variable time implementation must produce the same results from the
+// same random stream.
+func TestCompareP256(t *testing.T) {
+	test.CompareGroups(t, tSuite.XOF, p256.NewBlakeSHA256P256(), tSuite)
+}
+
+func TestScalarMultAgainstStdlib(t *testing.T) {
+	c := elliptic.P256()
+	rand := random.New()
+	P := tSuite.Point().Pick(rand)
+	buf, err := P.MarshalBinary()
+	require.NoError(t, err)
+	px, py := elliptic.Unmarshal(c, buf) //nolint:staticcheck // reference implementation
+
+	for i := 0; i < 32; i++ {
+		s := tSuite.Scalar().Pick(rand)
+		k, err := s.MarshalBinary()
+		require.NoError(t, err)
+
+		x, y := c.ScalarBaseMult(k) //nolint:staticcheck // reference implementation
+		b, err := tSuite.Point().Mul(s, nil).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(c, x, y), b) //nolint:staticcheck // reference implementation
+
+		x, y = c.ScalarMult(px, py, k) //nolint:staticcheck // reference implementation
+		b, err = tSuite.Point().Mul(s, P).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, elliptic.Marshal(c, x, y), b) //nolint:staticcheck // reference implementation
+	}
+}
+
+// Pick must consume the stream as random.Int, and return the same values.
+func TestScalarPick(t *testing.T) {
+	r1, r2 := tSuite.XOF([]byte("pick")), tSuite.XOF([]byte("pick"))
+	for i := 0; i < 64; i++ {
+		buf, err := tSuite.Scalar().Pick(r1).MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, random.Int(tSuite.Order(), r2).FillBytes(make([]byte, 32)), buf)
+	}
+}
+
+func TestScalarEdgeCases(t *testing.T) {
+	n := tSuite.Order()
+	s := tSuite.Scalar().SetBytes(n.Bytes())
+	require.True(t, s.Equal(tSuite.Scalar().Zero()))
+
+	s.SetInt64(-1)
+	require.Equal(t, new(big.Int).Sub(n, big.NewInt(1)).Text(16), s.String())
+
+	buf := n.FillBytes(make([]byte, 32))
+	require.Error(t, s.UnmarshalBinary(buf))
+
+	P := tSuite.Point().Mul(tSuite.Scalar().SetInt64(-1), nil)
+	require.True(t, P.Equal(tSuite.Point().Neg(tSuite.Point().Base())))
+}
+
+func TestPointEncoding(t *testing.T) {
+	buf, err := tSuite.Point().Null().MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{4}, make([]byte, 64)...), buf)
+
+	P := tSuite.Point().Base()
+	require.NoError(t, P.UnmarshalBinary(buf))
+	require.True(t, P.Equal(tSuite.Point().Null()))
+
+	// Not on the curve
+	buf[64] = 1
+	require.Error(t, P.UnmarshalBinary(buf))
+	require.Error(t, P.UnmarshalBinary(buf[:64]))
+}
+
+func BenchmarkScalarMul(b *testing.B)    { groupBench.ScalarMul(b.N) }
+func BenchmarkScalarInv(b *testing.B)    { groupBench.ScalarInv(b.N) }
+func BenchmarkPointAdd(b *testing.B)     { groupBench.PointAdd(b.N) }
+func BenchmarkPointMul(b *testing.B)     { groupBench.PointMul(b.N) }
+func BenchmarkPointBaseMul(b *testing.B) { groupBench.PointBaseMul(b.N) }
+func BenchmarkPointEncode(b *testing.B)  { groupBench.PointEncode(b.N) }
+func BenchmarkPointDecode(b *testing.B)  { groupBench.PointDecode(b.N) }
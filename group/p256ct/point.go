@@ -0,0 +1,454 @@
+package p256ct
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var marshalPointID = [8]byte{'p', '2', '5', '6', '.', 'p', 'n', 't'}
+
+// point is a P-256 point in projective coordinates (X:Y:Z), representing
+// the affine point (X/Z, Y/Z), with the coordinates in Montgomery form.
+// The neutral element is (0:1:0).
+type point struct {
+	x, y, z limbs
+}
+
+// window is the width of the fixed windows of the scalar multiplication.
+const window = 4
+
+// table holds the multiples 0*P through 15*P of a point P.
+type table [1 << window]point
+
+// fixedBase holds the tables of the multiples of 16^i * P for every
+// window i of a scalar, so that no doubling is needed to multiply P.
+type fixedBase [64]table
+
+var baseTable struct {
+	sync.Once
+	fb fixedBase
+}
+
+func (P *point) String() string {
+	x, y := P.affine()
+	return "(" + new(big.Int).SetBytes(x[:]).String() + "," +
+		new(big.Int).SetBytes(y[:]).String() + ")"
+}
+
+// affine returns the big-endian affine coordinates of P, which are both
+// zero for the neutral element.
+func (P *point) affine() ([32]byte, [32]byte) {
+	var zInv, x, y limbs
+	field.exp(&zInv, &P.z, primeMinus2)
+	field.mul(&x, &P.x, &zInv)
+	field.mul(&y, &P.y, &zInv)
+	field.fromMontgomery(&x, &x)
+	field.fromMontgomery(&y, &y)
+	return x.bytes(), y.bytes()
+}
+
+func (P *point) MarshalSize() int {
+	return 65
+}
+
+func (P *point) MarshalBinary() ([]byte, error) {
+	x, y := P.affine()
+	b := make([]byte, 65)
+	b[0] = 4 // uncompressed
+	copy(b[1:33], x[:])
+	copy(b[33:], y[:])
+	return b, nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (P *point) MarshalID() [8]byte {
+	return marshalPointID
+}
+
+// UnmarshalBinary sets the point from its uncompressed encoding. The
+// neutral element is encoded by zero coordinates.
+func (P *point) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 65 || buf[0] != 4 {
+		return errors.New("invalid P-256 point encoding")
+	}
+	var bx, by [32]byte
+	copy(bx[:], buf[1:33])
+	copy(by[:], buf[33:])
+	x, y := limbsFromBytes(&bx), limbsFromBytes(&by)
+
+	if x.isZero()&y.isZero() == 1 {
+		P.Null()
+		return nil
+	}
+	if x.lessThan(&field.m)&y.lessThan(&field.m) == 0 {
+		return errors.New("invalid P-256 point encoding")
+	}
+	field.toMontgomery(&x, &x)
+	field.toMontgomery(&y, &y)
+
+	var y2, rhs limbs
+	field.square(&y2, &y)
+	curveEquation(&rhs, &x)
+	if y2.equal(&rhs) == 0 {
+		return errors.New("invalid elliptic curve point")
+	}
+	P.x, P.y, P.z = x, y, field.one
+	return nil
+}
+
+// curveEquation sets z = x^3 - 3x + b.
+func curveEquation(z, x *limbs) {
+	var t, x3 limbs
+	field.square(&t, x)
+	field.mul(&x3, &t, x)
+	field.add(&t, x, x)
+	field.add(&t, &t, x)
+	field.sub(z, &x3, &t)
+	field.add(z, z, &curveB)
+}
+
+func (P *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+func (P *point) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *point) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
+// Equal tests whether two points are equal, by cross-multiplying their
+// coordinates.
+func (P *point) Equal(P2 kyber.Point) bool {
+	Q := P2.(*point) //nolint:errcheck // Design pattern to emulate generics
+	var a, b limbs
+	field.mul(&a, &P.x, &Q.z)
+	field.mul(&b, &Q.x, &P.z)
+	ex := a.equal(&b)
+	field.mul(&a, &P.y, &Q.z)
+	field.mul(&b, &Q.y, &P.z)
+	ey := a.equal(&b)
+	return ex&ey == 1
+}
+
+func (P *point) Null() kyber.Point {
+	P.x, P.y, P.z = limbs{}, field.one, limbs{}
+	return P
+}
+
+func (P *point) Base() kyber.Point {
+	*P = generator
+	return P
+}
+
+func (P *point) Set(P2 kyber.Point) kyber.Point {
+	*P = *P2.(*point)
+	return P
+}
+
+func (P *point) Clone() kyber.Point {
+	Q := *P
+	return &Q
+}
+
+func (P *point) EmbedLen() int {
+	// Reserve at least 8 most-significant bits for randomness,
+	// and the least-significant 8 bits for embedded data length.
+	return (256 - 8 - 8) / 8
+}
+
+// Embed picks a point whose x-coordinate contains the embedded data,
+// using the same layout as package go.dedis.ch/kyber/v4/group/p256.
+func (P *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		b := random.Bits(256, false, rand)
+		if data != nil {
+			b[31] = byte(dl)        // Encode length in low 8 bits
+			copy(b[31-dl:31], data) // Copy in data to embed
+		}
+		if P.genPoint(b, rand) {
+			return P
+		}
+	}
+}
+
+// genPoint tries to set P to a point with the big-endian x-coordinate b,
+// and a random sign.
+func (P *point) genPoint(b []byte, rand cipher.Stream) bool {
+	var bx [32]byte
+	copy(bx[:], b)
+	x := limbsFromBytes(&bx)
+	if x.lessThan(&field.m) == 0 {
+		return false
+	}
+	field.toMontgomery(&x, &x)
+
+	var y, yNeg, y2, check limbs
+	curveEquation(&y2, &x)
+	field.exp(&y, &y2, sqrtExp)
+
+	// Pick a random sign for the y coordinate
+	s := make([]byte, 1)
+	rand.XORKeyStream(s, s)
+	field.neg(&yNeg, &y)
+	y.selectLimbs(&yNeg, &y, uint64(s[0]>>7))
+
+	field.square(&check, &y)
+	if check.equal(&y2) == 0 {
+		return false
+	}
+
+	P.x, P.y, P.z = x, y, field.one
+	return true
+}
+
+func (P *point) Pick(rand cipher.Stream) kyber.Point {
+	return P.Embed(nil, rand)
+}
+
+// Data extracts embedded data from a curve point
+func (P *point) Data() ([]byte, error) {
+	x, _ := P.affine()
+	dl := int(x[31])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return x[31-dl : 31], nil
+}
+
+// Add sets P = A + B with the complete addition formula of Renes, Costello
+// and Batina for curves with a = -3 (algorithm 4).
+func (P *point) Add(A, B kyber.Point) kyber.Point {
+	pointAdd(P, A.(*point), B.(*point))
+	return P
+}
+
+func (P *point) Sub(A, B kyber.Point) kyber.Point {
+	var N point
+	pointNeg(&N, B.(*point))
+	pointAdd(P, A.(*point), &N)
+	return P
+}
+
+func (P *point) Neg(A kyber.Point) kyber.Point {
+	pointNeg(P, A.(*point))
+	return P
+}
+
+// Mul sets P = s*B, or s times the base point if B is nil, in constant
+// time with fixed windows of 4 bits.
+func (P *point) Mul(s kyber.Scalar, B kyber.Point) kyber.Point {
+	k := s.(*scalar).bytes()
+	if B == nil {
+		baseTable.Do(func() { baseTable.fb.init(&generator) })
+		P.fixedBaseMult(&baseTable.fb, &k)
+		return P
+	}
+
+	var t table
+	t.init(B.(*point))
+	P.scalarMult(&t, &k)
+	return P
+}
+
+// init fills the table with the multiples of Q.
+func (t *table) init(Q *point) {
+	t[0].Null()
+	t[1] = *Q
+	for i := 2; i < len(t); i += 2 {
+		pointDouble(&t[i], &t[i/2])
+		pointAdd(&t[i+1], &t[i], Q)
+	}
+}
+
+// selectPoint sets P to t[d] while reading every entry of the table.
+func (t *table) selectPoint(P *point, d byte) {
+	P.Null()
+	for i := range t {
+		eq := uint64(subtle.ConstantTimeByteEq(byte(i), d))
+		P.x.selectLimbs(&t[i].x, &P.x, eq)
+		P.y.selectLimbs(&t[i].y, &P.y, eq)
+		P.z.selectLimbs(&t[i].z, &P.z, eq)
+	}
+}
+
+// scalarMult sets P to k times the point of table t, where k is a
+// big-endian scalar.
+func (P *point) scalarMult(t *table, k *[32]byte) {
+	var acc, q point
+	acc.Null()
+	for _, b := range k {
+		for _, d := range [2]byte{b >> 4, b & 0xf} {
+			for i := 0; i < window; i++ {
+				pointDouble(&acc, &acc)
+			}
+			t.selectPoint(&q, d)
+			pointAdd(&acc, &acc, &q)
+		}
+	}
+	*P = acc
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs in constant time at the speed of
+// the multiplication of the base point.
+func (P *point) Precompute() kyber.FixedBase {
+	fb := new(fixedBase)
+	fb.init(P)
+	return fb
+}
+
+// Mul returns s times the precomputed point.
+func (fb *fixedBase) Mul(s kyber.Scalar) kyber.Point {
+	k := s.(*scalar).bytes()
+	P := new(point)
+	P.fixedBaseMult(fb, &k)
+	return P
+}
+
+// init fills the tables with the multiples of Q.
+func (fb *fixedBase) init(Q *point) {
+	q := *Q
+	for i := range fb {
+		fb[i].init(&q)
+		for j := 0; j < window; j++ {
+			pointDouble(&q, &q)
+		}
+	}
+}
+
+// fixedBaseMult sets P to k times the point of the tables fb, where k is
+// a big-endian scalar.
+func (P *point) fixedBaseMult(fb *fixedBase, k *[32]byte) {
+	var acc, q point
+	acc.Null()
+	for i, b := range k {
+		w := 2 * (len(k) - 1 - i)
+		fb[w].selectPoint(&q, b&0xf)
+		pointAdd(&acc, &acc, &q)
+		fb[w+1].selectPoint(&q, b>>4)
+		pointAdd(&acc, &acc, &q)
+	}
+	*P = acc
+}
+
+func pointNeg(P, A *point) {
+	P.x = A.x
+	field.neg(&P.y, &A.y)
+	P.z = A.z
+}
+
+// pointAdd sets P = A + B, see algorithm 4 of
+// https://eprint.iacr.org/2015/1060. It is complete: it handles
+// doublings and the neutral element without exceptions.
+func pointAdd(P, A, B *point) {
+	var t0, t1, t2, t3, t4, x3, y3, z3 limbs
+
+	field.mul(&t0, &A.x, &B.x)   // t0 := X1 * X2
+	field.mul(&t1, &A.y, &B.y)   // t1 := Y1 * Y2
+	field.mul(&t2, &A.z, &B.z)   // t2 := Z1 * Z2
+	field.add(&t3, &A.x, &A.y)   // t3 := X1 + Y1
+	field.add(&t4, &B.x, &B.y)   // t4 := X2 + Y2
+	field.mul(&t3, &t3, &t4)     // t3 := t3 * t4
+	field.add(&t4, &t0, &t1)     // t4 := t0 + t1
+	field.sub(&t3, &t3, &t4)     // t3 := t3 - t4
+	field.add(&t4, &A.y, &A.z)   // t4 := Y1 + Z1
+	field.add(&x3, &B.y, &B.z)   // X3 := Y2 + Z2
+	field.mul(&t4, &t4, &x3)     // t4 := t4 * X3
+	field.add(&x3, &t1, &t2)     // X3 := t1 + t2
+	field.sub(&t4, &t4, &x3)     // t4 := t4 - X3
+	field.add(&x3, &A.x, &A.z)   // X3 := X1 + Z1
+	field.add(&y3, &B.x, &B.z)   // Y3 := X2 + Z2
+	field.mul(&x3, &x3, &y3)     // X3 := X3 * Y3
+	field.add(&y3, &t0, &t2)     // Y3 := t0 + t2
+	field.sub(&y3, &x3, &y3)     // Y3 := X3 - Y3
+	field.mul(&z3, &curveB, &t2) // Z3 := b * t2
+	field.sub(&x3, &y3, &z3)     // X3 := Y3 - Z3
+	field.add(&z3, &x3, &x3)     // Z3 := X3 + X3
+	field.add(&x3, &x3, &z3)     // X3 := X3 + Z3
+	field.sub(&z3, &t1, &x3)     // Z3 := t1 - X3
+	field.add(&x3, &t1, &x3)     // X3 := t1 + X3
+	field.mul(&y3, &curveB, &y3) // Y3 := b * Y3
+	field.add(&t1, &t2, &t2)     // t1 := t2 + t2
+	field.add(&t2, &t1, &t2)     // t2 := t1 + t2
+	field.sub(&y3, &y3, &t2)     // Y3 := Y3 - t2
+	field.sub(&y3, &y3, &t0)     // Y3 := Y3 - t0
+	field.add(&t1, &y3, &y3)     // t1 := Y3 + Y3
+	field.add(&y3, &t1, &y3)     // Y3 := t1 + Y3
+	field.add(&t1, &t0, &t0)     // t1 := t0 + t0
+	field.add(&t0, &t1, &t0)     // t0 := t1 + t0
+	field.sub(&t0, &t0, &t2)     // t0 := t0 - t2
+	field.mul(&t1, &t4, &y3)     // t1 := t4 * Y3
+	field.mul(&t2, &t0, &y3)     // t2 := t0 * Y3
+	field.mul(&y3, &x3, &z3)     // Y3 := X3 * Z3
+	field.add(&y3, &y3, &t2)     // Y3 := Y3 + t2
+	field.mul(&x3, &t3, &x3)     // X3 := t3 * X3
+	field.sub(&x3, &x3, &t1)     // X3 := X3 - t1
+	field.mul(&z3, &t4, &z3)     // Z3 := t4 * Z3
+	field.mul(&t1, &t3, &t0)     // t1 := t3 * t0
+	field.add(&z3, &z3, &t1)     // Z3 := Z3 + t1
+
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// pointDouble sets P = 2A, see algorithm 6 of
+// https://eprint.iacr.org/2015/1060.
+func pointDouble(P, A *point) {
+	var t0, t1, t2, t3, x3, y3, z3 limbs
+
+	field.square(&t0, &A.x)      // t0 := X ^ 2
+	field.square(&t1, &A.y)      // t1 := Y ^ 2
+	field.square(&t2, &A.z)      // t2 := Z ^ 2
+	field.mul(&t3, &A.x, &A.y)   // t3 := X * Y
+	field.add(&t3, &t3, &t3)     // t3 := t3 + t3
+	field.mul(&z3, &A.x, &A.z)   // Z3 := X * Z
+	field.add(&z3, &z3, &z3)     // Z3 := Z3 + Z3
+	field.mul(&y3, &curveB, &t2) // Y3 := b * t2
+	field.sub(&y3, &y3, &z3)     // Y3 := Y3 - Z3
+	field.add(&x3, &y3, &y3)     // X3 := Y3 + Y3
+	field.add(&y3, &x3, &y3)     // Y3 := X3 + Y3
+	field.sub(&x3, &t1, &y3)     // X3 := t1 - Y3
+	field.add(&y3, &t1, &y3)     // Y3 := t1 + Y3
+	field.mul(&y3, &x3, &y3)     // Y3 := X3 * Y3
+	field.mul(&x3, &x3, &t3)     // X3 := X3 * t3
+	field.add(&t3, &t2, &t2)     // t3 := t2 + t2
+	field.add(&t2, &t2, &t3)     // t2 := t2 + t3
+	field.mul(&z3, &curveB, &z3) // Z3 := b * Z3
+	field.sub(&z3, &z3, &t2)     // Z3 := Z3 - t2
+	field.sub(&z3, &z3, &t0)     // Z3 := Z3 - t0
+	field.add(&t3, &z3, &z3)     // t3 := Z3 + Z3
+	field.add(&z3, &z3, &t3)     // Z3 := Z3 + t3
+	field.add(&t3, &t0, &t0)     // t3 := t0 + t0
+	field.add(&t0, &t3, &t0)     // t0 := t3 + t0
+	field.sub(&t0, &t0, &t2)     // t0 := t0 - t2
+	field.mul(&t0, &t0, &z3)     // t0 := t0 * Z3
+	field.add(&y3, &y3, &t0)     // Y3 := Y3 + t0
+	field.mul(&t0, &A.y, &A.z)   // t0 := Y * Z
+	field.add(&t0, &t0, &t0)     // t0 := t0 + t0
+	field.mul(&z3, &t0, &z3)     // Z3 := t0 * Z3
+	field.sub(&x3, &x3, &z3)     // X3 := X3 - Z3
+	field.mul(&z3, &t0, &t1)     // Z3 := t0 * t1
+	field.add(&z3, &z3, &z3)     // Z3 := Z3 + Z3
+	field.add(&z3, &z3, &z3)     // Z3 := Z3 + Z3
+
+	P.x, P.y, P.z = x3, y3, z3
+}
@@ -0,0 +1,195 @@
+package p256ct
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var marshalScalarID = [8]byte{'p', '2', '5', '6', '.', 's', 'c', 'a'}
+
+// scalar is an integer modulo the order n of the P-256 group, kept in
+// Montgomery form so that every operation runs in constant time.
+type scalar struct {
+	v limbs
+}
+
+func (s *scalar) Equal(s2 kyber.Scalar) bool {
+	return s.v.equal(&s2.(*scalar).v) == 1
+}
+
+func (s *scalar) Set(a kyber.Scalar) kyber.Scalar {
+	s.v = a.(*scalar).v
+	return s
+}
+
+func (s *scalar) Clone() kyber.Scalar {
+	return &scalar{v: s.v}
+}
+
+// SetInt64 sets the scalar to a small integer value.
+func (s *scalar) SetInt64(v int64) kyber.Scalar {
+	neg := uint64(v) >> 63
+	abs := limbs{uint64(v)}
+	var negAbs limbs
+	negAbs[0] = -abs[0]
+	abs.selectLimbs(&negAbs, &abs, neg)
+
+	scalarField.toMontgomery(&s.v, &abs)
+	scalarField.neg(&negAbs, &s.v)
+	s.v.selectLimbs(&negAbs, &s.v, neg)
+	return s
+}
+
+func (s *scalar) Zero() kyber.Scalar {
+	s.v = limbs{}
+	return s
+}
+
+func (s *scalar) Add(a, b kyber.Scalar) kyber.Scalar {
+	scalarField.add(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Sub(a, b kyber.Scalar) kyber.Scalar {
+	scalarField.sub(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Neg(a kyber.Scalar) kyber.Scalar {
+	scalarField.neg(&s.v, &a.(*scalar).v)
+	return s
+}
+
+func (s *scalar) One() kyber.Scalar {
+	s.v = scalarField.one
+	return s
+}
+
+func (s *scalar) Mul(a, b kyber.Scalar) kyber.Scalar {
+	scalarField.mul(&s.v, &a.(*scalar).v, &b.(*scalar).v)
+	return s
+}
+
+func (s *scalar) Div(a, b kyber.Scalar) kyber.Scalar {
+	var inv limbs
+	scalarField.exp(&inv, &b.(*scalar).v, orderMinus2)
+	scalarField.mul(&s.v, &a.(*scalar).v, &inv)
+	return s
+}
+
+// Inv sets the scalar to the modular inverse of a, computed as a^(n-2).
+// The inverse of zero is zero.
+func (s *scalar) Inv(a kyber.Scalar) kyber.Scalar {
+	scalarField.exp(&s.v, &a.(*scalar).v, orderMinus2)
+	return s
+}
+
+// Pick sets the scalar to a uniformly random value, by rejection sampling
+// of 32-byte candidates, which consumes the stream as random.Int does. The
+// comparison of the candidates with the order runs in constant time, and
+// the rejected candidates are discarded.
+func (s *scalar) Pick(rand cipher.Stream) kyber.Scalar {
+	for {
+		var b [32]byte
+		copy(b[:], random.Bits(256, false, rand))
+		v := limbsFromBytes(&b)
+		if v.lessThan(&scalarField.m) == 1 {
+			scalarField.toMontgomery(&s.v, &v)
+			return s
+		}
+	}
+}
+
+// SetBytes sets the scalar from a big-endian byte-slice of any length,
+// reducing it modulo the group order.
+func (s *scalar) SetBytes(b []byte) kyber.Scalar {
+	// Horner's rule in the Montgomery domain, one byte at a time.
+	var acc, d limbs
+	for _, c := range b {
+		scalarField.mul(&acc, &acc, &scalar256)
+		scalarField.toMontgomery(&d, &limbs{uint64(c)})
+		scalarField.add(&acc, &acc, &d)
+	}
+	s.v = acc
+	return s
+}
+
+// ByteOrder returns the byte representation type, which is big-endian
+// for compatibility with package go.dedis.ch/kyber/v4/group/p256.
+func (s *scalar) ByteOrder() kyber.ByteOrder {
+	return kyber.BigEndian
+}
+
+// GroupOrder returns the order of the P-256 group.
+func (s *scalar) GroupOrder() *big.Int {
+	return new(big.Int).Set(order)
+}
+
+func (s *scalar) String() string {
+	b := s.bytes()
+	i := 0
+	for i < len(b) && b[i] == 0 {
+		i++
+	}
+	return hex.EncodeToString(b[i:])
+}
+
+// bytes returns the 32 bytes big-endian encoding of the scalar.
+func (s *scalar) bytes() [32]byte {
+	var v limbs
+	scalarField.fromMontgomery(&v, &s.v)
+	return v.bytes()
+}
+
+func (s *scalar) MarshalSize() int {
+	return 32
+}
+
+func (s *scalar) MarshalBinary() ([]byte, error) {
+	b := s.bytes()
+	return b[:], nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (s *scalar) MarshalID() [8]byte {
+	return marshalScalarID
+}
+
+// UnmarshalBinary sets the scalar from its 32 bytes big-endian encoding,
+// which must be reduced modulo the group order.
+func (s *scalar) UnmarshalBinary(buf []byte) error {
+	if len(buf) != 32 {
+		return errors.New("UnmarshalBinary: wrong size buffer")
+	}
+	var b [32]byte
+	copy(b[:], buf)
+	v := limbsFromBytes(&b)
+	if v.lessThan(&scalarField.m) == 0 {
+		return errors.New("UnmarshalBinary: value out of range")
+	}
+	scalarField.toMontgomery(&s.v, &v)
+	return nil
+}
+
+func (s *scalar) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.ScalarMarshalTo(s, w)
+}
+
+func (s *scalar) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.ScalarUnmarshalFrom(s, r)
+}
+
+func (s *scalar) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(s)
+}
+
+func (s *scalar) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(s, text)
+}
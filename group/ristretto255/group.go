@@ -0,0 +1,49 @@
+// Package ristretto255 implements the ristretto255 prime-order group of
+// RFC 9496 on top of the Ed25519 arithmetic of package
+// go.dedis.ch/kyber/v4/group/edwards25519.
+//
+// Ristretto255 elements are equivalence classes of Ed25519 points modulo the
+// small torsion, so the group has prime order and none of the cofactor
+// pitfalls of Ed25519: every valid encoding is a group element, and every
+// element has a unique encoding. Scalars are the Ed25519 scalars.
+//
+// [RFC9496]: https://datatracker.ietf.org/doc/html/rfc9496
+package ristretto255
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+)
+
+// Group represents the ristretto255 group. There are no parameters and no
+// initialization is required.
+type Group struct {
+	ed edwards25519.Curve
+}
+
+// String returns the name of the group, "Ristretto255".
+func (g *Group) String() string {
+	return "Ristretto255"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (g *Group) ScalarLen() int {
+	return 32
+}
+
+// Scalar creates a new Scalar modulo the order of the group, which is the
+// order of the prime-order subgroup of Ed25519. Scalars are encoded in
+// little-endian.
+func (g *Group) Scalar() kyber.Scalar {
+	return g.ed.Scalar()
+}
+
+// PointLen returns 32, the size in bytes of an encoded Point.
+func (g *Group) PointLen() int {
+	return 32
+}
+
+// Point creates a new ristretto255 Point.
+func (g *Group) Point() kyber.Point {
+	return &point{ed: g.ed.Point()}
+}
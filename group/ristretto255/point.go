@@ -0,0 +1,220 @@
+package ristretto255
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// DefaultDST is the domain separation tag used by Hash.
+const DefaultDST = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+
+var marshalPointID = [8]byte{'r', '2', '5', '5', '.', 'p', 'n', 't'}
+
+// point is a ristretto255 element, represented by any of the Ed25519 points
+// of its equivalence class.
+type point struct {
+	ed kyber.Point
+}
+
+func (P *point) String() string {
+	return hex.EncodeToString(edwards25519.RistrettoEncode(P.ed))
+}
+
+func (P *point) MarshalSize() int {
+	return 32
+}
+
+func (P *point) MarshalBinary() ([]byte, error) {
+	return edwards25519.RistrettoEncode(P.ed), nil
+}
+
+// MarshalID returns the type tag used in encoding/decoding
+func (P *point) MarshalID() [8]byte {
+	return marshalPointID
+}
+
+func (P *point) UnmarshalBinary(b []byte) error {
+	return edwards25519.RistrettoDecode(P.ed, b)
+}
+
+func (P *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(P, w)
+}
+
+func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(P, r)
+}
+
+func (P *point) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(P)
+}
+
+func (P *point) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(P, text)
+}
+
+// Equal tests whether two points represent the same group element.
+func (P *point) Equal(P2 kyber.Point) bool {
+	return edwards25519.RistrettoEqual(P.ed, P2.(*point).ed)
+}
+
+// Set point to be equal to P2.
+func (P *point) Set(P2 kyber.Point) kyber.Point {
+	P.ed.Set(P2.(*point).ed)
+	return P
+}
+
+// Clone returns a copy of the point.
+func (P *point) Clone() kyber.Point {
+	return &point{ed: P.ed.Clone()}
+}
+
+// Null sets the point to the neutral element.
+func (P *point) Null() kyber.Point {
+	P.ed.Null()
+	return P
+}
+
+// Base sets the point to the standard generator, which is the Ed25519
+// base point.
+func (P *point) Base() kyber.Point {
+	P.ed.Base()
+	return P
+}
+
+func (P *point) EmbedLen() int {
+	// Reserve the least-significant byte, whose low bit must be zero,
+	// for pseudo-randomness, and the next byte for the embedded data
+	// length. The most-significant byte is kept below 0x40 so that the
+	// encoding is always a reduced field element.
+	return 32 - 3
+}
+
+func (P *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := P.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+
+	for {
+		// Pick random bytes with the embedded data until they form
+		// a valid encoding, which happens about once in four tries.
+		var b [32]byte
+		rand.XORKeyStream(b[:], b[:])
+		b[0] &= 0xfe
+		b[1] = byte(dl)
+		copy(b[2:2+dl], data)
+		b[31] &= 0x3f
+		if P.UnmarshalBinary(b[:]) == nil {
+			return P
+		}
+	}
+}
+
+// Pick sets the point to a uniformly random element.
+func (P *point) Pick(rand cipher.Stream) kyber.Point {
+	var b [64]byte
+	random.Bytes(b[:], rand)
+	edwards25519.RistrettoFromUniformBytes(P.ed, b[:])
+	return P
+}
+
+// Data extracts the data embedded in a point with Embed.
+func (P *point) Data() ([]byte, error) {
+	b := edwards25519.RistrettoEncode(P.ed)
+	dl := int(b[1])
+	if dl > P.EmbedLen() {
+		return nil, errors.New("invalid embedded data length")
+	}
+	return b[2 : 2+dl], nil
+}
+
+func (P *point) Add(P1, P2 kyber.Point) kyber.Point {
+	P.ed.Add(P1.(*point).ed, P2.(*point).ed)
+	return P
+}
+
+func (P *point) Sub(P1, P2 kyber.Point) kyber.Point {
+	P.ed.Sub(P1.(*point).ed, P2.(*point).ed)
+	return P
+}
+
+func (P *point) Neg(A kyber.Point) kyber.Point {
+	P.ed.Neg(A.(*point).ed)
+	return P
+}
+
+// Mul multiplies point A by scalar s, or the base point if A is nil.
+func (P *point) Mul(s kyber.Scalar, A kyber.Point) kyber.Point {
+	if A == nil {
+		P.ed.Mul(s, nil)
+	} else {
+		P.ed.Mul(s, A.(*point).ed)
+	}
+	return P
+}
+
+// AllowVarTime sets a flag in this object which determines if a faster
+// but variable time implementation can be used. Set this only on Points
+// which represent public information. Using variable time algorithms to
+// operate on private information can result in timing side-channels.
+func (P *point) AllowVarTime(varTime bool) {
+	P.ed.(kyber.AllowsVarTime).AllowVarTime(varTime)
+}
+
+// MultiScalarMul sets P to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It runs in variable time, thus it must
+// only be used on public Scalars and Points.
+func (P *point) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	eds := make([]kyber.Point, len(points))
+	for i, Q := range points {
+		if Q != nil {
+			eds[i] = Q.(*point).ed
+		}
+	}
+	P.ed.(kyber.MultiScalarMultiplier).MultiScalarMul(scalars, eds)
+	return P
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs in constant time at about the
+// speed of the multiplication of the base point.
+func (P *point) Precompute() kyber.FixedBase {
+	return &fixedBase{ed: P.ed.(kyber.FixedBasePrecomputer).Precompute()}
+}
+
+// fixedBase wraps the table of the underlying edwards25519 point.
+type fixedBase struct {
+	ed kyber.FixedBase
+}
+
+func (fb *fixedBase) Mul(s kyber.Scalar) kyber.Point {
+	return &point{ed: fb.ed.Mul(s)}
+}
+
+// IsInCorrectGroup always returns true, since every ristretto255 element
+// belongs to the prime-order group.
+func (P *point) IsInCorrectGroup() bool {
+	return true
+}
+
+// Hash sets the point to the hash of m, using the
+// ristretto255_XMD:SHA-512_R255MAP_RO_ suite with DefaultDST.
+func (P *point) Hash(m []byte) kyber.Point {
+	return P.HashWithDST(m, DefaultDST)
+}
+
+// HashWithDST sets the point to the hash of m, using the
+// ristretto255_XMD:SHA-512_R255MAP_RO_ suite of RFC 9380 and RFC 9496 with
+// the domain separation tag dst, which must not be empty.
+func (P *point) HashWithDST(m []byte, dst string) kyber.Point {
+	edwards25519.RistrettoHash(P.ed, m, dst)
+	return P
+}
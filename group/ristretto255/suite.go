@@ -0,0 +1,71 @@
+package ristretto255
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"hash"
+	"io"
+	"reflect"
+
+	"go.dedis.ch/fixbuf"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
+	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/xof/blake2xb"
+)
+
+// SuiteRistretto255 implements some basic functionalities such as Group, HashFactory,
+// and XOFFactory.
+type SuiteRistretto255 struct {
+	Group
+	r cipher.Stream
+}
+
+// Hash returns a newly instanciated sha512 hash function.
+func (s *SuiteRistretto255) Hash() hash.Hash {
+	return sha512.New()
+}
+
+// XOF returns an XOF which is implemented via the Blake2b hash.
+func (s *SuiteRistretto255) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+func (s *SuiteRistretto255) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *SuiteRistretto255) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs...)
+}
+
+// New implements the kyber.Encoding interface
+func (s *SuiteRistretto255) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteRistretto255) RandomStream() cipher.Stream {
+	if s.r != nil {
+		return s.r
+	}
+	return random.New()
+}
+
+// NewBlakeSHA512Ristretto255 returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/blake2xb, SHA-512, and the ristretto255 group.
+// It produces cryptographically random numbers via package crypto/rand.
+func NewBlakeSHA512Ristretto255() *SuiteRistretto255 {
+	suite := new(SuiteRistretto255)
+	return suite
+}
+
+// NewBlakeSHA512Ristretto255WithRand returns a cipher suite based on package
+// go.dedis.ch/kyber/v4/xof/blake2xb, SHA-512, and the ristretto255 group.
+// It produces cryptographically random numbers via the provided stream r.
+func NewBlakeSHA512Ristretto255WithRand(r cipher.Stream) *SuiteRistretto255 {
+	suite := new(SuiteRistretto255)
+	suite.r = r
+	return suite
+}
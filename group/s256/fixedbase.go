@@ -0,0 +1,103 @@
+package s256
+
+import (
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// fixedBase holds table[i][j] = (j+1) * 16^i * P in affine coordinates,
+// so that a multiplication only needs one mixed addition per signed window
+// of 4 bits and no doubling.
+type fixedBase struct {
+	c     *curve
+	table [65][8]secp256k1.JacobianPoint
+}
+
+// Precompute returns a table of multiples of P, with which the
+// multiplication of P by any scalar runs about as fast as the
+// multiplication of the base point. It runs in variable time, as the
+// multiplications of the group.
+func (P *curvePoint) Precompute() kyber.FixedBase {
+	fb := &fixedBase{c: P.c}
+	if P.x.Sign() == 0 && P.y.Sign() == 0 {
+		// Every multiple of the neutral element is the point at infinity.
+		return fb
+	}
+
+	var base secp256k1.JacobianPoint
+	base.X.SetByteSlice(P.x.Bytes())
+	base.Y.SetByteSlice(P.y.Bytes())
+	base.Z.SetInt(1)
+	for i := range fb.table {
+		t := &fb.table[i]
+		t[0] = base
+		for j := 1; j < len(t); j++ {
+			secp256k1.AddNonConst(&t[j-1], &base, &t[j])
+		}
+		secp256k1.DoubleNonConst(&t[len(t)-1], &base)
+	}
+	toAffine(fb.table[:])
+	return fb
+}
+
+// toAffine converts the points of the tables to affine coordinates with a
+// single field inversion, using Montgomery's trick. None of the points may
+// be the point at infinity.
+func toAffine(tables [][8]secp256k1.JacobianPoint) {
+	ps := make([]*secp256k1.JacobianPoint, 0, 8*len(tables))
+	for i := range tables {
+		for j := range tables[i] {
+			ps = append(ps, &tables[i][j])
+		}
+	}
+
+	// prods[i] is the product of the Z coordinates of the points before i.
+	prods := make([]secp256k1.FieldVal, len(ps))
+	var acc secp256k1.FieldVal
+	acc.SetInt(1)
+	for i, p := range ps {
+		prods[i].Set(&acc)
+		acc.Mul(&p.Z).Normalize()
+	}
+
+	acc.Inverse()
+	var zInv, zInv2 secp256k1.FieldVal
+	for i := len(ps) - 1; i >= 0; i-- {
+		p := ps[i]
+		zInv.Mul2(&acc, &prods[i]).Normalize()
+		acc.Mul(&p.Z).Normalize()
+		zInv2.SquareVal(&zInv).Normalize()
+		p.X.Mul(&zInv2).Normalize()
+		p.Y.Mul(zInv2.Mul(&zInv)).Normalize()
+		p.Z.SetInt(1)
+	}
+}
+
+// Mul returns s times the precomputed point.
+func (fb *fixedBase) Mul(s kyber.Scalar) kyber.Point {
+	digits := msm.SignedDigits(msm.ScalarsBytes([]kyber.Scalar{s})[0], 4)
+	var acc, q, sum secp256k1.JacobianPoint
+	for i, d := range digits {
+		switch {
+		case d > 0:
+			q = fb.table[i][d-1]
+		case d < 0:
+			q = fb.table[i][-d-1]
+			q.Y.Negate(1).Normalize()
+		default:
+			continue
+		}
+		secp256k1.AddNonConst(&acc, &q, &sum)
+		acc = sum
+	}
+
+	acc.ToAffine()
+	return &curvePoint{
+		x: new(big.Int).SetBytes(acc.X.Bytes()[:]),
+		y: new(big.Int).SetBytes(acc.Y.Bytes()[:]),
+		c: fb.c,
+	}
+}
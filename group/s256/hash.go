@@ -0,0 +1,113 @@
+package s256
+
+import (
+	"crypto/sha256"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+)
+
+// DefaultDST is the domain separation tag used by Hash. Applications
+// should pick their own tag and call HashWithDST.
+const DefaultDST = "secp256k1_XMD:SHA-256_SSWU_RO_"
+
+// Parameters of the curve E' 3-isogenous to secp256k1 and of the isogeny
+// map, from RFC 9380, section 8.7 and appendix E.1.
+var (
+	isoA = hexInt("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	isoB = big.NewInt(1771)
+
+	isoXNum = []*big.Int{
+		hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa8c7"),
+		hexInt("07d3d4c80bc321d5b9f315cea7fd44c5d595d2fc0bf63b92dfff1044f17c6581"),
+		hexInt("534c328d23f234e6e2a413deca25caece4506144037c40314ecbd0b53d9dd262"),
+		hexInt("8e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38e38daaaaa88c"),
+	}
+	isoXDen = []*big.Int{
+		hexInt("d35771193d94918a9ca34ccbb7b640dd86cd409542f8487d9fe6b745781eb49b"),
+		hexInt("edadc6f64383dc1df7c4b2d51b54225406d36b641f5e41bbc52a56612a8c6d14"),
+		big.NewInt(1),
+	}
+	isoYNum = []*big.Int{
+		hexInt("4bda12f684bda12f684bda12f684bda12f684bda12f684bda12f684b8e38e23c"),
+		hexInt("c75e0c32d5cb7c0fa9d0a54b12a0a6d5647ab046d686da6fdffc90fc201d71a3"),
+		hexInt("29a6194691f91a73715209ef6512e576722830a201be2018a765e85a9ecee931"),
+		hexInt("2f684bda12f684bda12f684bda12f684bda12f684bda12f684bda12f38e38d84"),
+	}
+	isoYDen = []*big.Int{
+		hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffff93b"),
+		hexInt("7a06534bb8bdb49fd5e9e6632722c2989467c1bfc8e8d978dfb425d2685c2573"),
+		hexInt("6484aa716545ca2cf3a70c3fa8fe337e0a3d21162f0d6299a7bf8192bfd2a76f"),
+		big.NewInt(1),
+	}
+)
+
+func hexInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("s256: invalid constant " + s)
+	}
+	return i
+}
+
+// Hash sets the point to the hash of m, using the
+// secp256k1_XMD:SHA-256_SSWU_RO_ suite with DefaultDST.
+func (P *curvePoint) Hash(m []byte) kyber.Point {
+	return P.HashWithDST(m, DefaultDST)
+}
+
+// HashWithDST sets the point to the hash of m, using the
+// secp256k1_XMD:SHA-256_SSWU_RO_ suite of RFC 9380, section 8.7, with the
+// domain separation tag dst, which must not be empty.
+func (P *curvePoint) HashWithDST(m []byte, dst string) kyber.Point {
+	p := P.c.p.P
+	u, err := h2c.HashToField(sha256.New(), m, dst, p, 128, 2)
+	if err != nil {
+		panic("s256: " + err.Error())
+	}
+
+	// secp256k1 has a = 0, so the simplified SWU map is applied on the
+	// isogenous curve E' with Z = -11, and its output is mapped back.
+	Z := new(big.Int).Sub(p, big.NewInt(11))
+	x0, y0 := h2c.SSWU(u[0], isoA, isoB, Z, p)
+	x0, y0 = isoMap(x0, y0, p)
+	x1, y1 := h2c.SSWU(u[1], isoA, isoB, Z, p)
+	x1, y1 = isoMap(x1, y1, p)
+
+	// The cofactor is 1, there is nothing to clear.
+	P.x, P.y = P.c.Add(x0, y0, x1, y1)
+	return P
+}
+
+// isoMap evaluates the 3-isogeny map from E' to secp256k1 of RFC 9380,
+// appendix E.1, on the point (x, y) of E' over the field of order p.
+func isoMap(x, y, p *big.Int) (*big.Int, *big.Int) {
+	xNum := evalPoly(isoXNum, x, p)
+	xDen := evalPoly(isoXDen, x, p)
+	yNum := evalPoly(isoYNum, x, p)
+	yDen := evalPoly(isoYDen, x, p)
+
+	// The denominators vanish only on the kernel of the isogeny, which
+	// the simplified SWU map never outputs, so both are invertible.
+	X := xDen.ModInverse(xDen, p)
+	X.Mul(X, xNum)
+	X.Mod(X, p)
+	Y := yDen.ModInverse(yDen, p)
+	Y.Mul(Y, yNum)
+	Y.Mul(Y, y)
+	Y.Mod(Y, p)
+	return X, Y
+}
+
+// evalPoly returns the polynomial with coefficients k, from the constant
+// term upward, evaluated on x modulo p.
+func evalPoly(k []*big.Int, x, p *big.Int) *big.Int {
+	r := new(big.Int).Set(k[len(k)-1])
+	for i := len(k) - 2; i >= 0; i-- {
+		r.Mul(r, x)
+		r.Add(r, k[i])
+		r.Mod(r, p)
+	}
+	return r
+}
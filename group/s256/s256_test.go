@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/util/test"
 )
 
 func Test1(t *testing.T) {
@@ -20,3 +21,7 @@ func Test1(t *testing.T) {
 	t.Logf("Scalar*Point: %s", S)
 
 }
+
+func TestFixedBase(t *testing.T) {
+	test.FixedBaseTest(t, NewSuite())
+}
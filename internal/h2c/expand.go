@@ -0,0 +1,168 @@
+// Package h2c implements the building blocks shared by the hash-to-curve
+// suites of RFC 9380 of the different groups.
+//
+// [RFC9380]: https://datatracker.ietf.org/doc/html/rfc9380
+package h2c
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"golang.org/x/crypto/sha3"
+)
+
+var longDomainSeparator = "H2C-OVERSIZE-DST-"
+
+// ExpandMessageXMD implements expand_message_xmd of RFC 9380, section 5.3.1:
+// it expands the message m into byteLen uniformly random bytes using the
+// Merkle-Damgard hash function h and the domain separation tag dst. Tags
+// longer than 255 bytes are hashed as described in section 5.3.3.
+func ExpandMessageXMD(h hash.Hash, m []byte, domainSeparator string, byteLen int) ([]byte, error) {
+	// ell = ceil(len_in_bytes / b_in_bytes)
+	ell := (byteLen + h.Size() - 1) / h.Size()
+	if ell > 255 || byteLen < 0 || byteLen > 65535 || len(domainSeparator) == 0 {
+		return nil, errors.New("invalid parameters")
+	}
+
+	if len(domainSeparator) > 255 {
+		h.Reset()
+		h.Write([]byte(longDomainSeparator))
+		h.Write([]byte(domainSeparator))
+
+		domainSeparator = string(h.Sum(nil))
+	}
+
+	padDom, err := i2OSP(len(domainSeparator), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	dstPrime := append([]byte(domainSeparator), padDom...)
+	byteLenStr, _ := i2OSP(byteLen, 2)
+	zeroPad, _ := i2OSP(0, 1)
+	zPad, _ := i2OSP(0, h.BlockSize())
+
+	// mPrime = Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prim
+	mPrime := make([]byte, 0, len(zPad)+len(m)+len(byteLenStr)+len(zeroPad)+len(dstPrime))
+	mPrime = append(mPrime, zPad...)
+	mPrime = append(mPrime, m...)
+	mPrime = append(mPrime, byteLenStr...)
+	mPrime = append(mPrime, zeroPad...)
+	mPrime = append(mPrime, dstPrime...)
+
+	// b0 = H(msg_prime)
+	h.Reset()
+	h.Write([]byte(mPrime))
+	b0 := h.Sum(nil)
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	h.Reset()
+	h.Write(b0)
+	onePad, _ := i2OSP(1, 1)
+	h.Write([]byte(onePad))
+	h.Write([]byte(dstPrime))
+	b1 := h.Sum(nil)
+
+	bFinal := make([]byte, 0, len(b1)*(ell+1))
+	bFinal = append(bFinal, b1...)
+	bPred := b1
+	for i := 2; i <= ell; i++ {
+		x, err := byteXor(bPred, b0, bPred)
+		if err != nil {
+			return nil, err
+		}
+		ithPad, _ := i2OSP(i, 1)
+
+		h.Reset()
+		h.Write(x)
+		h.Write(ithPad)
+		h.Write(dstPrime)
+
+		bPred = h.Sum(nil)
+		bFinal = append(bFinal, bPred...)
+	}
+
+	return bFinal[:byteLen], nil
+}
+
+// ExpandMessageXOF implements expand_message_xof of RFC 9380, section 5.3.2,
+// with the extendable-output function h.
+func ExpandMessageXOF(h sha3.ShakeHash, m []byte, domainSeparator string, byteLen int) ([]byte, error) {
+	if byteLen > 65535 || len(domainSeparator) == 0 {
+		return nil, errors.New("invalid parameters")
+	}
+
+	if len(domainSeparator) > 255 {
+		outputSize := h.Size()
+
+		h.Reset()
+		h.Write([]byte(longDomainSeparator))
+		h.Write([]byte(domainSeparator))
+
+		dst := make([]byte, outputSize)
+		n, err := h.Read(dst)
+		if err != nil {
+			return nil, err
+		}
+
+		if n != outputSize {
+			return nil, fmt.Errorf("read %d byte instead of expected %d from xof", n, byteLen)
+		}
+
+		domainSeparator = string(dst)
+	}
+
+	dstPad, err := i2OSP(len(domainSeparator), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	lenPad, err := i2OSP(byteLen, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	dstPrime := append([]byte(domainSeparator), dstPad...)
+
+	h.Reset()
+	h.Write(m)
+	h.Write(lenPad)
+	h.Write(dstPrime)
+
+	uniformBytes := make([]byte, byteLen)
+	n, err := h.Read(uniformBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if n != byteLen {
+		return nil, fmt.Errorf("read %d byte instead of expected %d from xof", n, byteLen)
+	}
+
+	return uniformBytes, nil
+}
+
+func i2OSP(x int, xLen int) ([]byte, error) {
+	b := big.NewInt(int64(x))
+	s := b.Bytes()
+	if len(s) > xLen {
+		return nil, fmt.Errorf("input %d superior to max length %d", len(s), xLen)
+	}
+
+	pad := make([]byte, (xLen - len(s)))
+	return append(pad, s...), nil
+}
+
+func byteXor(dst, b1, b2 []byte) ([]byte, error) {
+	if !(len(dst) == len(b1) && len(b2) == len(b1)) {
+		return nil, errors.New("incompatible lengths")
+	}
+
+	for i := 0; i < len(dst); i++ {
+		dst[i] = b1[i] ^ b2[i]
+	}
+
+	return dst, nil
+}
@@ -0,0 +1,94 @@
+package h2c
+
+import (
+	"hash"
+	"math/big"
+)
+
+// HashToField implements hash_to_field of RFC 9380, section 5.2, for the
+// prime field of order p: it hashes m with the domain separation tag dst
+// into count field elements, using expand_message_xmd with the hash
+// function h and L = ceil((ceil(log2(p)) + k) / 8) bytes per element,
+// where k is the security level in bits.
+func HashToField(h hash.Hash, m []byte, dst string, p *big.Int, k, count int) ([]*big.Int, error) {
+	l := (p.BitLen() + k + 7) / 8
+	uniformBytes, err := ExpandMessageXMD(h, m, dst, count*l)
+	if err != nil {
+		return nil, err
+	}
+
+	u := make([]*big.Int, count)
+	for i := range u {
+		u[i] = new(big.Int).SetBytes(uniformBytes[i*l : (i+1)*l])
+		u[i].Mod(u[i], p)
+	}
+	return u, nil
+}
+
+// Sgn0 returns the sign of x as defined in RFC 9380, section 4.1, i.e.
+// its parity, for a reduced element x of a prime field.
+func Sgn0(x *big.Int) uint {
+	return x.Bit(0)
+}
+
+// SSWU implements the simplified Shallue-van de Woestijne-Ulas method of
+// RFC 9380, section 6.6.2, mapping the field element u to a point (x, y)
+// of the curve y^2 = x^3 + A*x + B over the prime field of order p, where
+// A and B are non-zero and Z is the non-square constant of the suite.
+//
+// The map uses big.Int arithmetic and runs in variable time, which is
+// fine as long as it is only applied to public inputs.
+func SSWU(u, A, B, Z, p *big.Int) (x, y *big.Int) {
+	// tv1 = inv0(Z^2 * u^4 + Z * u^2)
+	zu2 := new(big.Int).Mul(u, u)
+	zu2.Mul(zu2, Z)
+	zu2.Mod(zu2, p)
+	tv1 := new(big.Int).Mul(zu2, zu2)
+	tv1.Add(tv1, zu2)
+	tv1.Mod(tv1, p)
+	if tv1.Sign() != 0 {
+		tv1.ModInverse(tv1, p)
+	}
+
+	// x1 = (-B / A) * (1 + tv1), or B / (Z * A) if tv1 == 0
+	x1 := new(big.Int)
+	if tv1.Sign() == 0 {
+		x1.Mul(Z, A)
+		x1.ModInverse(x1, p)
+		x1.Mul(x1, B)
+	} else {
+		x1.ModInverse(A, p)
+		x1.Mul(x1, B)
+		x1.Neg(x1)
+		x1.Mul(x1, tv1.Add(tv1, big.NewInt(1)))
+	}
+	x1.Mod(x1, p)
+
+	// x2 = Z * u^2 * x1
+	x2 := new(big.Int).Mul(zu2, x1)
+	x2.Mod(x2, p)
+
+	// Use x1 if g(x1) is a square and x2 otherwise, in which case g(x2)
+	// is guaranteed to be a square.
+	x = x1
+	y = new(big.Int).ModSqrt(curveEquation(x1, A, B, p), p)
+	if y == nil {
+		x = x2
+		y = new(big.Int).ModSqrt(curveEquation(x2, A, B, p), p)
+	}
+
+	if Sgn0(u) != Sgn0(y) {
+		y.Sub(p, y)
+		y.Mod(y, p)
+	}
+	return x, y
+}
+
+// curveEquation returns x^3 + A*x + B mod p.
+func curveEquation(x, A, B, p *big.Int) *big.Int {
+	gx := new(big.Int).Mul(x, x)
+	gx.Add(gx, A)
+	gx.Mul(gx, x)
+	gx.Add(gx, B)
+	return gx.Mod(gx, p)
+}
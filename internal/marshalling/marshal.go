@@ -3,6 +3,8 @@ package marshalling
 
 import (
 	"crypto/cipher"
+	"encoding"
+	"encoding/hex"
 	"io"
 	"reflect"
 
@@ -63,6 +65,29 @@ func ScalarUnmarshalFrom(s kyber.Scalar, r io.Reader) (int, error) {
 	return n, s.UnmarshalBinary(buf)
 }
 
+// MarshalText provides a generic implementation of encoding.TextMarshaler
+// for Points and Scalars, the hexadecimal encoding of MarshalBinary.
+func MarshalText(m encoding.BinaryMarshaler) ([]byte, error) {
+	buf, err := m.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	text := make([]byte, hex.EncodedLen(len(buf)))
+	hex.Encode(text, buf)
+	return text, nil
+}
+
+// UnmarshalText provides a generic implementation of
+// encoding.TextUnmarshaler for Points and Scalars, based on
+// UnmarshalBinary.
+func UnmarshalText(m encoding.BinaryUnmarshaler, text []byte) error {
+	buf := make([]byte, hex.DecodedLen(len(text)))
+	if _, err := hex.Decode(buf, text); err != nil {
+		return err
+	}
+	return m.UnmarshalBinary(buf)
+}
+
 // Not used other than for reflect.TypeOf()
 var aScalar kyber.Scalar
 var aPoint kyber.Point
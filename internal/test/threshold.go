@@ -10,6 +10,12 @@ import (
 	"go.dedis.ch/kyber/v4/util/random"
 )
 
+// batchRecoverer is the tbls.BatchRecoverer interface, which this package
+// can't import as the tests of tbls use it.
+type batchRecoverer interface {
+	RecoverBatch(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, []int, error)
+}
+
 // ThresholdTest performs a simple check on a threshold scheme implementation
 func ThresholdTest(test *testing.T, keyGroup kyber.Group, scheme sign.ThresholdScheme) {
 	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
@@ -94,4 +100,63 @@ func ThresholdTest(test *testing.T, keyGroup kyber.Group, scheme sign.ThresholdS
 		err = scheme.VerifyRecovered(pubPoly.Commit(), msg, fakeSig)
 		require.Error(tt, err)
 	})
+	test.Run("Batch verification and recovery", func(tt *testing.T) {
+		recoverer, ok := scheme.(batchRecoverer)
+		require.True(tt, ok, "the scheme doesn't implement RecoverBatch")
+		secret := keyGroup.Scalar().Pick(random.New())
+		priPoly := share.NewPriPoly(keyGroup, t, secret, random.New())
+		pubPoly := priPoly.Commit(keyGroup.Point().Base())
+		fakePriPoly := share.NewPriPoly(keyGroup, t, keyGroup.Scalar().Pick(random.New()), random.New())
+		fakeShares := fakePriPoly.Shares(n)
+		sigShares := make([][]byte, 0)
+		for _, x := range priPoly.Shares(n) {
+			sig, err := scheme.Sign(x, msg)
+			require.Nil(tt, err)
+			sigShares = append(sigShares, sig)
+		}
+		require.NoError(tt, scheme.VerifyPartialBatch(pubPoly, msg, sigShares))
+		sig, bad, err := recoverer.RecoverBatch(pubPoly, msg, sigShares, t, n)
+		require.NoError(tt, err)
+		require.Empty(tt, bad)
+		require.NoError(tt, scheme.VerifyRecovered(pubPoly.Commit(), msg, sig))
+
+		// Shares of another polynomial, a share of another message and a
+		// malformed one.
+		for _, i := range []int{1, 4} {
+			fake, err := scheme.Sign(fakeShares[i], msg)
+			require.NoError(tt, err)
+			sigShares[i] = fake
+		}
+		other, err := scheme.Sign(priPoly.Eval(7), []byte("other message"))
+		require.NoError(tt, err)
+		sigShares[7] = other
+		sigShares = append(sigShares, []byte("ain't no sunshine when she's gone"))
+
+		err = scheme.VerifyPartialBatch(pubPoly, msg, sigShares)
+		var batchErr *sign.BatchError
+		require.ErrorAs(tt, err, &batchErr)
+		require.Equal(tt, []int{1, 4, 7, n}, batchErr.Indices())
+		for i, s := range sigShares {
+			require.Equal(tt, batchErr.Invalid[i] != nil, scheme.VerifyPartial(pubPoly, msg, s) != nil)
+		}
+
+		sig, bad, err = recoverer.RecoverBatch(pubPoly, msg, sigShares, t, n)
+		require.NoError(tt, err)
+		require.Equal(tt, []int{1, 4, 7, n}, bad)
+		require.NoError(tt, scheme.VerifyRecovered(pubPoly.Commit(), msg, sig))
+
+		// Not enough valid shares.
+		_, bad, err = recoverer.RecoverBatch(pubPoly, msg, sigShares[:t], t, n)
+		require.ErrorContains(tt, err, "not enough valid partial signatures")
+		require.Equal(tt, []int{1, 4}, bad)
+
+		// Enough valid shares, but of the same signer.
+		dup := [][]byte{sigShares[0]}
+		for len(dup) < t {
+			dup = append(dup, sigShares[0])
+		}
+		_, bad, err = recoverer.RecoverBatch(pubPoly, msg, dup, t, n)
+		require.ErrorContains(tt, err, "share: not enough good public shares")
+		require.Empty(tt, bad)
+	})
 }
@@ -115,3 +115,63 @@ type Test struct {
 	Sig     string   `json:"sig"`
 	Result  string   `json:"result"`
 }
+
+// TestV0 holds the test vectors of the schema used before v1, in which
+// each group holds the full key pair.
+type TestV0 struct {
+	Algorithm        string   `json:"algorithm"`
+	Schema           string   `json:"schema"`
+	GeneratorVersion string   `json:"generatorVersion"`
+	NumberOfTest     int      `json:"numberOfTests"`
+	Header           []string `json:"header"`
+
+	TestGroups []TestGroupV0 `json:"testGroups"`
+}
+
+type TestGroupV0 struct {
+	Type   string    `json:"type"`
+	Key    KeyPairV0 `json:"key"`
+	KeyDer string    `json:"keyDer"`
+	KeyPem string    `json:"keyPem"`
+
+	Tests []Test `json:"tests"`
+}
+
+type KeyPairV0 struct {
+	Type    string `json:"type"`
+	Curve   string `json:"curve"`
+	KeySize int    `json:"keySize"`
+	PK      string `json:"pk"`
+	SK      string `json:"sk"`
+}
+
+// EcdsaTestV1 holds the ECDSA test vectors, whose groups hold an uncompressed
+// public key and the name of the hash function.
+type EcdsaTestV1 struct {
+	Algorithm        string   `json:"algorithm"`
+	Schema           string   `json:"schema"`
+	GeneratorVersion string   `json:"generatorVersion"`
+	NumberOfTest     int      `json:"numberOfTests"`
+	Header           []string `json:"header"`
+
+	TestGroups []EcdsaTestGroupV1 `json:"testGroups"`
+}
+
+type EcdsaTestGroupV1 struct {
+	Type      string        `json:"type"`
+	PublicKey EcPublicKeyV1 `json:"publicKey"`
+	PkDer     string        `json:"publicKeyDer"`
+	PkPem     string        `json:"publicKeyPem"`
+	Sha       string        `json:"sha"`
+
+	Tests []Test `json:"tests"`
+}
+
+type EcPublicKeyV1 struct {
+	Type         string `json:"type"`
+	Curve        string `json:"curve"`
+	KeySize      int    `json:"keySize"`
+	Uncompressed string `json:"uncompressed"`
+	Wx           string `json:"wx"`
+	Wy           string `json:"wy"`
+}
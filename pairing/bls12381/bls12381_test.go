@@ -23,6 +23,7 @@ import (
 	"go.dedis.ch/kyber/v4/sign/bls"
 	"go.dedis.ch/kyber/v4/sign/tbls"
 	"go.dedis.ch/kyber/v4/util/random"
+	utiltest "go.dedis.ch/kyber/v4/util/test"
 	"go.dedis.ch/kyber/v4/xof/blake2xb"
 	"gopkg.in/yaml.v3"
 )
@@ -419,6 +420,30 @@ func TestKyberG2(t *testing.T) {
 	}
 }
 
+func TestKyberMultiScalarMul(t *testing.T) {
+	suites := []pairing.Suite{
+		kilic.NewBLS12381Suite(),
+		circl.NewSuiteBLS12381(),
+	}
+
+	for _, suite := range suites {
+		utiltest.MultiScalarMulTest(t, suite.G1())
+		utiltest.MultiScalarMulTest(t, suite.G2())
+	}
+}
+
+func TestKyberFixedBase(t *testing.T) {
+	suites := []pairing.Suite{
+		kilic.NewBLS12381Suite(),
+		circl.NewSuiteBLS12381(),
+	}
+
+	for _, suite := range suites {
+		utiltest.FixedBaseTest(t, suite.G1())
+		utiltest.FixedBaseTest(t, suite.G2())
+	}
+}
+
 func TestKyberPairingG2(t *testing.T) {
 	suites := []pairing.Suite{
 		kilic.NewBLS12381Suite(),
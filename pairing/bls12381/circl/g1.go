@@ -7,8 +7,11 @@ import (
 
 	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointID1 = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', '1'}
+
 var _ kyber.SubGroupElement = &G1Elt{}
 
 // G1Elt is a wrapper around a G1 point on the BLS12-381 Circl curve.
@@ -22,6 +25,9 @@ func (p *G1Elt) UnmarshalBinary(data []byte) error { return p.inner.SetBytes(dat
 
 func (p *G1Elt) String() string { return p.inner.String() }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (p *G1Elt) MarshalID() [8]byte { return marshalPointID1 }
+
 func (p *G1Elt) MarshalSize() int { return bls12381.G1SizeCompressed }
 
 // MarshalTo writes a compressed point to the Writer, without any domain separation tag information
@@ -43,6 +49,17 @@ func (p *G1Elt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *G1Elt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *G1Elt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *G1Elt) Equal(p2 kyber.Point) bool { x := p2.(*G1Elt); return p.inner.IsEqual(&x.inner) }
 
 func (p *G1Elt) Null() kyber.Point { p.inner.SetIdentity(); return p }
@@ -101,3 +118,6 @@ var domainG1 = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
 
 func (p *G1Elt) Hash(msg []byte) kyber.Point       { p.inner.Hash(msg, domainG1); return p }
 func (p *G1Elt) Hash2(msg, dst []byte) kyber.Point { p.inner.Hash(msg, dst); return p }
+
+// HashToCurveID returns the identifier of the RFC 9380 suite used by Hash2.
+func (p *G1Elt) HashToCurveID() string { return "BLS12381G1_XMD:SHA-256_SSWU_RO_" }
@@ -7,8 +7,11 @@ import (
 
 	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointID2 = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', '2'}
+
 var _ kyber.SubGroupElement = &G2Elt{}
 
 // G2Elt is a wrapper around the Circl G2 point type.
@@ -22,6 +25,9 @@ func (p *G2Elt) UnmarshalBinary(data []byte) error { return p.inner.SetBytes(dat
 
 func (p *G2Elt) String() string { return p.inner.String() }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (p *G2Elt) MarshalID() [8]byte { return marshalPointID2 }
+
 func (p *G2Elt) MarshalSize() int { return bls12381.G2SizeCompressed }
 
 // MarshalTo writes a compressed point to the Writer, without any domain separation tag information
@@ -43,6 +49,17 @@ func (p *G2Elt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *G2Elt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *G2Elt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *G2Elt) Equal(p2 kyber.Point) bool { x := p2.(*G2Elt); return p.inner.IsEqual(&x.inner) }
 
 func (p *G2Elt) Null() kyber.Point { p.inner.SetIdentity(); return p }
@@ -101,3 +118,6 @@ var domainG2 = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
 
 func (p *G2Elt) Hash(msg []byte) kyber.Point       { p.inner.Hash(msg, domainG2); return p }
 func (p *G2Elt) Hash2(msg, dst []byte) kyber.Point { p.inner.Hash(msg, dst); return p }
+
+// HashToCurveID returns the identifier of the RFC 9380 suite used by Hash2.
+func (p *G2Elt) HashToCurveID() string { return "BLS12381G2_XMD:SHA-256_SSWU_RO_" }
@@ -6,8 +6,11 @@ import (
 
 	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointIDT = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', 't'}
+
 var gtBase *bls12381.Gt
 
 func init() {
@@ -27,6 +30,9 @@ func (p *GTElt) UnmarshalBinary(data []byte) error { return p.inner.UnmarshalBin
 
 func (p *GTElt) String() string { return p.inner.String() }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (p *GTElt) MarshalID() [8]byte { return marshalPointIDT }
+
 func (p *GTElt) MarshalSize() int { return bls12381.GtSize }
 
 // MarshalTo writes a compressed point to the Writer, without any domain separation tag information
@@ -48,6 +54,17 @@ func (p *GTElt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *GTElt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *GTElt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *GTElt) Equal(p2 kyber.Point) bool { x := p2.(*GTElt); return p.inner.IsEqual(&x.inner) }
 
 func (p *GTElt) Null() kyber.Point { p.inner.SetIdentity(); return p }
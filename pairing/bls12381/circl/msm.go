@@ -0,0 +1,211 @@
+package circl
+
+import (
+	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// MultiScalarMul sets p to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It runs in variable time, thus it must
+// only be used on public Scalars and Points.
+func (p *G1Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bls12-381.G1: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return p.Null()
+	}
+	As := make([]*bls12381.G1, len(points))
+	for i, q := range points {
+		if q == nil {
+			As[i] = bls12381.G1Generator()
+		} else {
+			As[i] = &q.(*G1Elt).inner
+		}
+	}
+
+	ks := msm.ScalarsBytes(scalars)
+	if len(As) < msm.StrausThreshold {
+		p.inner = g1MultiScalarMultStraus(ks, As)
+	} else {
+		p.inner = g1MultiScalarMultPippenger(ks, As)
+	}
+	return p
+}
+
+// MultiScalarMul sets p to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It runs in variable time, thus it must
+// only be used on public Scalars and Points.
+func (p *G2Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bls12-381.G2: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return p.Null()
+	}
+	As := make([]*bls12381.G2, len(points))
+	for i, q := range points {
+		if q == nil {
+			As[i] = bls12381.G2Generator()
+		} else {
+			As[i] = &q.(*G2Elt).inner
+		}
+	}
+
+	ks := msm.ScalarsBytes(scalars)
+	if len(As) < msm.StrausThreshold {
+		p.inner = g2MultiScalarMultStraus(ks, As)
+	} else {
+		p.inner = g2MultiScalarMultPippenger(ks, As)
+	}
+	return p
+}
+
+//nolint:dupl // unavoidable duplication between g1 and g2
+func g1MultiScalarMultStraus(ks [][]byte, As []*bls12381.G1) bls12381.G1 {
+	const w = msm.StrausWindow
+	tables := make([][1 << (w - 1)]bls12381.G1, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		tables[i][0] = *A
+		for j := 1; j < len(tables[i]); j++ {
+			tables[i][j].Add(&tables[i][j-1], A)
+		}
+		digits[i] = msm.SignedDigits(ks[i], w)
+	}
+
+	var sum, neg bls12381.G1
+	sum.SetIdentity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < w; d++ {
+			sum.Double()
+		}
+		for i := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				sum.Add(&sum, &tables[i][d-1])
+			case d < 0:
+				neg = tables[i][-d-1]
+				neg.Neg()
+				sum.Add(&sum, &neg)
+			}
+		}
+	}
+	return sum
+}
+
+//nolint:dupl // unavoidable duplication between g1 and g2
+func g1MultiScalarMultPippenger(ks [][]byte, As []*bls12381.G1) bls12381.G1 {
+	c := msm.PippengerWindow(len(As))
+	digits := make([][]int, len(As))
+	for i := range As {
+		digits[i] = msm.SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]bls12381.G1, 1<<(c-1))
+	var acc, run, sum, neg bls12381.G1
+	acc.SetIdentity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < c; d++ {
+			acc.Double()
+		}
+
+		for b := range buckets {
+			buckets[b].SetIdentity()
+		}
+		for i, A := range As {
+			switch d := digits[i][j]; {
+			case d > 0:
+				buckets[d-1].Add(&buckets[d-1], A)
+			case d < 0:
+				neg = *A
+				neg.Neg()
+				buckets[-d-1].Add(&buckets[-d-1], &neg)
+			}
+		}
+
+		run.SetIdentity()
+		sum.SetIdentity()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			run.Add(&run, &buckets[b])
+			sum.Add(&sum, &run)
+		}
+		acc.Add(&acc, &sum)
+	}
+	return acc
+}
+
+//nolint:dupl // unavoidable duplication between g1 and g2
+func g2MultiScalarMultStraus(ks [][]byte, As []*bls12381.G2) bls12381.G2 {
+	const w = msm.StrausWindow
+	tables := make([][1 << (w - 1)]bls12381.G2, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		tables[i][0] = *A
+		for j := 1; j < len(tables[i]); j++ {
+			tables[i][j].Add(&tables[i][j-1], A)
+		}
+		digits[i] = msm.SignedDigits(ks[i], w)
+	}
+
+	var sum, neg bls12381.G2
+	sum.SetIdentity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < w; d++ {
+			sum.Double()
+		}
+		for i := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				sum.Add(&sum, &tables[i][d-1])
+			case d < 0:
+				neg = tables[i][-d-1]
+				neg.Neg()
+				sum.Add(&sum, &neg)
+			}
+		}
+	}
+	return sum
+}
+
+//nolint:dupl // unavoidable duplication between g1 and g2
+func g2MultiScalarMultPippenger(ks [][]byte, As []*bls12381.G2) bls12381.G2 {
+	c := msm.PippengerWindow(len(As))
+	digits := make([][]int, len(As))
+	for i := range As {
+		digits[i] = msm.SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]bls12381.G2, 1<<(c-1))
+	var acc, run, sum, neg bls12381.G2
+	acc.SetIdentity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < c; d++ {
+			acc.Double()
+		}
+
+		for b := range buckets {
+			buckets[b].SetIdentity()
+		}
+		for i, A := range As {
+			switch d := digits[i][j]; {
+			case d > 0:
+				buckets[d-1].Add(&buckets[d-1], A)
+			case d < 0:
+				neg = *A
+				neg.Neg()
+				buckets[-d-1].Add(&buckets[-d-1], &neg)
+			}
+		}
+
+		run.SetIdentity()
+		sum.SetIdentity()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			run.Add(&run, &buckets[b])
+			sum.Add(&sum, &run)
+		}
+		acc.Add(&acc, &sum)
+	}
+	return acc
+}
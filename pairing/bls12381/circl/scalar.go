@@ -7,8 +7,11 @@ import (
 
 	bls12381 "github.com/cloudflare/circl/ecc/bls12381"
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalScalarID = [8]byte{'b', 'l', 's', '1', '2', '.', 's', 'c'}
+
 var _ kyber.Scalar = &Scalar{}
 
 type Scalar struct{ inner bls12381.Scalar }
@@ -19,6 +22,9 @@ func (s *Scalar) UnmarshalBinary(data []byte) error { return s.inner.UnmarshalBi
 
 func (s *Scalar) String() string { return s.inner.String() }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (s *Scalar) MarshalID() [8]byte { return marshalScalarID }
+
 func (s *Scalar) MarshalSize() int { return bls12381.ScalarSize }
 
 func (s *Scalar) MarshalTo(w io.Writer) (int, error) {
@@ -38,6 +44,17 @@ func (s *Scalar) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, s.inner.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (s *Scalar) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(s)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *Scalar) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(s, text)
+}
+
 func (s *Scalar) Equal(s2 kyber.Scalar) bool {
 	x := s2.(*Scalar)
 	return s.inner.IsEqual(&x.inner) == 1
@@ -39,6 +39,20 @@ func (s Suite) ValidatePairing(p1, p2, p3, p4 kyber.Point) bool {
 	return out.IsIdentity()
 }
 
+// ValidatePairings implements pairing.MultiPairing.
+func (s Suite) ValidatePairings(p1s, p2s []kyber.Point) bool {
+	if len(p1s) != len(p2s) {
+		return false
+	}
+	g1s := make([]*bls12381.G1, len(p1s))
+	g2s := make([]*bls12381.G2, len(p2s))
+	exps := make([]int, len(p1s))
+	for i := range p1s {
+		g1s[i], g2s[i], exps[i] = &p1s[i].(*G1Elt).inner, &p2s[i].(*G2Elt).inner, 1
+	}
+	return bls12381.ProdPairFrac(g1s, g2s, exps).IsIdentity()
+}
+
 func (s Suite) Read(_ io.Reader, _ ...interface{}) error {
 	panic("Suite.Read(): deprecated in drand")
 }
@@ -9,8 +9,11 @@ import (
 	bls12381 "github.com/kilic/bls12-381"
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointID1 = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', '1'}
+
 // domainG1 is the DST used for hash to curve on G1, this is the default from the RFC.
 var domainG1 = []byte("BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_")
 
@@ -147,6 +150,17 @@ func (k *G1Elt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, k.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (k *G1Elt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(k)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *G1Elt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(k, text)
+}
+
 func (k *G1Elt) MarshalSize() int {
 	return 48
 }
@@ -156,6 +170,11 @@ func (k *G1Elt) String() string {
 	return "bls12-381.G1: " + hex.EncodeToString(b)
 }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (k *G1Elt) MarshalID() [8]byte {
+	return marshalPointID1
+}
+
 func (k *G1Elt) Hash(m []byte) kyber.Point {
 	domain := domainG1
 	// We treat a 0 len dst as the default value as per the RFC "Tags MUST have nonzero length"
@@ -167,6 +186,18 @@ func (k *G1Elt) Hash(m []byte) kyber.Point {
 	return k
 }
 
+// Hash2 hashes m to the curve with the domain separation tag dst.
+func (k *G1Elt) Hash2(m, dst []byte) kyber.Point {
+	p, _ := bls12381.NewG1().HashToCurve(m, dst)
+	k.p = p
+	return k
+}
+
+// HashToCurveID returns the identifier of the RFC 9380 suite used by Hash2.
+func (k *G1Elt) HashToCurveID() string {
+	return "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+}
+
 func (k *G1Elt) IsInCorrectGroup() bool {
 	return bls12381.NewG1().InCorrectSubgroup(k.p)
 }
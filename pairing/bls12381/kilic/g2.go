@@ -9,8 +9,11 @@ import (
 	bls12381 "github.com/kilic/bls12-381"
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointID2 = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', '2'}
+
 // domainG2 is the DST used for hash to curve on G2, this is the default from the RFC.
 // This is compatible with the paired library > v18
 var domainG2 = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
@@ -146,6 +149,17 @@ func (k *G2Elt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, k.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (k *G2Elt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(k)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *G2Elt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(k, text)
+}
+
 func (k *G2Elt) MarshalSize() int {
 	return 96
 }
@@ -155,6 +169,11 @@ func (k *G2Elt) String() string {
 	return "bls12-381.G2: " + hex.EncodeToString(b)
 }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (k *G2Elt) MarshalID() [8]byte {
+	return marshalPointID2
+}
+
 func (k *G2Elt) Hash(m []byte) kyber.Point {
 	domain := domainG2
 	// We treat a 0 len dst as the default value as per the RFC "Tags MUST have nonzero length"
@@ -166,6 +185,18 @@ func (k *G2Elt) Hash(m []byte) kyber.Point {
 	return k
 }
 
+// Hash2 hashes m to the curve with the domain separation tag dst.
+func (k *G2Elt) Hash2(m, dst []byte) kyber.Point {
+	pg2, _ := bls12381.NewG2().HashToCurve(m, dst)
+	k.p = pg2
+	return k
+}
+
+// HashToCurveID returns the identifier of the RFC 9380 suite used by Hash2.
+func (k *G2Elt) HashToCurveID() string {
+	return "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+}
+
 func (k *G2Elt) IsInCorrectGroup() bool {
 	return bls12381.NewG2().InCorrectSubgroup(k.p)
 }
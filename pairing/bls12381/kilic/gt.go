@@ -8,8 +8,11 @@ import (
 	bls12381 "github.com/kilic/bls12-381"
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
+var marshalPointIDT = [8]byte{'b', 'l', 's', '1', '2', '.', 'g', 't'}
+
 // GTElt contains a Gt element from the Kilic BLS12-381 curve
 type GTElt struct {
 	f *bls12381.E
@@ -110,6 +113,17 @@ func (k *GTElt) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, k.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (k *GTElt) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(k)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *GTElt) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(k, text)
+}
+
 func (k *GTElt) MarshalSize() int {
 	return 576
 }
@@ -119,6 +133,11 @@ func (k *GTElt) String() string {
 	return "bls12-381.GT: " + hex.EncodeToString(b)
 }
 
+// MarshalID returns the type tag used in encoding/decoding
+func (k *GTElt) MarshalID() [8]byte {
+	return marshalPointIDT
+}
+
 func (k *GTElt) EmbedLen() int {
 	panic("bls12-381.GT.EmbedLen(): unsupported operation")
 }
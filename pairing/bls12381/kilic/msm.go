@@ -0,0 +1,75 @@
+package kilic
+
+import (
+	"math/big"
+
+	bls12381 "github.com/kilic/bls12-381"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/mod"
+)
+
+func scalarsBig(scalars []kyber.Scalar) []*big.Int {
+	es := make([]*big.Int, len(scalars))
+	for i, s := range scalars {
+		es[i] = &s.(*mod.Int).V
+	}
+	return es
+}
+
+// MultiScalarMul sets k to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point, using the bucket method of the
+// underlying library. It runs in variable time, thus it must only be used
+// on public Scalars and Points.
+func (k *G1Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bls12-381.G1: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return k.Null()
+	}
+	g := bls12381.NewG1()
+	ps := make([]*bls12381.PointG1, len(points))
+	for i, q := range points {
+		if q == nil {
+			ps[i] = g.One()
+		} else {
+			ps[i] = q.(*G1Elt).p
+		}
+	}
+
+	r := g.New()
+	if _, err := g.MultiExpBig(r, ps, scalarsBig(scalars)); err != nil {
+		panic(err)
+	}
+	k.p = r
+	return k
+}
+
+// MultiScalarMul sets k to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point, using the bucket method of the
+// underlying library. It runs in variable time, thus it must only be used
+// on public Scalars and Points.
+func (k *G2Elt) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bls12-381.G2: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return k.Null()
+	}
+	g := bls12381.NewG2()
+	ps := make([]*bls12381.PointG2, len(points))
+	for i, q := range points {
+		if q == nil {
+			ps[i] = g.One()
+		} else {
+			ps[i] = q.(*G2Elt).p
+		}
+	}
+
+	r := g.New()
+	if _, err := g.MultiExpBig(r, ps, scalarsBig(scalars)); err != nil {
+		panic(err)
+	}
+	k.p = r
+	return k
+}
@@ -65,6 +65,21 @@ func (s *Suite) ValidatePairing(p1, p2, p3, p4 kyber.Point) bool {
 	return e.Check()
 }
 
+// ValidatePairings implements pairing.MultiPairing.
+func (s *Suite) ValidatePairings(p1s, p2s []kyber.Point) bool {
+	if len(p1s) != len(p2s) {
+		return false
+	}
+	e := bls12381.NewEngine()
+	for i := range p1s {
+		// cloned for the same reason as in ValidatePairing
+		g1point := new(bls12381.PointG1).Set(p1s[i].(*G1Elt).p)
+		g2point := new(bls12381.PointG2).Set(p2s[i].(*G2Elt).p)
+		e.AddPair(g1point, g2point)
+	}
+	return e.Check()
+}
+
 func (s *Suite) Pair(p1, p2 kyber.Point) kyber.Point {
 	e := bls12381.NewEngine()
 	g1point := p1.(*G1Elt).p
@@ -0,0 +1,236 @@
+package bn254
+
+import (
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// scalarsLittleEndian returns the scalars as little-endian byte slices
+// of the length of the group order.
+func scalarsLittleEndian(scalars []kyber.Scalar) [][]byte {
+	l := (Order.BitLen() + 7) / 8
+	ks := make([][]byte, len(scalars))
+	for i, s := range scalars {
+		ks[i] = s.(*mod.Int).LittleEndian(l, l)
+	}
+	return ks
+}
+
+// MultiScalarMul sets p to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It runs in variable time, thus it must
+// only be used on public Scalars and Points.
+func (p *pointG1) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bn254.G1: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return p.Null()
+	}
+	As := make([]*curvePoint, len(points))
+	for i, q := range points {
+		if q == nil {
+			As[i] = curveGen
+		} else {
+			As[i] = q.(*pointG1).g
+		}
+	}
+
+	ks := scalarsLittleEndian(scalars)
+	if len(As) < msm.StrausThreshold {
+		p.g = curveMultiScalarMultStraus(ks, As)
+	} else {
+		p.g = curveMultiScalarMultPippenger(ks, As)
+	}
+	return p
+}
+
+// MultiScalarMul sets p to the sum of scalars[i]*points[i], where a nil
+// point stands for the base point. It runs in variable time, thus it must
+// only be used on public Scalars and Points.
+func (p *pointG2) MultiScalarMul(scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	if len(scalars) != len(points) {
+		panic("bn254.G2: mismatched number of scalars and points")
+	}
+	if len(points) == 0 {
+		return p.Null()
+	}
+	As := make([]*twistPoint, len(points))
+	for i, q := range points {
+		if q == nil {
+			As[i] = twistGen
+		} else {
+			As[i] = q.(*pointG2).g
+		}
+	}
+
+	ks := scalarsLittleEndian(scalars)
+	if len(As) < msm.StrausThreshold {
+		p.g = twistMultiScalarMultStraus(ks, As)
+	} else {
+		p.g = twistMultiScalarMultPippenger(ks, As)
+	}
+	return p
+}
+
+//nolint:dupl // curvePoint and twistPoint have the same methods but no common interface
+func curveMultiScalarMultStraus(ks [][]byte, As []*curvePoint) *curvePoint {
+	const w = msm.StrausWindow
+	tables := make([][1 << (w - 1)]curvePoint, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		tables[i][0].Set(A)
+		for j := 1; j < len(tables[i]); j++ {
+			tables[i][j].Add(&tables[i][j-1], A)
+		}
+		digits[i] = msm.SignedDigits(ks[i], w)
+	}
+
+	sum, t, neg := &curvePoint{}, &curvePoint{}, &curvePoint{}
+	sum.SetInfinity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < w; d++ {
+			t.Double(sum)
+			sum.Set(t)
+		}
+		for i := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(sum, &tables[i][d-1])
+				sum.Set(t)
+			case d < 0:
+				neg.Neg(&tables[i][-d-1])
+				t.Add(sum, neg)
+				sum.Set(t)
+			}
+		}
+	}
+	return sum
+}
+
+//nolint:dupl // curvePoint and twistPoint have the same methods but no common interface
+func curveMultiScalarMultPippenger(ks [][]byte, As []*curvePoint) *curvePoint {
+	c := msm.PippengerWindow(len(As))
+	digits := make([][]int, len(As))
+	for i := range As {
+		digits[i] = msm.SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]curvePoint, 1<<(c-1))
+	sum, run, acc, t, neg := &curvePoint{}, &curvePoint{}, &curvePoint{}, &curvePoint{}, &curvePoint{}
+	acc.SetInfinity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < c; d++ {
+			t.Double(acc)
+			acc.Set(t)
+		}
+
+		for b := range buckets {
+			buckets[b].SetInfinity()
+		}
+		for i, A := range As {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(&buckets[d-1], A)
+				buckets[d-1].Set(t)
+			case d < 0:
+				neg.Neg(A)
+				t.Add(&buckets[-d-1], neg)
+				buckets[-d-1].Set(t)
+			}
+		}
+
+		run.SetInfinity()
+		sum.SetInfinity()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			t.Add(run, &buckets[b])
+			run.Set(t)
+			t.Add(sum, run)
+			sum.Set(t)
+		}
+		t.Add(acc, sum)
+		acc.Set(t)
+	}
+	return acc
+}
+
+//nolint:dupl // curvePoint and twistPoint have the same methods but no common interface
+func twistMultiScalarMultStraus(ks [][]byte, As []*twistPoint) *twistPoint {
+	const w = msm.StrausWindow
+	tables := make([][1 << (w - 1)]twistPoint, len(As))
+	digits := make([][]int, len(As))
+	for i, A := range As {
+		tables[i][0].Set(A)
+		for j := 1; j < len(tables[i]); j++ {
+			tables[i][j].Add(&tables[i][j-1], A)
+		}
+		digits[i] = msm.SignedDigits(ks[i], w)
+	}
+
+	sum, t, neg := &twistPoint{}, &twistPoint{}, &twistPoint{}
+	sum.SetInfinity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < w; d++ {
+			t.Double(sum)
+			sum.Set(t)
+		}
+		for i := range tables {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(sum, &tables[i][d-1])
+				sum.Set(t)
+			case d < 0:
+				neg.Neg(&tables[i][-d-1])
+				t.Add(sum, neg)
+				sum.Set(t)
+			}
+		}
+	}
+	return sum
+}
+
+//nolint:dupl // curvePoint and twistPoint have the same methods but no common interface
+func twistMultiScalarMultPippenger(ks [][]byte, As []*twistPoint) *twistPoint {
+	c := msm.PippengerWindow(len(As))
+	digits := make([][]int, len(As))
+	for i := range As {
+		digits[i] = msm.SignedDigits(ks[i], c)
+	}
+
+	buckets := make([]twistPoint, 1<<(c-1))
+	sum, run, acc, t, neg := &twistPoint{}, &twistPoint{}, &twistPoint{}, &twistPoint{}, &twistPoint{}
+	acc.SetInfinity()
+	for j := len(digits[0]) - 1; j >= 0; j-- {
+		for d := 0; d < c; d++ {
+			t.Double(acc)
+			acc.Set(t)
+		}
+
+		for b := range buckets {
+			buckets[b].SetInfinity()
+		}
+		for i, A := range As {
+			switch d := digits[i][j]; {
+			case d > 0:
+				t.Add(&buckets[d-1], A)
+				buckets[d-1].Set(t)
+			case d < 0:
+				neg.Neg(A)
+				t.Add(&buckets[-d-1], neg)
+				buckets[-d-1].Set(t)
+			}
+		}
+
+		run.SetInfinity()
+		sum.SetInfinity()
+		for b := len(buckets) - 1; b >= 0; b-- {
+			t.Add(run, &buckets[b])
+			run.Set(t)
+			t.Add(sum, run)
+			sum.Set(t)
+		}
+		t.Add(acc, sum)
+		acc.Set(t)
+	}
+	return acc
+}
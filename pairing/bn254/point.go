@@ -10,6 +10,8 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 	"golang.org/x/crypto/sha3"
 
 	_ "github.com/ethereum/go-ethereum/crypto"
@@ -194,6 +196,17 @@ func (p *pointG1) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointG1) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointG1) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointG1) MarshalSize() int {
 	return 2 * p.ElementSize()
 }
@@ -211,6 +224,19 @@ func (p *pointG1) Hash(m []byte) kyber.Point {
 	return hashToPointHashAndPray(m)
 }
 
+// Hash2 hashes m to the curve with the domain separation tag dst, using
+// expand_message_xmd with Keccak-256 and the Shallue-van de Woestijne map.
+func (p *pointG1) Hash2(m, dst []byte) kyber.Point {
+	p.g.Set(hashToPoint(dst, m).(*pointG1).g)
+	return p
+}
+
+// HashToCurveID returns the identifier of the hash to curve suite used by
+// Hash2.
+func (p *pointG1) HashToCurveID() string {
+	return string(newDefaultDomainG1())
+}
+
 func hashToPoint(domain, m []byte) kyber.Point {
 	e0, e1 := hashToField(domain, m)
 	p0 := mapToPoint(domain, e0)
@@ -337,55 +363,16 @@ func mapToPoint(domain []byte, u *gfP) kyber.Point {
 }
 
 // `expandMsgXmdKeccak256` implements expand_message_xmd from IETF RFC9380 Sec 5.3.1
-// Borrowed from: https://github.com/kilic/bls12-381/blob/master/hash_to_field.go
+// with Keccak-256.
 func expandMsgXmdKeccak256(domain, msg []byte, outLen int) []byte {
-	h := sha3.NewLegacyKeccak256()
 	if len(domain) > 255 {
 		panic("invalid domain length")
 	}
-	domainLen := uint8(len(domain))
-
-	// DST_prime = DST || I2OSP(len(DST), 1)
-	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
-	_, _ = h.Write(make([]byte, h.BlockSize()))
-	_, _ = h.Write(msg)
-	_, _ = h.Write([]byte{uint8(outLen >> 8), uint8(outLen)})
-	_, _ = h.Write([]byte{0})
-	_, _ = h.Write(domain)
-	_, _ = h.Write([]byte{domainLen})
-	b0 := h.Sum(nil)
-
-	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
-	h.Reset()
-	_, _ = h.Write(b0)
-	_, _ = h.Write([]byte{1})
-	_, _ = h.Write(domain)
-	_, _ = h.Write([]byte{domainLen})
-	b1 := h.Sum(nil)
-
-	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
-	ell := (outLen + h.Size() - 1) / h.Size()
-	bi := b1
-	out := make([]byte, outLen)
-	for i := 1; i < ell; i++ {
-		h.Reset()
-		// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
-		tmp := make([]byte, h.Size())
-		for j := 0; j < h.Size(); j++ {
-			tmp[j] = b0[j] ^ bi[j]
-		}
-		_, _ = h.Write(tmp)
-		_, _ = h.Write([]byte{1 + uint8(i)})
-		_, _ = h.Write(domain)
-		_, _ = h.Write([]byte{domainLen})
-
-		// b_1 || ... || b_(ell - 1)
-		copy(out[(i-1)*h.Size():i*h.Size()], bi)
-		bi = h.Sum(nil)
+	out, err := h2c.ExpandMessageXMD(sha3.NewLegacyKeccak256(), msg, string(domain), outLen)
+	if err != nil {
+		panic("bn254: " + err.Error())
 	}
-	// b_ell
-	copy(out[(ell-1)*h.Size():], bi)
-	return out[:outLen]
+	return out
 }
 
 type pointG2 struct {
@@ -572,6 +559,17 @@ func (p *pointG2) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointG2) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointG2) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointG2) MarshalSize() int {
 	return 4 * p.ElementSize()
 }
@@ -802,6 +800,17 @@ func (p *pointGT) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointGT) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointGT) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointGT) MarshalSize() int {
 	return 12 * p.ElementSize()
 }
@@ -10,6 +10,7 @@ import (
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
 	"go.dedis.ch/kyber/v4/util/random"
+	"go.dedis.ch/kyber/v4/util/test"
 	"go.dedis.ch/protobuf"
 )
 
@@ -372,3 +373,17 @@ func Test_g2_2add_oncurve_issue400(t *testing.T) {
 	err = p.UnmarshalBinary(ma)
 	require.NoError(t, err)
 }
+
+func TestMultiScalarMul(t *testing.T) {
+	suite := NewSuite()
+	test.MultiScalarMulTest(t, suite.G1())
+	test.MultiScalarMulTest(t, suite.G2())
+	test.MultiScalarMulTest(t, suite.GT())
+}
+
+func TestFixedBase(t *testing.T) {
+	suite := NewSuite()
+	test.FixedBaseTest(t, suite.G1())
+	test.FixedBaseTest(t, suite.G2())
+	test.FixedBaseTest(t, suite.GT())
+}
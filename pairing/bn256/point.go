@@ -10,6 +10,7 @@ import (
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/mod"
+	"go.dedis.ch/kyber/v4/internal/marshalling"
 )
 
 var marshalPointID1 = [8]byte{'b', 'n', '2', '5', '6', '.', 'g', '1'}
@@ -225,6 +226,17 @@ func (p *pointG1) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointG1) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointG1) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointG1) MarshalSize() int {
 	return 2 * p.ElementSize()
 }
@@ -263,6 +275,24 @@ func (p *pointG1) Hash(m []byte) kyber.Point {
 	return p
 }
 
+// Hash2 hashes m to the curve with the domain separation tag dst, as HashG1
+// does.
+func (p *pointG1) Hash2(m, dst []byte) kyber.Point {
+	if p.g == nil {
+		p.g = new(curvePoint)
+	}
+	p.g.Set(HashG1(m, dst).(*pointG1).g)
+	return p
+}
+
+// HashToCurveID returns an identifier of the hash to curve suite used by
+// Hash2, in the format of RFC 9380: HashG1 hashes to the base field with
+// HKDF-SHA-256 and maps a single element with the Shallue-van de Woestijne
+// map.
+func (p *pointG1) HashToCurveID() string {
+	return "BN256G1_HKDF:SHA-256_SVDW_NU_"
+}
+
 // hashes a byte slice into a curve point represented by two big.Int's
 // ideally we want to do this using gfP, but gfP doesn't have a ModSqrt function
 func hashToPoint(m []byte) (*big.Int, *big.Int) {
@@ -463,6 +493,17 @@ func (p *pointG2) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointG2) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointG2) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointG2) MarshalSize() int {
 	return 4 * p.ElementSize()
 }
@@ -654,6 +695,17 @@ func (p *pointGT) UnmarshalFrom(r io.Reader) (int, error) {
 	return n, p.UnmarshalBinary(buf)
 }
 
+// MarshalText implements encoding.TextMarshaler with the hexadecimal
+// encoding of MarshalBinary.
+func (p *pointGT) MarshalText() ([]byte, error) {
+	return marshalling.MarshalText(p)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *pointGT) UnmarshalText(text []byte) error {
+	return marshalling.UnmarshalText(p, text)
+}
+
 func (p *pointGT) MarshalSize() int {
 	return 12 * p.ElementSize()
 }
@@ -102,6 +102,24 @@ func (s *Suite) ValidatePairing(p1, p2, inv1, inv2 kyber.Point) bool {
 	return s.Pair(p1, p2).Equal(s.Pair(inv1, inv2))
 }
 
+// ValidatePairings implements pairing.MultiPairing. It multiplies the
+// Miller loops of the pairs and does a single final exponentiation.
+func (s *Suite) ValidatePairings(p1s, p2s []kyber.Point) bool {
+	if len(p1s) != len(p2s) {
+		return false
+	}
+	acc := (&gfP12{}).SetOne()
+	for i := range p1s {
+		a := p1s[i].(*pointG1).g
+		b := p2s[i].(*pointG2).g
+		if a.IsInfinity() || b.IsInfinity() {
+			continue
+		}
+		acc.Mul(acc, miller(b, a))
+	}
+	return finalExponentiation(acc).IsOne()
+}
+
 // Not used other than for reflect.TypeOf()
 var aScalar kyber.Scalar
 var aPoint kyber.Point
@@ -18,3 +18,29 @@ type Suite interface {
 	kyber.XOFFactory
 	kyber.Random
 }
+
+// MultiPairing is implemented by the suites that can check a product of
+// pairings with a single final exponentiation.
+type MultiPairing interface {
+	// ValidatePairings returns whether the product of the pairings
+	// e(p1s[i],p2s[i]) is the identity of GT.
+	ValidatePairings(p1s, p2s []kyber.Point) bool
+}
+
+// ValidatePairings returns whether the product of the pairings
+// e(p1s[i],p2s[i]) is the identity of GT. It uses the multi-pairing of
+// the suite if it implements MultiPairing, and adds up the pairings in GT
+// otherwise. It returns false if the numbers of points differ.
+func ValidatePairings(s Suite, p1s, p2s []kyber.Point) bool {
+	if len(p1s) != len(p2s) {
+		return false
+	}
+	if m, ok := s.(MultiPairing); ok {
+		return m.ValidatePairings(p1s, p2s)
+	}
+	acc := s.GT().Point().Null()
+	for i := range p1s {
+		acc.Add(acc, s.Pair(p1s[i], p2s[i]))
+	}
+	return acc.Equal(s.GT().Point().Null())
+}
@@ -0,0 +1,47 @@
+package pairing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// onlyPair hides the multi-pairing of a suite.
+type onlyPair struct {
+	pairing.Suite
+}
+
+func TestValidatePairings(t *testing.T) {
+	for _, suite := range []pairing.Suite{circl.NewSuite(), kilic.NewBLS12381Suite(), bn254.NewSuite(), bn256.NewSuite()} {
+		_, ok := suite.(pairing.MultiPairing)
+		require.True(t, ok)
+
+		// e(a*G1, b*G2) * e(-c*G1, G2) * e(G1, 0) == 1 with c = a*b
+		a := suite.G1().Scalar().Pick(random.New())
+		b := suite.G1().Scalar().Pick(random.New())
+		c := suite.G1().Scalar().Mul(a, b)
+		p1s := []kyber.Point{
+			suite.G1().Point().Mul(a, nil),
+			suite.G1().Point().Neg(suite.G1().Point().Mul(c, nil)),
+			suite.G1().Point().Base(),
+		}
+		p2s := []kyber.Point{
+			suite.G2().Point().Mul(b, nil),
+			suite.G2().Point().Base(),
+			suite.G2().Point().Null(),
+		}
+		for _, s := range []pairing.Suite{suite, onlyPair{suite}} {
+			require.True(t, pairing.ValidatePairings(s, p1s, p2s))
+			require.False(t, pairing.ValidatePairings(s, p1s[1:], p2s[1:]))
+			require.False(t, pairing.ValidatePairings(s, p1s, p2s[1:]))
+			require.True(t, pairing.ValidatePairings(s, nil, nil))
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/fixedbase"
 )
 
 // Suite wraps the functionalities needed by the dleq package.
@@ -106,14 +107,29 @@ func NewDLEQProofBatch(
 	vG := make([]kyber.Point, n)
 	vH := make([]kyber.Point, n)
 
+	mulG := func(s kyber.Scalar, i int) kyber.Point {
+		return suite.Point().Mul(s, G[i])
+	}
+	if sharedBase(G) {
+		// A base shared by all the proofs, e.g. the H of PVSS, is
+		// multiplied using the table of the group, which runs in
+		// constant time as Mul does. The secrets and the commitments
+		// must never go through a generic table.
+		if fb, ok := fixedbase.Native(suite, G[0]); ok {
+			mulG = func(s kyber.Scalar, _ int) kyber.Point {
+				return fb.Mul(s)
+			}
+		}
+	}
+
 	for i, x := range secrets {
 		// Encrypt base points with secrets
-		xG[i] = suite.Point().Mul(x, G[i])
+		xG[i] = mulG(x, i)
 		xH[i] = suite.Point().Mul(x, H[i])
 
 		// Commitments
 		v[i] = suite.Scalar().Pick(suite.RandomStream())
-		vG[i] = suite.Point().Mul(v[i], G[i])
+		vG[i] = mulG(v[i], i)
 		vH[i] = suite.Point().Mul(v[i], H[i])
 	}
 
@@ -170,3 +186,18 @@ func (p *Proof) Verify(suite Suite, G kyber.Point, H kyber.Point, xG kyber.Point
 	}
 	return nil
 }
+
+// sharedBase tells whether the points are numerous enough to be worth a
+// precomputed table and all equal to the first one.
+func sharedBase(points []kyber.Point) bool {
+	// Every point is multiplied twice.
+	if 2*len(points) < fixedbase.Threshold {
+		return false
+	}
+	for _, p := range points[1:] {
+		if !p.Equal(points[0]) {
+			return false
+		}
+	}
+	return true
+}
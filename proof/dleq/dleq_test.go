@@ -59,3 +59,23 @@ func TestDLEQLengths(t *testing.T) {
 	_, _, _, err := NewDLEQProofBatch(suite, g, h, x)
 	require.ErrorIs(t, err, ErrDifferentLengths)
 }
+
+func TestDLEQProofBatchSharedBase(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	n := 10
+	x := make([]kyber.Scalar, n)
+	g := make([]kyber.Point, n)
+	h := make([]kyber.Point, n)
+	base := suite.Point().Pick(rng)
+	for i := range x {
+		x[i] = suite.Scalar().Pick(rng)
+		g[i] = base
+		h[i] = suite.Point().Pick(rng)
+	}
+	proofs, xG, xH, err := NewDLEQProofBatch(suite, g, h, x)
+	require.NoError(t, err)
+	for i := range proofs {
+		require.True(t, suite.Point().Mul(x[i], base).Equal(xG[i]))
+		require.Nil(t, proofs[i].Verify(suite, g[i], h[i], xG[i], xH[i]))
+	}
+}
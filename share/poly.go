@@ -19,6 +19,8 @@ import (
 	"strings"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/fixedbase"
+	"go.dedis.ch/kyber/v4/group/msm"
 )
 
 // Some error definitions
@@ -140,6 +142,19 @@ func (p *PriPoly) Equal(q *PriPoly) bool {
 // the standard base if b == nil.
 func (p *PriPoly) Commit(b kyber.Point) *PubPoly {
 	commits := make([]kyber.Point, p.Threshold())
+	if b != nil && len(commits) >= fixedbase.Threshold && !b.Equal(p.g.Point().Base()) {
+		// A custom base, e.g. the second generator of Pedersen
+		// commitments, is multiplied using the table of the group, which
+		// runs in constant time as Mul does, while the standard base
+		// point already has its own. The secret coefficients must never
+		// go through a generic table.
+		if fb, ok := fixedbase.Native(p.g, b); ok {
+			for i := range commits {
+				commits[i] = fb.Mul(p.coeffs[i])
+			}
+			return &PubPoly{p.g, b, commits}
+		}
+	}
 	for i := range commits {
 		commits[i] = p.g.Point().Mul(p.coeffs[i], b)
 	}
@@ -337,11 +352,15 @@ func (p *PubPoly) Commit() kyber.Point {
 // Eval computes the public share v = p(i).
 func (p *PubPoly) Eval(i uint32) *PubShare {
 	xi := p.g.Scalar().SetInt64(1 + int64(i)) // x-coordinate of this share
-	v := p.g.Point().Null()
-	for j := p.Threshold() - 1; j >= 0; j-- {
-		v.Mul(xi, v)
-		v.Add(v, p.commits[j])
+	pows := make([]kyber.Scalar, p.Threshold())
+	for j := range pows {
+		if j == 0 {
+			pows[j] = p.g.Scalar().One()
+		} else {
+			pows[j] = p.g.Scalar().Mul(pows[j-1], xi)
+		}
 	}
+	v := msm.MultiScalarMul(p.g, pows, p.commits)
 	return &PubShare{i, v}
 }
 
@@ -450,14 +469,13 @@ func RecoverCommit(g kyber.Group, shares []*PubShare, t, n int) (kyber.Point, er
 		return nil, errors.New("share: not enough good public shares to reconstruct secret commitment")
 	}
 
-	num := g.Scalar()
 	den := g.Scalar()
 	tmp := g.Scalar()
-	Acc := g.Point().Null()
-	Tmp := g.Point()
+	coeffs := make([]kyber.Scalar, 0, len(x))
+	points := make([]kyber.Point, 0, len(x))
 
 	for i, xi := range x {
-		num.One()
+		num := g.Scalar().One()
 		den.One()
 		for j, xj := range x {
 			if i == j {
@@ -466,11 +484,11 @@ func RecoverCommit(g kyber.Group, shares []*PubShare, t, n int) (kyber.Point, er
 			num.Mul(num, xj)
 			den.Mul(den, tmp.Sub(xj, xi))
 		}
-		Tmp.Mul(num.Div(num, den), y[i])
-		Acc.Add(Acc, Tmp)
+		coeffs = append(coeffs, num.Div(num, den))
+		points = append(points, y[i])
 	}
 
-	return Acc, nil
+	return msm.MultiScalarMul(g, coeffs, points), nil
 }
 
 // RecoverPubPoly reconstructs the full public polynomial from a set of public
@@ -545,3 +545,18 @@ func TestRefreshDKG(test *testing.T) {
 	// Check that the secret and the corresponding (old) public commit match
 	require.True(test, g.Point().Mul(refreshedPriPoly.Secret(), nil).Equal(dkgCommits[0]))
 }
+
+func TestPriPolyCommitBase(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	t := 16
+	b := g.Point().Pick(g.RandomStream())
+
+	priPoly := NewPriPoly(g, t, nil, g.RandomStream())
+	pubPoly := priPoly.Commit(b)
+
+	base, commits := pubPoly.Info()
+	require.True(test, base.Equal(b))
+	for i, c := range priPoly.Coefficients() {
+		require.True(test, g.Point().Mul(c, b).Equal(commits[i]))
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"errors"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/proof"
 	"go.dedis.ch/kyber/v4/util/random"
 )
@@ -288,20 +289,19 @@ func (ps *PairShuffle) Verify(
 	}
 
 	// V step 7
-	Phi1 := grp.Point().Null()
-	Phi2 := grp.Point().Null()
 	P := grp.Point() // scratch
 	Q := grp.Point() // scratch
+	scalars := make([]kyber.Scalar, 2*k)
 	for i := 0; i < k; i++ {
-		Phi1 = Phi1.Add(Phi1, P.Mul(p5.Zsigma[i], Xbar[i])) // (31)
-		Phi1 = Phi1.Sub(Phi1, P.Mul(v2.Zrho[i], X[i]))
-		Phi2 = Phi2.Add(Phi2, P.Mul(p5.Zsigma[i], Ybar[i])) // (32)
-		Phi2 = Phi2.Sub(Phi2, P.Mul(v2.Zrho[i], Y[i]))
+		scalars[i] = p5.Zsigma[i]
+		scalars[k+i] = grp.Scalar().Neg(v2.Zrho[i])
 		if !P.Mul(p5.Zsigma[i], p1.Gamma).Equal( // (33)
 			Q.Add(p1.W[i], p3.D[i])) {
 			return errors.New("invalid PairShuffleProof")
 		}
 	}
+	Phi1 := msm.MultiScalarMul(grp, scalars, append(Xbar[:k:k], X...)) // (31)
+	Phi2 := msm.MultiScalarMul(grp, scalars, append(Ybar[:k:k], Y...)) // (32)
 
 	if !P.Add(p1.Lambda1, Q.Mul(p5.Ztau, G)).Equal(Phi1) || // (34)
 		!P.Add(p1.Lambda2, Q.Mul(p5.Ztau, H)).Equal(Phi2) { // (35)
@@ -7,6 +7,7 @@ import (
 	"math/big"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/proof"
 	"go.dedis.ch/kyber/v4/util/random"
 )
@@ -163,26 +164,23 @@ func GetSequenceVerifiable(group kyber.Group, X, Y, Xbar, Ybar [][]kyber.Point,
 	xDown = make([]kyber.Point, k)
 	yDown = make([]kyber.Point, k)
 
+	// column returns the i-th element of every sequence of Z
+	column := func(Z [][]kyber.Point, i int) []kyber.Point {
+		c := make([]kyber.Point, NQ)
+		for j := range c {
+			c[j] = Z[j][i]
+		}
+		return c
+	}
+
 	for i := 0; i < k; i++ {
 		// No modification could be made for e[0] -> e[0] = 1 if one wanted -
 		// Remark 7 in the paper
-		xUp[i] = group.Point().Mul(e[0], X[0][i])
-		yUp[i] = group.Point().Mul(e[0], Y[0][i])
-
-		xDown[i] = group.Point().Mul(e[0], Xbar[0][i])
-		yDown[i] = group.Point().Mul(e[0], Ybar[0][i])
-
-		for j := 1; j < NQ; j++ {
-			xUp[i] = group.Point().Add(xUp[i],
-				group.Point().Mul(e[j], X[j][i]))
-			yUp[i] = group.Point().Add(yUp[i],
-				group.Point().Mul(e[j], Y[j][i]))
-
-			xDown[i] = group.Point().Add(xDown[i],
-				group.Point().Mul(e[j], Xbar[j][i]))
-			yDown[i] = group.Point().Add(yDown[i],
-				group.Point().Mul(e[j], Ybar[j][i]))
-		}
+		xUp[i] = msm.MultiScalarMul(group, e[:NQ], column(X, i))
+		yUp[i] = msm.MultiScalarMul(group, e[:NQ], column(Y, i))
+
+		xDown[i] = msm.MultiScalarMul(group, e[:NQ], column(Xbar, i))
+		yDown[i] = msm.MultiScalarMul(group, e[:NQ], column(Ybar, i))
 	}
 
 	return xUp, yUp, xDown, yDown
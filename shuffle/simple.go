@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/proof"
 )
 
@@ -174,11 +175,9 @@ func (ss *SimpleShuffle) Prove(g kyber.Point, gamma kyber.Scalar,
 
 // Simple helper to verify Theta elements,
 // by checking whether A^a*B^-b = T.
-// P,Q,s are simply "scratch" kyber.Point/Scalars reused for efficiency.
-func thver(A, B, T, P, Q kyber.Point, aS, bS, s kyber.Scalar) bool {
-	P.Mul(aS, A)
-	Q.Mul(s.Neg(bS), B)
-	P.Add(P, Q)
+// s is simply a "scratch" kyber.Scalar reused for efficiency.
+func thver(grp kyber.Group, A, B, T kyber.Point, aS, bS, s kyber.Scalar) bool {
+	P := msm.MultiScalarMul(grp, []kyber.Scalar{aS, s.Neg(bS)}, []kyber.Point{A, B})
 	return P.Equal(T)
 }
 
@@ -231,20 +230,18 @@ func (ss *SimpleShuffle) Verify(G, Gamma kyber.Point,
 		Xhat[i] = grp.Point().Add(X[i], U)
 		Yhat[i] = grp.Point().Add(Y[i], W)
 	}
-	P := grp.Point() // scratch variables
-	Q := grp.Point()
-	s := grp.Scalar()
+	s := grp.Scalar() // scratch variable
 	good := true
-	good = good && thver(Xhat[0], Yhat[0], Theta[0], P, Q, c, alpha[0], s)
+	good = good && thver(grp, Xhat[0], Yhat[0], Theta[0], c, alpha[0], s)
 	for i := 1; i < k; i++ {
-		good = good && thver(Xhat[i], Yhat[i], Theta[i], P, Q,
+		good = good && thver(grp, Xhat[i], Yhat[i], Theta[i],
 			alpha[i-1], alpha[i], s)
 	}
 	for i := k; i < thlen; i++ {
-		good = good && thver(Gamma, G, Theta[i], P, Q,
+		good = good && thver(grp, Gamma, G, Theta[i],
 			alpha[i-1], alpha[i], s)
 	}
-	good = good && thver(Gamma, G, Theta[thlen], P, Q,
+	good = good && thver(grp, Gamma, G, Theta[thlen],
 		alpha[thlen-1], c, s)
 	if !good {
 		return errors.New("incorrect SimpleShuffleProof")
@@ -0,0 +1,338 @@
+package anon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+)
+
+// compact ring signature: the bit commitments A, B, C, D of the signer's
+// position, the commitments G (and Q for linkable signatures) of the
+// polynomial coefficients, and the responses
+type cSig struct {
+	A, B, C, D kyber.Point
+	G          []kyber.Point
+	F          []kyber.Scalar
+	ZA, ZC, Z  kyber.Scalar
+}
+
+// linkable compact ring signature, encoded as the compact ring signature
+// followed by Q and the linkage tag
+type clSig struct {
+	cSig
+	Q   []kyber.Point
+	Tag kyber.Point
+}
+
+// compactDepth returns the number of bits m of the positions in a set of n
+// keys, which is padded to 2^m keys.
+func compactDepth(n int) int {
+	if n <= 2 {
+		return 1
+	}
+	return bits.Len(uint(n - 1))
+}
+
+// compactGenerators returns the m generators of the bit commitments, which
+// have no known discrete logarithm with respect to the base point.
+func compactGenerators(suite Suite, m int) []kyber.Point {
+	H := make([]kyber.Point, m)
+	for j := range H {
+		H[j] = suite.Point().Pick(suite.XOF([]byte(fmt.Sprintf("anon.CompactSign.H%d", j))))
+	}
+	return H
+}
+
+// compactCommit returns r*B + v_0*H_0 + ... + v_{m-1}*H_{m-1}.
+func compactCommit(suite Suite, H []kyber.Point, v []kyber.Scalar, r kyber.Scalar) kyber.Point {
+	return msm.MultiScalarMul(suite, append([]kyber.Scalar{r}, v...), append([]kyber.Point{nil}, H...))
+}
+
+// compactChallenge returns the challenge of the proof, which binds the
+// message, the linkage scope, the anonymity set and all the commitments.
+func compactChallenge(suite Suite, message, linkScope []byte, L []kyber.Point,
+	points ...[]kyber.Point) (kyber.Scalar, error) {
+	h := suite.XOF([]byte("anon.CompactSign"))
+	_, _ = h.Write(message)
+	if linkScope != nil {
+		_, _ = h.Write(linkScope)
+	}
+	for _, P := range L {
+		if _, err := P.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	for _, list := range points {
+		for _, P := range list {
+			if _, err := P.MarshalTo(h); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return suite.Scalar().Pick(h), nil
+}
+
+// CompactSign creates an anonymous signature like Sign, whose size is
+// logarithmic instead of linear in the size of the anonymity set. It is a
+// one-out-of-many proof of Groth and Kohlweiss, "One-out-of-Many Proofs: Or
+// How to Leak a Secret and Spend a Coin" (https://eprint.iacr.org/2014/764),
+// in the binary form of Bootle et al. (https://eprint.iacr.org/2015/643),
+// proving the knowledge of the private key of one of the public keys.
+//
+// If linkScope isn't nil, the signature is linkable and also proves that
+// its linkage tag is the product of the same private key with the base
+// point derived from the scope, in the same way as Sign: the linkage tags of
+// both kinds of signatures are equal for the same signer and scope.
+//
+// The signature holds m+4 points, or 2m+5 for linkable signatures, and m+3
+// scalars, where 2^m is the size of the anonymity set rounded up to a power
+// of two. Signing and verifying take time linear in the size of the set.
+func CompactSign(suite Suite, message []byte, anonymitySet Set, linkScope []byte, mine int,
+	privateKey kyber.Scalar) ([]byte, error) {
+	n := len(anonymitySet)
+	if mine < 0 || mine >= n {
+		return nil, errors.New("index of the signer out of range")
+	}
+	if !suite.Point().Mul(privateKey, nil).Equal(anonymitySet[mine]) {
+		return nil, errors.New("the private key doesn't match the public key of the signer")
+	}
+	m := compactDepth(n)
+	L := padSet(anonymitySet, m)
+	H := compactGenerators(suite, m)
+	rand := suite.RandomStream()
+	pick := func() kyber.Scalar { return suite.Scalar().Pick(rand) }
+
+	// Commit to the bits b_j of the position and to their blinding a_j.
+	a := make([]kyber.Scalar, m)
+	b := make([]kyber.Scalar, m)
+	c := make([]kyber.Scalar, m)
+	d := make([]kyber.Scalar, m)
+	for j := 0; j < m; j++ {
+		a[j] = pick()
+		b[j] = suite.Scalar().SetInt64(int64(mine>>j) & 1)
+		// c = a * (1 - 2b), d = -a^2
+		c[j] = suite.Scalar().Sub(suite.Scalar().One(), suite.Scalar().Add(b[j], b[j]))
+		c[j].Mul(c[j], a[j])
+		d[j] = suite.Scalar().Mul(a[j], a[j])
+		d[j].Neg(d[j])
+	}
+	rA, rB, rC, rD := pick(), pick(), pick(), pick()
+	sig := clSig{}
+	sig.A = compactCommit(suite, H, a, rA)
+	sig.B = compactCommit(suite, H, b, rB)
+	sig.C = compactCommit(suite, H, c, rC)
+	sig.D = compactCommit(suite, H, d, rD)
+
+	// The coefficients of p_i(x), the product of the f_{j,i_j}(x) with
+	// f_{j,1}(x) = b_j*x + a_j and f_{j,0}(x) = x - f_{j,1}(x), whose
+	// leading coefficient is 1 for the position of the signer and 0 for the
+	// others.
+	polys := [][]kyber.Scalar{{suite.Scalar().One()}}
+	for j := 0; j < m; j++ {
+		f1 := []kyber.Scalar{a[j], b[j]}
+		f0 := []kyber.Scalar{suite.Scalar().Neg(a[j]), suite.Scalar().Sub(suite.Scalar().One(), b[j])}
+		next := make([][]kyber.Scalar, 2*len(polys))
+		for i, p := range polys {
+			next[i] = polyMulLinear(suite, p, f0)
+			next[i+len(polys)] = polyMulLinear(suite, p, f1)
+		}
+		polys = next
+	}
+
+	// Commit to the low coefficients of sum_i p_i(x)*L_i, which hide the
+	// position of the signer, with fresh blinding factors rho_k.
+	var linkBase kyber.Point
+	if linkScope != nil {
+		linkBase = suite.Point().Pick(suite.XOF(linkScope))
+		sig.Tag = suite.Point().Mul(privateKey, linkBase)
+		sig.Q = make([]kyber.Point, m)
+	}
+	rho := make([]kyber.Scalar, m)
+	sig.G = make([]kyber.Point, m)
+	coeffs := make([]kyber.Scalar, len(L)+1)
+	points := append([]kyber.Point{nil}, L...)
+	for k := 0; k < m; k++ {
+		rho[k] = pick()
+		coeffs[0] = rho[k]
+		for i, p := range polys {
+			coeffs[i+1] = p[k]
+		}
+		sig.G[k] = msm.MultiScalarMul(suite, coeffs, points)
+		if linkScope != nil {
+			sig.Q[k] = suite.Point().Mul(rho[k], linkBase)
+		}
+	}
+
+	commitments := [][]kyber.Point{{sig.A, sig.B, sig.C, sig.D}, sig.G}
+	if linkScope != nil {
+		commitments = append(commitments, []kyber.Point{sig.Tag}, sig.Q)
+	}
+	x, err := compactChallenge(suite, message, linkScope, anonymitySet, commitments...)
+	if err != nil {
+		return nil, err
+	}
+
+	// f_j = b_j*x + a_j, zA = rB*x + rA, zC = rC*x + rD and
+	// z = privateKey*x^m - sum_k rho_k*x^k
+	sig.F = make([]kyber.Scalar, m)
+	for j := range sig.F {
+		sig.F[j] = suite.Scalar().Mul(b[j], x)
+		sig.F[j].Add(sig.F[j], a[j])
+	}
+	sig.ZA = suite.Scalar().Mul(rB, x)
+	sig.ZA.Add(sig.ZA, rA)
+	sig.ZC = suite.Scalar().Mul(rC, x)
+	sig.ZC.Add(sig.ZC, rD)
+	sig.Z = suite.Scalar().Zero()
+	xk := suite.Scalar().One()
+	for k := 0; k < m; k++ {
+		sig.Z.Sub(sig.Z, suite.Scalar().Mul(rho[k], xk))
+		xk.Mul(xk, x)
+	}
+	sig.Z.Add(sig.Z, suite.Scalar().Mul(privateKey, xk))
+
+	buf := bytes.Buffer{}
+	if linkScope != nil {
+		err = suite.Write(&buf, &sig.cSig, sig.Q, sig.Tag)
+	} else {
+		err = suite.Write(&buf, &sig.cSig)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CompactVerify checks a signature generated by CompactSign. Like Verify, it
+// returns the linkage tag of a valid linkable signature, or an empty but
+// non-nil byte-slice for a valid unlinkable signature.
+func CompactVerify(suite Suite, message []byte, anonymitySet Set, linkScope []byte,
+	signatureBuffer []byte) ([]byte, error) {
+	n := len(anonymitySet)
+	if n == 0 {
+		return nil, errors.New("empty anonymity set")
+	}
+	m := compactDepth(n)
+	L := padSet(anonymitySet, m)
+	H := compactGenerators(suite, m)
+
+	// Decode the signature
+	sig := clSig{}
+	sig.G = make([]kyber.Point, m)
+	sig.F = make([]kyber.Scalar, m)
+	buf := bytes.NewBuffer(signatureBuffer)
+	var linkBase kyber.Point
+	if linkScope != nil {
+		sig.Q = make([]kyber.Point, m)
+		if err := suite.Read(buf, &sig.cSig, &sig.Q, &sig.Tag); err != nil {
+			return nil, err
+		}
+		linkBase = suite.Point().Pick(suite.XOF(linkScope))
+	} else if err := suite.Read(buf, &sig.cSig); err != nil {
+		return nil, err
+	}
+	if buf.Len() != 0 {
+		return nil, errors.New("invalid signature length")
+	}
+
+	commitments := [][]kyber.Point{{sig.A, sig.B, sig.C, sig.D}, sig.G}
+	if linkScope != nil {
+		commitments = append(commitments, []kyber.Point{sig.Tag}, sig.Q)
+	}
+	x, err := compactChallenge(suite, message, linkScope, anonymitySet, commitments...)
+	if err != nil {
+		return nil, err
+	}
+	null := suite.Point().Null()
+
+	// The bits are committed in B: x*B + A == Com(f; zA) and
+	// x*C + D == Com(f*(x-f); zC)
+	fxf := make([]kyber.Scalar, m)
+	for j, f := range sig.F {
+		fxf[j] = suite.Scalar().Sub(x, f)
+		fxf[j].Mul(fxf[j], f)
+	}
+	check := msm.MultiScalarMul(suite, []kyber.Scalar{x, suite.Scalar().One()}, []kyber.Point{sig.B, sig.A})
+	if !check.Equal(compactCommit(suite, H, sig.F, sig.ZA)) {
+		return nil, errors.New("invalid signature")
+	}
+	check = msm.MultiScalarMul(suite, []kyber.Scalar{x, suite.Scalar().One()}, []kyber.Point{sig.C, sig.D})
+	if !check.Equal(compactCommit(suite, H, fxf, sig.ZC)) {
+		return nil, errors.New("invalid signature")
+	}
+
+	// p_i(x) = prod_j f_{j,i_j} with f_{j,1} = f_j and f_{j,0} = x - f_j
+	p := []kyber.Scalar{suite.Scalar().One()}
+	for j := 0; j < m; j++ {
+		f0 := suite.Scalar().Sub(x, sig.F[j])
+		next := make([]kyber.Scalar, 2*len(p))
+		for i, v := range p {
+			next[i] = suite.Scalar().Mul(v, f0)
+			next[i+len(p)] = suite.Scalar().Mul(v, sig.F[j])
+		}
+		p = next
+	}
+
+	// sum_i p_i(x)*L_i - sum_k x^k*G_k - z*B == 0 and, for linkable
+	// signatures, x^m*Tag - sum_k x^k*Q_k - z*linkBase == 0
+	scalars := append([]kyber.Scalar{}, p...)
+	points := append([]kyber.Point{}, L...)
+	var linkScalars []kyber.Scalar
+	var linkPoints []kyber.Point
+	xk := suite.Scalar().One()
+	for k := 0; k < m; k++ {
+		scalars = append(scalars, suite.Scalar().Neg(xk))
+		points = append(points, sig.G[k])
+		if linkScope != nil {
+			linkScalars = append(linkScalars, suite.Scalar().Neg(xk))
+			linkPoints = append(linkPoints, sig.Q[k])
+		}
+		xk = suite.Scalar().Mul(xk, x)
+	}
+	scalars = append(scalars, suite.Scalar().Neg(sig.Z))
+	points = append(points, nil)
+	if !msm.MultiScalarMul(suite, scalars, points).Equal(null) {
+		return nil, errors.New("invalid signature")
+	}
+	if linkScope == nil {
+		return []byte{}, nil
+	}
+
+	linkScalars = append(linkScalars, xk, suite.Scalar().Neg(sig.Z))
+	linkPoints = append(linkPoints, sig.Tag, linkBase)
+	if !msm.MultiScalarMul(suite, linkScalars, linkPoints).Equal(null) {
+		return nil, errors.New("invalid signature")
+	}
+	tag, err := sig.Tag.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// padSet returns the public keys padded to 2^m keys with copies of the last
+// one.
+func padSet(anonymitySet Set, m int) []kyber.Point {
+	L := make([]kyber.Point, 1<<m)
+	for i := range L {
+		L[i] = anonymitySet[min(i, len(anonymitySet)-1)]
+	}
+	return L
+}
+
+// polyMulLinear returns the coefficients of p(x)*(f[0] + f[1]*x).
+func polyMulLinear(suite Suite, p, f []kyber.Scalar) []kyber.Scalar {
+	out := make([]kyber.Scalar, len(p)+1)
+	for k := range out {
+		out[k] = suite.Scalar().Zero()
+	}
+	for k, c := range p {
+		out[k].Add(out[k], suite.Scalar().Mul(c, f[0]))
+		out[k+1].Add(out[k+1], suite.Scalar().Mul(c, f[1]))
+	}
+	return out
+}
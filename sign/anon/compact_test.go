@@ -0,0 +1,169 @@
+package anon
+
+import (
+	"bytes"
+	"testing"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+)
+
+func TestCompactSign(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	M := []byte("Hello World!")
+	for _, n := range []int{1, 2, 3, 5, 8, 13} {
+		X, x := benchGenKeys(suite, n)
+		for mine := 0; mine < n; mine++ {
+			X[mine] = suite.Point().Mul(x, nil)
+			sig, err := CompactSign(suite, M, Set(X), nil, mine, x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tag, err := CompactVerify(suite, M, Set(X), nil, sig)
+			if err != nil {
+				t.Fatalf("n = %d, mine = %d: %v", n, mine, err)
+			}
+			if tag == nil || len(tag) != 0 {
+				t.Fatal("CompactVerify returned a tag for an unlinkable signature")
+			}
+			if _, err := CompactVerify(suite, []byte("Goodbye world!"), Set(X), nil, sig); err == nil {
+				t.Fatal("signature verified against the wrong message")
+			}
+			X[mine] = suite.Point().Pick(suite.RandomStream())
+		}
+	}
+}
+
+func TestCompactSignLinkable(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	M := []byte("Hello World!")
+	scope := []byte("The Anonymous Society")
+	X, x := benchGenKeys(suite, 7)
+	y := suite.Scalar().Pick(suite.RandomStream())
+	X[2] = suite.Point().Mul(y, nil)
+
+	verify := func(sig []byte, scope []byte) []byte {
+		tag, err := CompactVerify(suite, M, Set(X), scope, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tag
+	}
+	sign := func(mine int, x kyber.Scalar, scope []byte) []byte {
+		sig, err := CompactSign(suite, M, Set(X), scope, mine, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return verify(sig, scope)
+	}
+
+	// the tags of the same signer are equal, and equal to those of Sign
+	tag := sign(0, x, scope)
+	if len(tag) == 0 || !bytes.Equal(tag, sign(0, x, scope)) {
+		t.Fatal("tags of the same signer differ")
+	}
+	linear, err := Verify(suite, M, Set(X), scope, Sign(suite, M, Set(X), scope, 0, x))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tag, linear) {
+		t.Fatal("tags of CompactSign and Sign differ")
+	}
+
+	// the tags of different signers or scopes differ
+	if bytes.Equal(tag, sign(2, y, scope)) {
+		t.Fatal("tags of different signers are equal")
+	}
+	if bytes.Equal(tag, sign(0, x, []byte("Another Society"))) {
+		t.Fatal("tags of different scopes are equal")
+	}
+
+	// a signature doesn't verify under another scope, or without it
+	sig, err := CompactSign(suite, M, Set(X), scope, 2, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CompactVerify(suite, M, Set(X), []byte("Another Society"), sig); err == nil {
+		t.Fatal("signature verified under another scope")
+	}
+	if _, err := CompactVerify(suite, M, Set(X), nil, sig); err == nil {
+		t.Fatal("linkable signature verified as unlinkable")
+	}
+}
+
+func TestCompactSignErrors(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	M := []byte("Hello World!")
+	X, x := benchGenKeys(suite, 4)
+	if _, err := CompactSign(suite, M, Set(X), nil, 4, x); err == nil {
+		t.Fatal("signed with an index out of range")
+	}
+	if _, err := CompactSign(suite, M, Set(X), nil, 1, x); err == nil {
+		t.Fatal("signed with the private key of another public key")
+	}
+
+	sig, err := CompactSign(suite, M, Set(X), nil, 0, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CompactVerify(suite, M, Set(X[:3]), nil, sig); err == nil {
+		t.Fatal("signature verified against another anonymity set")
+	}
+	Y := append([]kyber.Point{}, X...)
+	Y[3] = suite.Point().Pick(suite.RandomStream())
+	if _, err := CompactVerify(suite, M, Set(Y), nil, sig); err == nil {
+		t.Fatal("signature verified against another anonymity set")
+	}
+	if _, err := CompactVerify(suite, M, Set(X), nil, append(sig, 0)); err == nil {
+		t.Fatal("signature verified with trailing bytes")
+	}
+	if _, err := CompactVerify(suite, M, Set(X), nil, sig[:len(sig)-1]); err == nil {
+		t.Fatal("truncated signature verified")
+	}
+	bad := append([]byte{}, sig...)
+	bad[len(bad)-1] ^= 1
+	if _, err := CompactVerify(suite, M, Set(X), nil, bad); err == nil {
+		t.Fatal("modified signature verified")
+	}
+}
+
+func TestCompactSignSize(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	M := []byte("Hello World!")
+	X, x := benchGenKeys(suite, 1000)
+	sig, err := CompactSign(suite, M, Set(X), []byte("scope"), 0, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CompactVerify(suite, M, Set(X), []byte("scope"), sig); err != nil {
+		t.Fatal(err)
+	}
+	// m = 10 bits: 2m+5 points and m+3 scalars of 32 bytes
+	if len(sig) != (2*10+5+10+3)*32 {
+		t.Fatalf("unexpected signature size %d", len(sig))
+	}
+	if linear := len(Sign(suite, M, Set(X), []byte("scope"), 0, x)); len(sig) >= linear/10 {
+		t.Fatalf("signature of %d bytes isn't compact, Sign takes %d bytes", len(sig), linear)
+	}
+}
+
+func BenchmarkCompactSign100Ed25519(b *testing.B) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	for i := 0; i < b.N; i++ {
+		_, _ = CompactSign(suite, benchMessage, Set(benchPubEd25519), nil, 0, benchPriEd25519)
+	}
+}
+
+func BenchmarkCompactVerify100Ed25519(b *testing.B) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	sig, err := CompactSign(suite, benchMessage, Set(benchPubEd25519), nil, 0, benchPriEd25519)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := CompactVerify(suite, benchMessage, Set(benchPubEd25519), nil, sig); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
 )
 
 // unlinkable ring signature
@@ -220,18 +221,14 @@ func Verify(suite Suite, message []byte, anonymitySet Set,
 	H1pre := signH1pre(suite, linkScope, linkTag, message)
 
 	// Verify the signature
-	var P, PG, PH kyber.Point
-	P = suite.Point()
-	PG = suite.Point()
-	if linkScope != nil {
-		PH = suite.Point()
-	}
+	var PG, PH kyber.Point
 	s := sig.S
 	ci := sig.C0
 	for i := 0; i < n; i++ {
-		PG.Add(PG.Mul(s[i], nil), P.Mul(ci, L[i]))
+		sc := []kyber.Scalar{s[i], ci}
+		PG = msm.MultiScalarMul(suite, sc, []kyber.Point{nil, L[i]})
 		if linkScope != nil {
-			PH.Add(PH.Mul(s[i], linkBase), P.Mul(ci, linkTag))
+			PH = msm.MultiScalarMul(suite, sc, []kyber.Point{linkBase, linkTag})
 		}
 		ci = signH1(suite, H1pre, PG, PH)
 	}
@@ -12,7 +12,6 @@ package bdn
 import (
 	"crypto/cipher"
 	"errors"
-	"fmt"
 	"slices"
 
 	"go.dedis.ch/kyber/v4"
@@ -125,32 +124,29 @@ func (scheme *Scheme) Verify(x kyber.Point, msg, sig []byte) error {
 // one of them where c = H(pk) and H: keyGroup -> R with R = {1, ..., 2^128}
 func (scheme *Scheme) AggregateSignatures(sigs [][]byte, mask *Mask) (kyber.Point, error) {
 	agg := scheme.sigGroup.Point()
-	for i := range mask.publics {
-		if enabled, err := mask.GetBit(i); err != nil {
-			// this should never happen because of the loop boundary
-			// an error here is probably a bug in the mask implementation
-			return nil, fmt.Errorf("couldn't find the index %d: %w", i, err)
-		} else if !enabled {
-			continue
-		}
-
+	var err error
+	mask.bits.ForEachEnabled(func(i int) bool {
 		if len(sigs) == 0 {
-			return nil, errors.New("length of signatures and public keys must match")
+			err = errors.New("length of signatures and public keys must match")
+			return false
 		}
 
 		buf := sigs[0]
 		sigs = sigs[1:]
 
 		sig := scheme.sigGroup.Point()
-		err := sig.UnmarshalBinary(buf)
-		if err != nil {
-			return nil, err
+		if err = sig.UnmarshalBinary(buf); err != nil {
+			return false
 		}
 
 		sigC := sig.Clone().Mul(mask.publicCoefs[i], sig)
 		// c+1 because R is in the range [1, 2^128] and not [0, 2^128-1]
 		sigC = sigC.Add(sigC, sig)
 		agg = agg.Add(agg, sigC)
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if len(sigs) > 0 {
@@ -165,17 +161,10 @@ func (scheme *Scheme) AggregateSignatures(sigs [][]byte, mask *Mask) (kyber.Poin
 // H: keyGroup -> R with R = {1, ..., 2^128}.
 func (scheme *Scheme) AggregatePublicKeys(mask *Mask) (kyber.Point, error) {
 	agg := scheme.keyGroup.Point()
-	for i := range mask.publics {
-		if enabled, err := mask.GetBit(i); err != nil {
-			// this should never happen because of the loop boundary
-			// an error here is probably a bug in the mask implementation
-			return nil, fmt.Errorf("couldn't find the index %d: %w", i, err)
-		} else if !enabled {
-			continue
-		}
-
+	mask.bits.ForEachEnabled(func(i int) bool {
 		agg = agg.Add(agg, mask.publicTerms[i])
-	}
+		return true
+	})
 
 	return agg, nil
 }
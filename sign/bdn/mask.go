@@ -3,16 +3,16 @@ package bdn
 import (
 	"errors"
 	"fmt"
-	"slices"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/sign"
 )
 
 // Mask is a bitmask of the participation to a collective signature.
 type Mask struct {
 	// The bitmask indicating which public keys are enabled/disabled for aggregation. This is
 	// the only mutable field.
-	mask []byte
+	bits *sign.Mask
 
 	// The following fields are immutable and should not be changed after the mask is created.
 	// They may be shared between multiple masks.
@@ -33,9 +33,9 @@ type Mask struct {
 // cloning it whenever aggregating signatures and/or public keys).
 func NewMask(group kyber.Group, publics []kyber.Point, myKey kyber.Point) (*Mask, error) {
 	m := &Mask{
+		bits:    sign.NewMask(len(publics)),
 		publics: publics,
 	}
-	m.mask = make([]byte, m.Len())
 
 	if myKey != nil {
 		for i, key := range publics {
@@ -65,95 +65,56 @@ func NewMask(group kyber.Group, publics []kyber.Point, myKey kyber.Point) (*Mask
 
 // Mask returns the bitmask as a byte array.
 func (m *Mask) Mask() []byte {
-	clone := make([]byte, len(m.mask))
-	copy(clone, m.mask)
-	return clone
+	return m.bits.Bytes()
+}
+
+// Participation returns a copy of the participation mask.
+func (m *Mask) Participation() *sign.Mask {
+	return m.bits.Clone()
+}
+
+// SetParticipation replaces the current mask by a copy of the new one if
+// the number of candidates matches.
+func (m *Mask) SetParticipation(bits *sign.Mask) error {
+	if bits.CountTotal() != len(m.publics) {
+		return sign.ErrMaskLength
+	}
+	m.bits = bits.Clone()
+	return nil
 }
 
 // Len returns the length of the byte array necessary to store the bitmask.
 func (m *Mask) Len() int {
-	return (len(m.publics) + 7) / 8
+	return m.bits.Len()
 }
 
 // SetMask replaces the current mask by the new one if the length matches.
 func (m *Mask) SetMask(mask []byte) error {
-	if m.Len() != len(mask) {
+	if err := m.bits.SetBytes(mask); err != nil {
 		return fmt.Errorf("mismatching mask lengths")
 	}
-
-	m.mask = mask
 	return nil
 }
 
 // GetBit returns true if the given bit is set.
 func (m *Mask) GetBit(i int) (bool, error) {
-	if i >= len(m.publics) || i < 0 {
-		return false, errors.New("index out of range")
-	}
-
-	byteIndex := i / 8
-	mask := byte(1) << uint(i&7)
-	return m.mask[byteIndex]&mask != 0, nil
+	return m.bits.IndexEnabled(i)
 }
 
 // SetBit turns on or off the bit at the given index.
 func (m *Mask) SetBit(i int, enable bool) error {
-	if i >= len(m.publics) || i < 0 {
-		return errors.New("index out of range")
-	}
-
-	byteIndex := i / 8
-	mask := byte(1) << uint(i&7)
-	if enable {
-		m.mask[byteIndex] |= mask
-	} else {
-		m.mask[byteIndex] &^= mask
-	}
-	return nil
-}
-
-// forEachBitEnabled is a helper to iterate over the bits set to 1 in the mask
-// and to return the result of the callback only if it is positive.
-func (m *Mask) forEachBitEnabled(f func(i, j, n int) int) int {
-	n := 0
-	for i, b := range m.mask {
-		for j := uint(0); j < 8; j++ {
-			mm := byte(1) << (j & 7)
-
-			if b&mm != 0 {
-				if res := f(i, int(j), n); res >= 0 {
-					return res
-				}
-
-				n++
-			}
-		}
-	}
-
-	return -1
+	return m.bits.SetBit(i, enable)
 }
 
 // IndexOfNthEnabled returns the index of the nth enabled bit or -1 if out of bounds.
 func (m *Mask) IndexOfNthEnabled(nth int) int {
-	return m.forEachBitEnabled(func(i, j, n int) int {
-		if n == nth {
-			return i*8 + int(j)
-		}
-
-		return -1
-	})
+	return m.bits.IndexOfNthEnabled(nth)
 }
 
 // NthEnabledAtIndex returns the sum of bits set to 1 until the given index. In other
 // words, it returns how many bits are enabled before the given index.
 func (m *Mask) NthEnabledAtIndex(idx int) int {
-	return m.forEachBitEnabled(func(i, j, n int) int {
-		if i*8+int(j) == idx {
-			return n
-		}
-
-		return -1
-	})
+	return m.bits.NthEnabledAtIndex(idx)
 }
 
 // Publics returns a copy of the list of public keys.
@@ -166,28 +127,16 @@ func (m *Mask) Publics() []kyber.Point {
 // Participants returns the list of public keys participating.
 func (m *Mask) Participants() []kyber.Point {
 	pp := []kyber.Point{}
-	for i, p := range m.publics {
-		byteIndex := i / 8
-		mask := byte(1) << uint(i&7)
-		if (m.mask[byteIndex] & mask) != 0 {
-			pp = append(pp, p)
-		}
-	}
-
+	m.bits.ForEachEnabled(func(i int) bool {
+		pp = append(pp, m.publics[i])
+		return true
+	})
 	return pp
 }
 
 // CountEnabled returns the number of bit set to 1
 func (m *Mask) CountEnabled() int {
-	count := 0
-	for i := range m.publics {
-		byteIndex := i / 8
-		mask := byte(1) << uint(i&7)
-		if (m.mask[byteIndex] & mask) != 0 {
-			count++
-		}
-	}
-	return count
+	return m.bits.CountEnabled()
 }
 
 // CountTotal returns the number of potential participants
@@ -198,22 +147,18 @@ func (m *Mask) CountTotal() int {
 // Merge merges the given mask to the current one only if
 // the length matches
 func (m *Mask) Merge(mask []byte) error {
-	if len(m.mask) != len(mask) {
+	other, err := sign.NewMaskFromBytes(len(m.publics), mask)
+	if err != nil {
 		return errors.New("mismatching mask length")
 	}
-
-	for i := range m.mask {
-		m.mask[i] |= mask[i]
-	}
-
-	return nil
+	return m.bits.Union(other)
 }
 
 // Clone copies the mask while keeping the precomputed coefficients, etc. This method is thread safe
 // and does not modify the original mask. Modifications to the new Mask will not affect the original.
 func (m *Mask) Clone() *Mask {
 	return &Mask{
-		mask:        slices.Clone(m.mask),
+		bits:        m.bits.Clone(),
 		publics:     m.publics,
 		publicCoefs: m.publicCoefs,
 		publicTerms: m.publicTerms,
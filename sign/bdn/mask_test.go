@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/util/key"
 )
 
@@ -151,3 +152,21 @@ func TestMask_PositionalQueries(t *testing.T) {
 		require.Equal(t, -1, mask.NthEnabledAtIndex(-1))
 	}
 }
+
+func TestMask_Participation(t *testing.T) {
+	mask, err := NewMask(suite, publics, nil)
+	require.NoError(t, err)
+
+	bits := sign.NewMask(n)
+	require.NoError(t, bits.SetBit(3, true))
+	require.NoError(t, bits.SetBit(16, true))
+	require.NoError(t, mask.SetParticipation(bits))
+	require.Equal(t, 2, len(mask.Participants()))
+	require.True(t, bits.Equal(mask.Participation()))
+
+	// the mask keeps its own copy
+	require.NoError(t, bits.SetBit(4, true))
+	require.Equal(t, 2, mask.CountEnabled())
+
+	require.ErrorIs(t, mask.SetParticipation(sign.NewMask(n+1)), sign.ErrMaskLength)
+}
@@ -0,0 +1,345 @@
+/*
+Package bip340 implements the Schnorr signatures over secp256k1 specified in
+BIP-340 (https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki),
+which are the signatures verified by Bitcoin for Taproot outputs, along with
+the tweaking of their keys specified in BIP-341.
+
+Public keys are encoded as the 32 bytes x-coordinate of a point with an even
+y-coordinate, and signatures as the 32 bytes x-coordinate of the commitment
+R, which also has an even y-coordinate, followed by the 32 bytes response s.
+All the hashes are the tagged SHA-256 hashes of BIP-340.
+
+Unlike package sign/schnorr, this package only works with the s256 group.
+*/
+package bip340
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+	"go.dedis.ch/kyber/v4/group/s256"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+const (
+	// PublicKeySize is the size of the x-only public keys.
+	PublicKeySize = 32
+	// SignatureSize is the size of the signatures, R || s.
+	SignatureSize = 64
+	// AuxSize is the size of the auxiliary random data mixed into the
+	// nonces.
+	AuxSize = 32
+)
+
+var (
+	// ErrInvalidPublicKey is returned for a public key which isn't the
+	// x-coordinate of a point of the curve.
+	ErrInvalidPublicKey = errors.New("bip340: invalid public key")
+	// ErrInvalidSignature is returned when a signature doesn't verify.
+	ErrInvalidSignature = errors.New("bip340: invalid signature")
+	// ErrInvalidTweak is returned when a tweak is not lower than the group
+	// order, which happens with negligible probability.
+	ErrInvalidTweak = errors.New("bip340: invalid tweak")
+)
+
+var group = s256.NewSuite()
+
+// fieldPrime is the order of the field of the coordinates of secp256k1.
+var fieldPrime, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+// sqrtExp is (p+1)/4, the exponent of the square roots modulo p.
+var sqrtExp = new(big.Int).Rsh(new(big.Int).Add(fieldPrime, big.NewInt(1)), 2)
+
+// Scheme implements sign.Scheme with BIP-340 signatures. Its Verify method
+// only depends on the x-coordinate of the public key.
+type Scheme struct{}
+
+// NewScheme returns the BIP-340 signature scheme.
+func NewScheme() sign.Scheme {
+	return &Scheme{}
+}
+
+// NewKeyPair returns a fresh secret key and its public point.
+func (s *Scheme) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	priv := group.Scalar().Pick(random)
+	pub := group.Point().Mul(priv, nil)
+	return priv, pub
+}
+
+// Sign returns the BIP-340 signature of msg by private, with fresh
+// auxiliary random data.
+func (s *Scheme) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	aux := make([]byte, AuxSize)
+	random.Bytes(aux, random.New())
+	return Sign(private, msg, aux)
+}
+
+// Verify checks the BIP-340 signature of msg by the x-only key of public.
+func (s *Scheme) Verify(public kyber.Point, msg, sig []byte) error {
+	return Verify(XOnly(public), msg, sig)
+}
+
+// Group returns the secp256k1 group used by this package.
+func Group() kyber.Group {
+	return group
+}
+
+// TaggedHash returns the BIP-340 tagged hash
+// SHA256(SHA256(tag) || SHA256(tag) || msgs...).
+func TaggedHash(tag string, msgs ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(t[:])
+	h.Write(t[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// XOnly returns the 32 bytes x-coordinate of the point P.
+func XOnly(P kyber.Point) []byte {
+	x, _ := coordinates(P)
+	return x
+}
+
+// PublicKey returns the x-only public key of the secret key private.
+func PublicKey(private kyber.Scalar) []byte {
+	return XOnly(group.Point().Mul(private, nil))
+}
+
+// LiftX returns the point with the even y-coordinate whose x-coordinate
+// is the 32 bytes big-endian integer x.
+func LiftX(x []byte) (kyber.Point, error) {
+	if len(x) != PublicKeySize {
+		return nil, fmt.Errorf("%w: length %d instead of %d", ErrInvalidPublicKey, len(x), PublicKeySize)
+	}
+	xi := new(big.Int).SetBytes(x)
+	if xi.Cmp(fieldPrime) >= 0 {
+		return nil, fmt.Errorf("%w: coordinate exceeds the field size", ErrInvalidPublicKey)
+	}
+
+	// y^2 = x^3 + 7
+	c := new(big.Int).Exp(xi, big.NewInt(3), fieldPrime)
+	c.Add(c, big.NewInt(7)).Mod(c, fieldPrime)
+	y := new(big.Int).Exp(c, sqrtExp, fieldPrime)
+	if new(big.Int).Exp(y, big.NewInt(2), fieldPrime).Cmp(c) != 0 {
+		return nil, fmt.Errorf("%w: not on the curve", ErrInvalidPublicKey)
+	}
+	if y.Bit(0) == 1 {
+		y.Sub(fieldPrime, y)
+	}
+
+	buf := make([]byte, 1+2*PublicKeySize)
+	buf[0] = 4
+	copy(buf[1:], x)
+	y.FillBytes(buf[1+PublicKeySize:])
+	P := group.Point()
+	if err := P.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPublicKey, err)
+	}
+	return P, nil
+}
+
+// Sign returns the BIP-340 signature of msg by the secret key private,
+// using the 32 bytes of auxiliary random data aux to derive the nonce.
+// Fresh random data should be used for each signature as recommended by
+// BIP-340, but any value, e.g. all zeros, gives a valid signature.
+func Sign(private kyber.Scalar, msg, aux []byte) ([]byte, error) {
+	if len(aux) != AuxSize {
+		return nil, fmt.Errorf("bip340: auxiliary data of length %d instead of %d", len(aux), AuxSize)
+	}
+	if private.Equal(group.Scalar().Zero()) {
+		return nil, errors.New("bip340: zero secret key")
+	}
+
+	d, P := normalize(private)
+	pub := XOnly(P)
+
+	// t = bytes(d) xor hash_BIP0340/aux(a)
+	t, err := d.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range TaggedHash("BIP0340/aux", aux) {
+		t[i] ^= b
+	}
+
+	// k' = int(hash_BIP0340/nonce(t || bytes(P) || m)) mod n
+	k := group.Scalar().SetBytes(TaggedHash("BIP0340/nonce", t, pub, msg))
+	if k.Equal(group.Scalar().Zero()) {
+		return nil, errors.New("bip340: zero nonce")
+	}
+	k, R := normalize(k)
+	r := XOnly(R)
+
+	// s = k + e*d mod n
+	e := challenge(r, pub, msg)
+	s := group.Scalar().Mul(e, d)
+	s.Add(k, s)
+	sBuff, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	sig := make([]byte, SignatureSize)
+	copy(sig, r)
+	copy(sig[32:], sBuff)
+	if err := Verify(pub, msg, sig); err != nil {
+		return nil, fmt.Errorf("bip340: produced an invalid signature: %w", err)
+	}
+	return sig, nil
+}
+
+// Verify returns nil if sig is a valid BIP-340 signature of msg by the
+// x-only public key pub, or an error otherwise.
+func Verify(pub, msg, sig []byte) error {
+	P, r, s, err := decode(pub, sig)
+	if err != nil {
+		return err
+	}
+
+	// R = s*G - e*P
+	e := challenge(sig[:32], pub, msg)
+	R := msm.MultiScalarMul(group, []kyber.Scalar{s, e.Neg(e)}, []kyber.Point{nil, P})
+	x, y := coordinates(R)
+	if R.Equal(group.Point().Null()) || y[len(y)-1]&1 != 0 || !bytes.Equal(x, r) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// BatchVerify returns nil if every sigs[i] is a valid signature of msgs[i]
+// by pubs[i], or an error otherwise. It checks a random linear combination
+// of the verification equations, which is faster than verifying the
+// signatures one by one, but doesn't tell which signatures are invalid.
+func BatchVerify(pubs, msgs, sigs [][]byte) error {
+	if len(pubs) != len(msgs) || len(pubs) != len(sigs) {
+		return errors.New("bip340: mismatching numbers of keys, messages and signatures")
+	}
+	if len(pubs) == 0 {
+		return nil
+	}
+
+	// sum(a_i*s_i)*G - sum(a_i*R_i) - sum(a_i*e_i*P_i) == 0, with a_1 = 1
+	// and a random a_i otherwise.
+	rand := random.New()
+	scalars := make([]kyber.Scalar, 1, 2*len(sigs)+1)
+	points := make([]kyber.Point, 1, 2*len(sigs)+1)
+	sum := group.Scalar().Zero()
+	for i := range sigs {
+		P, r, s, err := decode(pubs[i], sigs[i])
+		if err != nil {
+			return fmt.Errorf("signature %d: %w", i, err)
+		}
+		R, err := LiftX(r)
+		if err != nil {
+			return fmt.Errorf("signature %d: %w", i, ErrInvalidSignature)
+		}
+		e := challenge(r, pubs[i], msgs[i])
+
+		a := group.Scalar().One()
+		if i > 0 {
+			a.Pick(rand)
+		}
+		sum.Add(sum, s.Mul(a, s))
+		scalars = append(scalars, group.Scalar().Neg(a), e.Neg(e.Mul(a, e)))
+		points = append(points, R, P)
+	}
+	scalars[0] = sum
+
+	if !msm.MultiScalarMul(group, scalars, points).Equal(group.Point().Null()) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// TweakPublicKey returns the x-only output key Q = P + t*G of BIP-341,
+// where P is the point of the internal x-only key pub and
+// t = hash_TapTweak(pub || merkleRoot), along with the parity of the
+// y-coordinate of Q. merkleRoot is empty for outputs without script path.
+func TweakPublicKey(pub, merkleRoot []byte) ([]byte, bool, error) {
+	P, err := LiftX(pub)
+	if err != nil {
+		return nil, false, err
+	}
+	t, err := tweak(pub, merkleRoot)
+	if err != nil {
+		return nil, false, err
+	}
+	Q := group.Point().Mul(t, nil)
+	Q.Add(Q, P)
+	x, y := coordinates(Q)
+	return x, y[len(y)-1]&1 == 1, nil
+}
+
+// TweakSecretKey returns the secret key of the output key returned by
+// TweakPublicKey for the internal key of private, so that the output can
+// be spent with Sign.
+func TweakSecretKey(private kyber.Scalar, merkleRoot []byte) (kyber.Scalar, error) {
+	d, P := normalize(private)
+	t, err := tweak(XOnly(P), merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return t.Add(d, t), nil
+}
+
+// tweak returns t = hash_TapTweak(pub || merkleRoot) as a scalar.
+func tweak(pub, merkleRoot []byte) (kyber.Scalar, error) {
+	h := TaggedHash("TapTweak", pub, merkleRoot)
+	if new(big.Int).SetBytes(h).Cmp(group.Scalar().GroupOrder()) >= 0 {
+		return nil, ErrInvalidTweak
+	}
+	return group.Scalar().SetBytes(h), nil
+}
+
+// challenge returns e = int(hash_BIP0340/challenge(r || pub || msg)) mod n.
+func challenge(r, pub, msg []byte) kyber.Scalar {
+	return group.Scalar().SetBytes(TaggedHash("BIP0340/challenge", r, pub, msg))
+}
+
+// normalize returns the scalar among k and -k whose multiple of the base
+// point has an even y-coordinate, along with this multiple.
+func normalize(k kyber.Scalar) (kyber.Scalar, kyber.Point) {
+	K := group.Point().Mul(k, nil)
+	if _, y := coordinates(K); y[len(y)-1]&1 == 1 {
+		return group.Scalar().Neg(k), K.Neg(K)
+	}
+	return k.Clone(), K
+}
+
+// decode returns the public point of pub along with the x-coordinate r and
+// the response s of sig, checking that they are in range.
+func decode(pub, sig []byte) (kyber.Point, []byte, kyber.Scalar, error) {
+	P, err := LiftX(pub)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(sig) != SignatureSize {
+		return nil, nil, nil, fmt.Errorf("%w: length %d instead of %d", ErrInvalidSignature, len(sig), SignatureSize)
+	}
+	r := sig[:32]
+	if new(big.Int).SetBytes(r).Cmp(fieldPrime) >= 0 {
+		return nil, nil, nil, fmt.Errorf("%w: r exceeds the field size", ErrInvalidSignature)
+	}
+	s := group.Scalar()
+	if new(big.Int).SetBytes(sig[32:]).Cmp(s.GroupOrder()) >= 0 {
+		return nil, nil, nil, fmt.Errorf("%w: s exceeds the group order", ErrInvalidSignature)
+	}
+	s.SetBytes(sig[32:])
+	return P, r, s, nil
+}
+
+// coordinates returns the 32 bytes big-endian coordinates of P.
+func coordinates(P kyber.Point) ([]byte, []byte) {
+	buf, _ := P.MarshalBinary()
+	return buf[1 : 1+PublicKeySize], buf[1+PublicKeySize:]
+}
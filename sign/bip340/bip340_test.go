@@ -0,0 +1,262 @@
+package bip340
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/internal/test"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// Test vectors taken from
+// https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+var vectors = []struct {
+	secret    string
+	public    string
+	aux       string
+	msg       string
+	signature string
+	valid     bool
+}{
+	{"0000000000000000000000000000000000000000000000000000000000000003",
+		"F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+		true},
+	{"B7E151628AED2A6ABF7158809CF4F3C762E7160F38B4DA56A784D9045190CFEF",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+		true},
+	{"C90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B14E5C9",
+		"DD308AFEC5777E13121FA72B9CC1B7CC0139715309B086C960E18FD969774EB8",
+		"C87AA53824B4D7AE2EB035A2B5BBBCCC080E76CDC6D1692C4B0B62D798E6D906",
+		"7E2D58D8B3BCDF1ABADEC7829054F90DDA9805AAB56C77333024B9D0A508B75C",
+		"5831AAEED7B44BB74E5EAB94BA9D4294C49BCF2A60728D8B4C200F50DD313C1BAB745879A5AD954A72C45A91C3A51D3C7ADEA98D82F8481E0E1E03674A6F3FB7",
+		true},
+	{"0B432B2677937381AEF05BB02A66ECD012773062CF3FA2549E44F58ED2401710",
+		"25D1DFF95105F5253C4022F628A996AD3A0D95FBF21D468A1B33F8C160D8F517",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+		"7EB0509757E246F19449885651611CB965ECC1A187DD51B64FDA1EDC9637D5EC97582B9CB13DB3933705B32BA982AF5AF25FD78881EBB32771FC5922EFC66EA3",
+		true},
+	{"",
+		"D69C3509BB99E412E68B0FE8544E72837DFA30746D8BE2AA65975F29D22DC7B9",
+		"",
+		"4DF3C3F68FCC83B27E9D42C90431A72499F17875C81A599B566C9889B9696703",
+		"00000000000000000000003B78CE563F89A0ED9414F5AA28AD0D96D6795F9C6376AFB1548AF603B3EB45C9F8207DEE1060CB71C04E80F593060B07D28308D7F4",
+		true},
+	// public key not on the curve
+	{"",
+		"EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		false},
+	// has_even_y(R) is false
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"FFF97BD5755EEEA420453A14355235D382F6472F8568A18B2F057A14602975563CC27944640AC607CD107AE10923D9EF7A73C643E166BE5EBEAFA34B1AC553E2",
+		false},
+	// negated message
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"1FA62E331EDBC21C394792D2AB1100A7B432B013DF3F6FF4F99FCB33E0E1515F28890B3EDB6E7189B630448B515CE4F8622A954CFE545735AAEA5134FCCDB2BD",
+		false},
+	// negated s value
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769961764B3AA9B2FFCB6EF947B6887A226E8D7C93E00C5ED0C1834FF0D0C2E6DA6",
+		false},
+	// sG - eP is infinite, with x(inf) as 0
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"0000000000000000000000000000000000000000000000000000000000000000123DDA8328AF9C23A94C1FEECFD123BA4FB73476F0D594DCB65C6425BD186051",
+		false},
+	// sG - eP is infinite, with x(inf) as 1
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"00000000000000000000000000000000000000000000000000000000000000017615FBAF5AE28864013C099742DEADB4DBA87F11AC6754F93780D5A1837CF197",
+		false},
+	// sig[0:32] is not an x-coordinate on the curve
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"4A298DACAE57395A15D0795DDBFD1DCB564DA82B0F269BC70A74F8220429BA1D69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		false},
+	// sig[0:32] is equal to the field size
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F69E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		false},
+	// sig[32:64] is equal to the curve order
+	{"",
+		"DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E177769FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141",
+		false},
+	// public key exceeds the field size
+	{"",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		"",
+		"243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+		"6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+		false},
+	// messages of other sizes than 32 bytes
+	{"0340034003400340034003400340034003400340034003400340034003400340",
+		"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"",
+		"71535DB165ECD9FBBC046E5FFAEA61186BB6AD436732FCCC25291A55895464CF6069CE26BF03466228F19A3A62DB8A649F2D560FAC652827D1AF0574E427AB63",
+		true},
+	{"0340034003400340034003400340034003400340034003400340034003400340",
+		"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"11",
+		"08A20A0AFEF64124649232E0693C583AB1B9934AE63B4C3511F3AE1134C6A303EA3173BFEA6683BD101FA5AA5DBC1996FE7CACFC5A577D33EC14564CEC2BACBF",
+		true},
+	{"0340034003400340034003400340034003400340034003400340034003400340",
+		"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0102030405060708090A0B0C0D0E0F1011",
+		"5130F39A4059B43BC7CAC09A19ECE52B5D8699D1A71E3C52DA9AFDB6B50AC370C4A482B77BF960F8681540E25B6771ECE1E5A37FD80E5A51897C5566A97EA5A5",
+		true},
+	{"0340034003400340034003400340034003400340034003400340034003400340",
+		"778CAA53B4393AC467774D09497A87224BF9FAB6F6E68B23086497324D6FD117",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		strings.Repeat("99", 100),
+		"403B12B0D8555A344175EA7EC746566303321E5DBFA8BE6F091635163ECA79A8585ED3E3170807E7C03B720FC54C7B23897FCBA0E9D0B4A06894CFD249F22367",
+		true},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestVectors(t *testing.T) {
+	for i, v := range vectors {
+		public := decodeHex(t, v.public)
+		msg := decodeHex(t, v.msg)
+		sig := decodeHex(t, v.signature)
+
+		if v.secret != "" {
+			private := Group().Scalar().SetBytes(decodeHex(t, v.secret))
+			require.Equal(t, public, PublicKey(private), "vector %d", i)
+			s, err := Sign(private, msg, decodeHex(t, v.aux))
+			require.NoError(t, err, "vector %d", i)
+			require.Equal(t, sig, s, "vector %d", i)
+		}
+
+		err := Verify(public, msg, sig)
+		if v.valid {
+			require.NoError(t, err, "vector %d", i)
+		} else {
+			require.Error(t, err, "vector %d", i)
+		}
+	}
+}
+
+func TestScheme(t *testing.T) {
+	test.SchemeTesting(t, NewScheme())
+
+	// The public point may have an odd y-coordinate.
+	s := NewScheme()
+	for i := 0; i < 4; i++ {
+		private, public := s.NewKeyPair(random.New())
+		sig, err := s.Sign(private, []byte("odd or even"))
+		require.NoError(t, err)
+		require.NoError(t, s.Verify(public, []byte("odd or even"), sig))
+		require.NoError(t, s.Verify(Group().Point().Neg(public), []byte("odd or even"), sig))
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	n := 8
+	pubs := make([][]byte, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	aux := make([]byte, AuxSize)
+	for i := range sigs {
+		private := Group().Scalar().Pick(random.New())
+		pubs[i] = PublicKey(private)
+		msgs[i] = []byte{byte(i)}
+		sig, err := Sign(private, msgs[i], aux)
+		require.NoError(t, err)
+		sigs[i] = sig
+	}
+	require.NoError(t, BatchVerify(pubs, msgs, sigs))
+	require.NoError(t, BatchVerify(nil, nil, nil))
+
+	// The valid vectors verify as a batch, the other ones don't.
+	var vpubs, vmsgs, vsigs [][]byte
+	for _, v := range vectors {
+		p, m, s := decodeHex(t, v.public), decodeHex(t, v.msg), decodeHex(t, v.signature)
+		if v.valid {
+			vpubs, vmsgs, vsigs = append(vpubs, p), append(vmsgs, m), append(vsigs, s)
+			continue
+		}
+		require.Error(t, BatchVerify(append(pubs, p), append(msgs, m), append(sigs, s)))
+	}
+	require.NoError(t, BatchVerify(vpubs, vmsgs, vsigs))
+
+	msgs[3] = []byte("forged")
+	require.ErrorIs(t, BatchVerify(pubs, msgs, sigs), ErrInvalidSignature)
+	require.Error(t, BatchVerify(pubs, msgs[1:], sigs))
+}
+
+func TestTweak(t *testing.T) {
+	private := Group().Scalar().Pick(random.New())
+	pub := PublicKey(private)
+	root := TaggedHash("TapBranch", []byte("scripts"))
+
+	for _, h := range [][]byte{nil, root} {
+		output, odd, err := TweakPublicKey(pub, h)
+		require.NoError(t, err)
+
+		tweaked, err := TweakSecretKey(private, h)
+		require.NoError(t, err)
+		Q := Group().Point().Mul(tweaked, nil)
+		require.Equal(t, output, XOnly(Q))
+		_, y := coordinates(Q)
+		require.Equal(t, odd, y[len(y)-1]&1 == 1)
+
+		// The tweaked key can sign for the output key.
+		sig, err := Sign(tweaked, []byte("key path"), make([]byte, AuxSize))
+		require.NoError(t, err)
+		require.NoError(t, Verify(output, []byte("key path"), sig))
+		require.Error(t, Verify(pub, []byte("key path"), sig))
+	}
+
+	_, _, err := TweakPublicKey(bytes.Repeat([]byte{0xff}, PublicKeySize), nil)
+	require.ErrorIs(t, err, ErrInvalidPublicKey)
+}
+
+// Test vector of BIP-86: the first receiving address of the account 0 of
+// the "abandon ... about" mnemonic.
+func TestTweakBIP86(t *testing.T) {
+	internal := decodeHex(t, "cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	output, _, err := TweakPublicKey(internal, nil)
+	require.NoError(t, err)
+	require.Equal(t, "a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", hex.EncodeToString(output))
+}
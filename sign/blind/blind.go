@@ -0,0 +1,17 @@
+// Package blind implements blind signature schemes, where a signer signs a
+// message chosen by a client without learning it, nor being able to link the
+// resulting signature to the signing session. They are the basis of
+// anonymous tokens: an issuer signs blinded tokens, and later can't tell
+// which client redeems which token.
+//
+// BLS is the blind variant of the BLS signatures of the sign/bls package,
+// on the pairing suites. Schnorr is the Clause-blind Schnorr scheme of
+// Fuchsbauer, Plouviez and Seurin, "Blind Schnorr Signatures and Signed
+// ElGamal Encryption in the Algebraic Group Model"
+// (https://eprint.iacr.org/2019/877), whose signatures are those of the
+// sign/schnorr package on prime-order groups. Unlike the plain blind Schnorr
+// scheme, it resists the ROS attack of Benhamouda et al. against concurrent
+// signing sessions (https://eprint.iacr.org/2020/945): the signer commits to
+// two nonces and answers only one of the two challenges of the client,
+// chosen at random.
+package blind
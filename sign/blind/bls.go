@@ -0,0 +1,109 @@
+package blind
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/sign/bls"
+)
+
+// BLS is a blind BLS scheme. The client blinds the hash H(m) of its message
+// as r*H(m) with a random factor r, the signer multiplies it by its private
+// key, and the client unblinds the result with the inverse of r into the
+// BLS signature x*H(m).
+type BLS struct {
+	sigGroup kyber.Group
+	scheme   sign.Scheme
+}
+
+// NewBLSOnG1 returns the blind BLS scheme whose signatures are in G1 and the
+// public keys in G2, and verify with bls.NewSchemeOnG1.
+func NewBLSOnG1(suite pairing.Suite) *BLS {
+	return &BLS{sigGroup: suite.G1(), scheme: bls.NewSchemeOnG1(suite)}
+}
+
+// NewBLSOnG2 returns the blind BLS scheme whose signatures are in G2 and the
+// public keys in G1, and verify with bls.NewSchemeOnG2.
+func NewBLSOnG2(suite pairing.Suite) *BLS {
+	return &BLS{sigGroup: suite.G2(), scheme: bls.NewSchemeOnG2(suite)}
+}
+
+// Scheme returns the BLS scheme which verifies the unblinded signatures.
+func (b *BLS) Scheme() sign.Scheme {
+	return b.scheme
+}
+
+// BLSBlinding is the state of the client between blinding its message and
+// unblinding the signature.
+type BLSBlinding struct {
+	b      *BLS
+	msg    []byte
+	factor kyber.Scalar
+}
+
+// Blind returns the blinding of the message and the blinded message to send
+// to the signer.
+func (b *BLS) Blind(random cipher.Stream, msg []byte) (*BLSBlinding, []byte, error) {
+	hashable, ok := b.sigGroup.Point().(kyber.HashablePoint)
+	if !ok {
+		return nil, nil, errors.New("blind: point needs to implement hashablePoint")
+	}
+	factor := b.sigGroup.Scalar().Pick(random)
+	for factor.Equal(b.sigGroup.Scalar().Zero()) {
+		factor.Pick(random)
+	}
+	HM := hashable.Hash(msg)
+	blinded, err := HM.Mul(factor, HM).MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &BLSBlinding{b: b, msg: msg, factor: factor}, blinded, nil
+}
+
+// Sign returns the blind signature of the blinded message. The signer learns
+// nothing of the message, so it must authorize the client otherwise.
+func (b *BLS) Sign(private kyber.Scalar, blinded []byte) ([]byte, error) {
+	M, err := b.decodePoint(blinded)
+	if err != nil {
+		return nil, err
+	}
+	return M.Mul(private, M).MarshalBinary()
+}
+
+// Unblind returns the signature of the message from the blind signature,
+// after checking it against the public key of the signer.
+func (bl *BLSBlinding) Unblind(public kyber.Point, blindSig []byte) ([]byte, error) {
+	S, err := bl.b.decodePoint(blindSig)
+	if err != nil {
+		return nil, err
+	}
+	inv := bl.b.sigGroup.Scalar().Inv(bl.factor)
+	sig, err := S.Mul(inv, S).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := bl.b.scheme.Verify(public, bl.msg, sig); err != nil {
+		return nil, fmt.Errorf("blind: invalid blind signature: %w", err)
+	}
+	return sig, nil
+}
+
+// decodePoint decodes a point of the signature group, rejecting the
+// identity and the points outside of the prime-order subgroup.
+func (b *BLS) decodePoint(buf []byte) (kyber.Point, error) {
+	P := b.sigGroup.Point()
+	if err := P.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("blind: unmarshalling point: %w", err)
+	}
+	if P.Equal(b.sigGroup.Point().Null()) {
+		return nil, errors.New("blind: identity point")
+	}
+	if sub, ok := P.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return nil, errors.New("blind: point not in the prime-order subgroup")
+	}
+	return P, nil
+}
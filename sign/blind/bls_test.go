@@ -0,0 +1,105 @@
+package blind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+func TestBLS(t *testing.T) {
+	msg := []byte("Hello blind BLS")
+	for name, scheme := range map[string]*BLS{
+		"bn254-g1":    NewBLSOnG1(bn254.NewSuite()),
+		"bls12381-g1": NewBLSOnG1(kilic.NewBLS12381Suite()),
+		"bls12381-g2": NewBLSOnG2(kilic.NewBLS12381Suite()),
+	} {
+		private, public := scheme.Scheme().NewKeyPair(random.New())
+		blinding, blinded, err := scheme.Blind(random.New(), msg)
+		require.NoError(t, err, name)
+		blindSig, err := scheme.Sign(private, blinded)
+		require.NoError(t, err, name)
+		sig, err := blinding.Unblind(public, blindSig)
+		require.NoError(t, err, name)
+		require.NoError(t, scheme.Scheme().Verify(public, msg, sig), name)
+
+		// the signature is the BLS signature, and differs from the blind one
+		expected, err := scheme.Scheme().Sign(private, msg)
+		require.NoError(t, err, name)
+		require.Equal(t, expected, sig, name)
+		require.NotEqual(t, blindSig, sig, name)
+
+		// the blind signature of another key is rejected
+		other, _ := scheme.Scheme().NewKeyPair(random.New())
+		blindSig, err = scheme.Sign(other, blinded)
+		require.NoError(t, err, name)
+		_, err = blinding.Unblind(public, blindSig)
+		require.Error(t, err, name)
+
+		_, err = scheme.Sign(private, blinded[1:])
+		require.Error(t, err, name)
+	}
+}
+
+func TestBLSUnlinkable(t *testing.T) {
+	scheme := NewBLSOnG2(kilic.NewBLS12381Suite())
+	msg := []byte("Hello blind BLS")
+	_, blinded1, err := scheme.Blind(random.New(), msg)
+	require.NoError(t, err)
+	_, blinded2, err := scheme.Blind(random.New(), msg)
+	require.NoError(t, err)
+	require.NotEqual(t, blinded1, blinded2)
+}
+
+func TestBLSScheme(t *testing.T) {
+	suite := bn254.NewSuite()
+	scheme := NewBLSOnG1(suite)
+	private, public := scheme.Scheme().NewKeyPair(random.New())
+	blinding, blinded, err := scheme.Blind(random.New(), []byte("Hello"))
+	require.NoError(t, err)
+	blindSig, err := scheme.Sign(private, blinded)
+	require.NoError(t, err)
+	sig, err := blinding.Unblind(public, blindSig)
+	require.NoError(t, err)
+	require.NoError(t, bls.NewSchemeOnG1(suite).Verify(public, []byte("Hello"), sig))
+}
+
+// outsideGroup is a group whose points all claim to be outside of the
+// prime-order subgroup, as crafted points of the groups with a cofactor are.
+type outsideGroup struct {
+	kyber.Group
+}
+
+func (g outsideGroup) Point() kyber.Point {
+	return outsidePoint{g.Group.Point()}
+}
+
+type outsidePoint struct {
+	kyber.Point
+}
+
+func (outsidePoint) IsInCorrectGroup() bool { return false }
+
+// The points outside of the prime-order subgroup are rejected, as the
+// signatures of sign/bls are.
+func TestBLSSubgroup(t *testing.T) {
+	suite := kilic.NewBLS12381Suite()
+	honest := NewBLSOnG1(suite)
+	scheme := &BLS{sigGroup: outsideGroup{suite.G1()}, scheme: honest.Scheme()}
+	private, public := scheme.Scheme().NewKeyPair(random.New())
+
+	blinding, blinded, err := honest.Blind(random.New(), []byte("Hello"))
+	require.NoError(t, err)
+	_, err = scheme.Sign(private, blinded)
+	require.ErrorContains(t, err, "subgroup")
+
+	blindSig, err := honest.Sign(private, blinded)
+	require.NoError(t, err)
+	blinding.b = scheme
+	_, err = blinding.Unblind(public, blindSig)
+	require.ErrorContains(t, err, "subgroup")
+}
@@ -0,0 +1,166 @@
+package blind
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+)
+
+// SchnorrCommitment is the first message of the signer: the commitments to
+// its two nonces.
+type SchnorrCommitment struct {
+	R0, R1 kyber.Point
+}
+
+// SchnorrChallenge is the message of the client: the blinded challenges for
+// both nonces of the signer.
+type SchnorrChallenge struct {
+	C0, C1 kyber.Scalar
+}
+
+// SchnorrResponse is the last message of the signer: the response to the
+// challenge of the nonce it chose.
+type SchnorrResponse struct {
+	Bit int
+	S   kyber.Scalar
+}
+
+// SchnorrSigner is the signer of the Clause-blind Schnorr scheme.
+type SchnorrSigner struct {
+	suite   schnorr.Suite
+	private kyber.Scalar
+}
+
+// NewSchnorrSigner returns the signer with the private key.
+func NewSchnorrSigner(suite schnorr.Suite, private kyber.Scalar) *SchnorrSigner {
+	return &SchnorrSigner{suite: suite, private: private}
+}
+
+// SchnorrSession is a signing session of the signer, which answers a single
+// challenge.
+type SchnorrSession struct {
+	s      *SchnorrSigner
+	r0, r1 kyber.Scalar
+	done   bool
+}
+
+// Commit starts a signing session, and returns its commitment to send to
+// the client.
+func (s *SchnorrSigner) Commit() (*SchnorrSession, *SchnorrCommitment) {
+	rand := s.suite.RandomStream()
+	session := &SchnorrSession{s: s, r0: s.suite.Scalar().Pick(rand), r1: s.suite.Scalar().Pick(rand)}
+	return session, &SchnorrCommitment{
+		R0: s.suite.Point().Mul(session.r0, nil),
+		R1: s.suite.Point().Mul(session.r1, nil),
+	}
+}
+
+// Sign answers the challenge of the client for one of the nonces chosen at
+// random, and ends the session. Answering twice would leak the private key,
+// so the following calls return an error.
+func (ss *SchnorrSession) Sign(challenge *SchnorrChallenge) (*SchnorrResponse, error) {
+	if ss.done {
+		return nil, errors.New("blind: signing session already used")
+	}
+	if challenge.C0 == nil || challenge.C1 == nil {
+		return nil, errors.New("blind: invalid challenge")
+	}
+	ss.done = true
+
+	var bit [1]byte
+	ss.s.suite.RandomStream().XORKeyStream(bit[:], bit[:])
+	b := int(bit[0] & 1)
+	r, c := ss.r0, challenge.C0
+	if b == 1 {
+		r, c = ss.r1, challenge.C1
+	}
+	// s = r + c*x
+	S := ss.s.suite.Scalar().Mul(c, ss.s.private)
+	S.Add(S, r)
+	ss.r0, ss.r1 = nil, nil
+	return &SchnorrResponse{Bit: b, S: S}, nil
+}
+
+// SchnorrBlinding is the state of the client between the challenge and the
+// unblinding of the signature.
+type SchnorrBlinding struct {
+	suite  schnorr.Suite
+	public kyber.Point
+	msg    []byte
+	alpha  [2]kyber.Scalar
+	R      [2]kyber.Point
+}
+
+// BlindSchnorr returns the blinding of the message and the challenge to send
+// to the signer with the public key, in reply to its commitment. Both nonces
+// are blinded as R' = R + alpha*G + beta*X, and the challenges as
+// c = H(R', X, m) + beta, so that the signature can be unblinded whichever
+// nonce the signer chooses.
+func BlindSchnorr(suite schnorr.Suite, public kyber.Point, msg []byte, commitment *SchnorrCommitment) (
+	*SchnorrBlinding, *SchnorrChallenge, error) {
+	rand := suite.RandomStream()
+	bl := &SchnorrBlinding{suite: suite, public: public, msg: msg}
+	var c [2]kyber.Scalar
+	for i, R := range []kyber.Point{commitment.R0, commitment.R1} {
+		if R == nil || R.Equal(suite.Point().Null()) {
+			return nil, nil, errors.New("blind: invalid commitment")
+		}
+		bl.alpha[i] = suite.Scalar().Pick(rand)
+		beta := suite.Scalar().Pick(rand)
+		bl.R[i] = suite.Point().Mul(bl.alpha[i], nil)
+		bl.R[i].Add(bl.R[i], suite.Point().Mul(beta, public))
+		bl.R[i].Add(bl.R[i], R)
+
+		h, err := challenge(suite, public, bl.R[i], msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		c[i] = h.Add(h, beta)
+	}
+	return bl, &SchnorrChallenge{C0: c[0], C1: c[1]}, nil
+}
+
+// Unblind returns the Schnorr signature of the message from the response
+// of the signer, after checking it with schnorr.Verify.
+func (bl *SchnorrBlinding) Unblind(response *SchnorrResponse) ([]byte, error) {
+	if response.Bit != 0 && response.Bit != 1 {
+		return nil, fmt.Errorf("blind: invalid response bit %d", response.Bit)
+	}
+	if response.S == nil {
+		return nil, errors.New("blind: invalid response")
+	}
+	// s' = s + alpha
+	S := bl.suite.Scalar().Add(response.S, bl.alpha[response.Bit])
+	var b bytes.Buffer
+	if _, err := bl.R[response.Bit].MarshalTo(&b); err != nil {
+		return nil, err
+	}
+	if _, err := S.MarshalTo(&b); err != nil {
+		return nil, err
+	}
+	sig := b.Bytes()
+	if err := schnorr.Verify(bl.suite, bl.public, bl.msg, sig); err != nil {
+		return nil, fmt.Errorf("blind: invalid response: %w", err)
+	}
+	return sig, nil
+}
+
+// challenge returns the challenge hash(R || public || msg) of the signatures
+// of the schnorr package.
+func challenge(g kyber.Group, public, R kyber.Point, msg []byte) (kyber.Scalar, error) {
+	h := sha512.New()
+	if _, err := R.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	if _, err := public.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	if _, err := h.Write(msg); err != nil {
+		return nil, err
+	}
+	return g.Scalar().SetBytes(h.Sum(nil)), nil
+}
@@ -0,0 +1,76 @@
+package blind
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign/eddsa"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/key"
+)
+
+func TestSchnorr(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	signer := NewSchnorrSigner(suite, kp.Private)
+
+	// several sessions to get responses for both nonces
+	bits := map[int]bool{}
+	for i := 0; i < 16; i++ {
+		msg := []byte{byte(i)}
+		session, commitment := signer.Commit()
+		blinding, challenge, err := BlindSchnorr(suite, kp.Public, msg, commitment)
+		require.NoError(t, err)
+		response, err := session.Sign(challenge)
+		require.NoError(t, err)
+		bits[response.Bit] = true
+
+		sig, err := blinding.Unblind(response)
+		require.NoError(t, err)
+		require.NoError(t, schnorr.Verify(suite, kp.Public, msg, sig))
+		require.NoError(t, eddsa.Verify(kp.Public, msg, sig))
+
+		// the signature doesn't reveal the nonce of the session
+		R := suite.Point()
+		require.NoError(t, R.UnmarshalBinary(sig[:R.MarshalSize()]))
+		require.False(t, R.Equal(commitment.R0))
+		require.False(t, R.Equal(commitment.R1))
+
+		_, err = session.Sign(challenge)
+		require.Error(t, err)
+	}
+	require.Len(t, bits, 2)
+}
+
+func TestSchnorrErrors(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	other := key.NewKeyPair(suite)
+	msg := []byte("Hello blind Schnorr")
+
+	// a response of another signer is rejected
+	session, commitment := NewSchnorrSigner(suite, other.Private).Commit()
+	blinding, challenge, err := BlindSchnorr(suite, kp.Public, msg, commitment)
+	require.NoError(t, err)
+	response, err := session.Sign(challenge)
+	require.NoError(t, err)
+	_, err = blinding.Unblind(response)
+	require.Error(t, err)
+
+	// as well as a response to the wrong nonce
+	session, commitment = NewSchnorrSigner(suite, kp.Private).Commit()
+	blinding, challenge, err = BlindSchnorr(suite, kp.Public, msg, commitment)
+	require.NoError(t, err)
+	response, err = session.Sign(challenge)
+	require.NoError(t, err)
+	response.Bit = 1 - response.Bit
+	_, err = blinding.Unblind(response)
+	require.Error(t, err)
+	response.Bit = 2
+	_, err = blinding.Unblind(response)
+	require.Error(t, err)
+
+	_, _, err = BlindSchnorr(suite, kp.Public, msg, &SchnorrCommitment{R0: suite.Point().Null(), R1: commitment.R1})
+	require.Error(t, err)
+}
@@ -10,6 +10,10 @@
 // attack and for that reason, the code performing aggregation was removed.
 //
 // See the paper: https://crypto.stanford.edu/~dabo/pubs/papers/BLSmultisig.html
+//
+// Ciphersuite implements the ciphersuites of the IETF draft
+// draft-irtf-cfrg-bls-signature-05, used by Ethereum and drand, whose
+// aggregate signatures are safe against rogue public-key attacks.
 package bls
 
 import (
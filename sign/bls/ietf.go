@@ -0,0 +1,331 @@
+package bls
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+)
+
+// Mode is one of the three schemes of draft-irtf-cfrg-bls-signature-05,
+// which differ in the way they prevent rogue key attacks on aggregate
+// signatures.
+type Mode int
+
+const (
+	// Basic requires the messages of an aggregate signature to be distinct.
+	Basic Mode = iota
+	// MessageAugmentation prefixes every message with the public key of its
+	// signer.
+	MessageAugmentation
+	// ProofOfPossession requires every public key to come with a proof that
+	// its owner knows the secret key, see PopProve and PopVerify.
+	ProofOfPossession
+)
+
+// tag returns the scheme tag of the ciphersuite IDs of the mode.
+func (m Mode) tag() string {
+	switch m {
+	case Basic:
+		return "NUL_"
+	case MessageAugmentation:
+		return "AUG_"
+	case ProofOfPossession:
+		return "POP_"
+	default:
+		return ""
+	}
+}
+
+func (m Mode) String() string {
+	switch m {
+	case Basic:
+		return "Basic"
+	case MessageAugmentation:
+		return "MessageAugmentation"
+	case ProofOfPossession:
+		return "ProofOfPossession"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+var (
+	// ErrInvalidSignature is returned for a malformed or unverified signature.
+	ErrInvalidSignature = errors.New("bls: invalid signature")
+	// ErrInvalidPublicKey is returned by KeyValidate for an invalid public key.
+	ErrInvalidPublicKey = errors.New("bls: invalid public key")
+	// ErrDuplicateMessages is returned by AggregateVerify for repeated messages.
+	ErrDuplicateMessages = errors.New("bls: messages of the aggregate signature are not distinct")
+	// ErrUnsupportedMode is returned by the operations the mode doesn't support.
+	ErrUnsupportedMode = errors.New("bls: operation not supported by the ciphersuite")
+)
+
+// HashablePoint is implemented by the points that the ciphersuites can hash
+// messages to.
+type HashablePoint interface {
+	// Hash2 hashes msg to a point with the domain separation tag dst.
+	Hash2(msg, dst []byte) kyber.Point
+	// HashToCurveID returns the identifier of the hash to curve suite used
+	// by Hash2, e.g. "BLS12381G2_XMD:SHA-256_SSWU_RO_" for BLS12-381.
+	HashToCurveID() string
+}
+
+// Ciphersuite implements a ciphersuite of draft-irtf-cfrg-bls-signature-05.
+// The ciphersuites of BLS12-381 use the standard IDs of the draft, e.g.
+// "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_" for the proof of possession
+// scheme with signatures on G2 used by Ethereum. The IDs of the other
+// pairing suites are built in the same way from the identifier of their
+// hash to curve suite.
+type Ciphersuite struct {
+	suite    pairing.Suite
+	sigGroup kyber.Group
+	keyGroup kyber.Group
+	onG1     bool
+	mode     Mode
+	dst      []byte
+	popDST   []byte
+}
+
+// NewCiphersuiteOnG1 returns the ciphersuite of the mode with signatures on
+// G1 and public keys on G2, i.e. the "minimal-signature-size" variant.
+func NewCiphersuiteOnG1(suite pairing.Suite, mode Mode) (*Ciphersuite, error) {
+	return newCiphersuite(suite, suite.G1(), suite.G2(), true, mode)
+}
+
+// NewCiphersuiteOnG2 returns the ciphersuite of the mode with signatures on
+// G2 and public keys on G1, i.e. the "minimal-pubkey-size" variant.
+func NewCiphersuiteOnG2(suite pairing.Suite, mode Mode) (*Ciphersuite, error) {
+	return newCiphersuite(suite, suite.G2(), suite.G1(), false, mode)
+}
+
+func newCiphersuite(suite pairing.Suite, sigGroup, keyGroup kyber.Group, onG1 bool,
+	mode Mode) (*Ciphersuite, error) {
+	if mode.tag() == "" {
+		return nil, fmt.Errorf("bls: unknown mode %d", int(mode))
+	}
+	hashable, ok := sigGroup.Point().(HashablePoint)
+	if !ok {
+		return nil, fmt.Errorf("bls: the points of %s can't be hashed with a domain separation tag", sigGroup)
+	}
+	id := hashable.HashToCurveID()
+	return &Ciphersuite{
+		suite:    suite,
+		sigGroup: sigGroup,
+		keyGroup: keyGroup,
+		onG1:     onG1,
+		mode:     mode,
+		dst:      []byte("BLS_SIG_" + id + mode.tag()),
+		popDST:   []byte("BLS_POP_" + id + "POP_"),
+	}, nil
+}
+
+// ID returns the ciphersuite ID, which is the domain separation tag of the
+// hashes of the messages.
+func (c *Ciphersuite) ID() string {
+	return string(c.dst)
+}
+
+// Mode returns the mode of the ciphersuite.
+func (c *Ciphersuite) Mode() Mode {
+	return c.mode
+}
+
+// NewKeyPair returns a new random secret key and its public key.
+func (c *Ciphersuite) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	secret := c.keyGroup.Scalar().Pick(random)
+	public := c.keyGroup.Point().Mul(secret, nil)
+	return secret, public
+}
+
+// KeyValidate returns an error if the public key is the identity or isn't
+// in the prime-order subgroup.
+func (c *Ciphersuite) KeyValidate(public kyber.Point) error {
+	if public.Equal(c.keyGroup.Point().Null()) {
+		return fmt.Errorf("%w: identity", ErrInvalidPublicKey)
+	}
+	if sub, ok := public.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return fmt.Errorf("%w: not in the prime-order subgroup", ErrInvalidPublicKey)
+	}
+	return nil
+}
+
+// Sign returns the signature of msg by the secret key.
+func (c *Ciphersuite) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	msg, err := c.augment(c.keyGroup.Point().Mul(private, nil), msg)
+	if err != nil {
+		return nil, err
+	}
+	return c.coreSign(private, msg, c.dst)
+}
+
+// Verify returns nil if sig is a valid signature of msg by the public key.
+func (c *Ciphersuite) Verify(public kyber.Point, msg, sig []byte) error {
+	msg, err := c.augment(public, msg)
+	if err != nil {
+		return err
+	}
+	return c.coreVerify(public, msg, sig, c.dst)
+}
+
+// AggregateSignatures returns the aggregate of the signatures, which can be
+// verified with AggregateVerify or, in the proof of possession scheme,
+// with FastAggregateVerify.
+func (c *Ciphersuite) AggregateSignatures(sigs ...[]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls: no signatures to aggregate")
+	}
+	aggregate := c.sigGroup.Point().Null()
+	for i, sig := range sigs {
+		S, err := c.decodeSignature(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		aggregate.Add(aggregate, S)
+	}
+	return aggregate.MarshalBinary()
+}
+
+// AggregateVerify returns nil if sig is the aggregate of the signatures of
+// msgs[i] by publics[i]. The messages must be distinct in the basic scheme.
+// All the pairings are checked with a single multi-pairing.
+func (c *Ciphersuite) AggregateVerify(publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	if len(publics) != len(msgs) {
+		return errors.New("bls: mismatching numbers of public keys and messages")
+	}
+	if len(publics) == 0 {
+		return errors.New("bls: no public keys")
+	}
+	if c.mode == Basic {
+		seen := make(map[string]bool, len(msgs))
+		for _, msg := range msgs {
+			if seen[string(msg)] {
+				return ErrDuplicateMessages
+			}
+			seen[string(msg)] = true
+		}
+	}
+	hashes := make([]kyber.Point, len(msgs))
+	for i, public := range publics {
+		if err := c.KeyValidate(public); err != nil {
+			return fmt.Errorf("public key %d: %w", i, err)
+		}
+		msg, err := c.augment(public, msgs[i])
+		if err != nil {
+			return err
+		}
+		hashes[i] = c.hash(msg, c.dst)
+	}
+	return c.check(publics, hashes, sig)
+}
+
+// FastAggregateVerify returns nil if sig is the aggregate of the signatures
+// of msg by all the public keys. It is only available in the proof of
+// possession scheme, as the proofs of the keys must have been checked with
+// PopVerify beforehand.
+func (c *Ciphersuite) FastAggregateVerify(publics []kyber.Point, msg, sig []byte) error {
+	if c.mode != ProofOfPossession {
+		return ErrUnsupportedMode
+	}
+	if len(publics) == 0 {
+		return errors.New("bls: no public keys")
+	}
+	aggregate := c.keyGroup.Point().Null()
+	for _, public := range publics {
+		aggregate.Add(aggregate, public)
+	}
+	return c.coreVerify(aggregate, msg, sig, c.dst)
+}
+
+// PopProve returns a proof of possession of the secret key, in the proof
+// of possession scheme.
+func (c *Ciphersuite) PopProve(private kyber.Scalar) ([]byte, error) {
+	if c.mode != ProofOfPossession {
+		return nil, ErrUnsupportedMode
+	}
+	pub, err := c.keyGroup.Point().Mul(private, nil).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return c.coreSign(private, pub, c.popDST)
+}
+
+// PopVerify returns nil if proof is a valid proof of possession of the
+// secret key of the public key, in the proof of possession scheme.
+func (c *Ciphersuite) PopVerify(public kyber.Point, proof []byte) error {
+	if c.mode != ProofOfPossession {
+		return ErrUnsupportedMode
+	}
+	pub, err := public.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return c.coreVerify(public, pub, proof, c.popDST)
+}
+
+// augment prefixes msg with the public key in the message augmentation
+// scheme.
+func (c *Ciphersuite) augment(public kyber.Point, msg []byte) ([]byte, error) {
+	if c.mode != MessageAugmentation {
+		return msg, nil
+	}
+	pub, err := public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(pub, msg...), nil
+}
+
+func (c *Ciphersuite) hash(msg, dst []byte) kyber.Point {
+	return c.sigGroup.Point().(HashablePoint).Hash2(msg, dst)
+}
+
+func (c *Ciphersuite) coreSign(private kyber.Scalar, msg, dst []byte) ([]byte, error) {
+	H := c.hash(msg, dst)
+	return H.Mul(private, H).MarshalBinary()
+}
+
+func (c *Ciphersuite) coreVerify(public kyber.Point, msg, sig, dst []byte) error {
+	if err := c.KeyValidate(public); err != nil {
+		return err
+	}
+	return c.check([]kyber.Point{public}, []kyber.Point{c.hash(msg, dst)}, sig)
+}
+
+// decodeSignature returns the point of the signature, checking that it is
+// in the prime-order subgroup.
+func (c *Ciphersuite) decodeSignature(sig []byte) (kyber.Point, error) {
+	S := c.sigGroup.Point()
+	if err := S.UnmarshalBinary(sig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if sub, ok := S.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return nil, fmt.Errorf("%w: not in the prime-order subgroup", ErrInvalidSignature)
+	}
+	return S, nil
+}
+
+// check verifies that e(-S, B) * e(H_1, P_1) * ... * e(H_n, P_n) == 1 for
+// signatures on G1, and e(-B, S) * e(P_1, H_1) * ... * e(P_n, H_n) == 1 for
+// signatures on G2, negating the point of G1 in both cases.
+func (c *Ciphersuite) check(publics, hashes []kyber.Point, sig []byte) error {
+	S, err := c.decodeSignature(sig)
+	if err != nil {
+		return err
+	}
+	B := c.keyGroup.Point().Base()
+	var p1s, p2s []kyber.Point
+	if c.onG1 {
+		p1s = append([]kyber.Point{S.Neg(S)}, hashes...)
+		p2s = append([]kyber.Point{B}, publics...)
+	} else {
+		p1s = append([]kyber.Point{B.Neg(B)}, publics...)
+		p2s = append([]kyber.Point{S}, hashes...)
+	}
+	if !pairing.ValidatePairings(c.suite, p1s, p2s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package bls
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
+	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/pairing/bn256"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var _ sign.Scheme = (*Ciphersuite)(nil)
+
+// ciphersuites returns the ciphersuites of every pairing suite in the mode.
+func ciphersuites(t *testing.T, mode Mode) map[string]*Ciphersuite {
+	cs := make(map[string]*Ciphersuite)
+	suites := map[string]pairing.Suite{
+		"circl": circl.NewSuite(),
+		"kilic": kilic.NewBLS12381Suite(),
+		"bn254": bn254.NewSuite(),
+		"bn256": bn256.NewSuite(),
+	}
+	for name, suite := range suites {
+		c, err := NewCiphersuiteOnG1(suite, mode)
+		require.NoError(t, err)
+		cs[name+"/G1"] = c
+		c, err = NewCiphersuiteOnG2(suite, mode)
+		if name == "bn254" || name == "bn256" {
+			// there is no hash to G2 for the BN curves
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		cs[name+"/G2"] = c
+	}
+	return cs
+}
+
+func TestCiphersuiteIDs(t *testing.T) {
+	for _, suite := range []pairing.Suite{circl.NewSuite(), kilic.NewBLS12381Suite()} {
+		for mode, tag := range map[Mode]string{Basic: "NUL_", MessageAugmentation: "AUG_", ProofOfPossession: "POP_"} {
+			c, err := NewCiphersuiteOnG1(suite, mode)
+			require.NoError(t, err)
+			require.Equal(t, "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_"+tag, c.ID())
+			c, err = NewCiphersuiteOnG2(suite, mode)
+			require.NoError(t, err)
+			require.Equal(t, "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_"+tag, c.ID())
+			require.Equal(t, mode, c.Mode())
+		}
+	}
+	_, err := NewCiphersuiteOnG1(circl.NewSuite(), Mode(3))
+	require.Error(t, err)
+}
+
+// The basic ciphersuites of BLS12-381 use the same hashes as Scheme, and
+// both implementations of the curve give the same signatures.
+func TestCiphersuiteBasicInterop(t *testing.T) {
+	msg := []byte("Hello IETF")
+	secret := kilic.NewBLS12381Suite().G1().Scalar().Pick(random.New())
+	buf, err := secret.MarshalBinary()
+	require.NoError(t, err)
+
+	for _, onG1 := range []bool{true, false} {
+		var sigs [][]byte
+		for _, suite := range []pairing.Suite{circl.NewSuite(), kilic.NewBLS12381Suite()} {
+			private := suite.G1().Scalar()
+			require.NoError(t, private.UnmarshalBinary(buf))
+			scheme, newCiphersuite := NewSchemeOnG2(suite), NewCiphersuiteOnG2
+			if onG1 {
+				scheme, newCiphersuite = NewSchemeOnG1(suite), NewCiphersuiteOnG1
+			}
+			c, err := newCiphersuite(suite, Basic)
+			require.NoError(t, err)
+
+			sig, err := c.Sign(private, msg)
+			require.NoError(t, err)
+			expected, err := scheme.Sign(private, msg)
+			require.NoError(t, err)
+			require.Equal(t, expected, sig)
+			sigs = append(sigs, sig)
+		}
+		require.Equal(t, sigs[0], sigs[1])
+	}
+}
+
+// Test vector of the Ethereum consensus specifications, which use the
+// proof of possession ciphersuite with signatures on G2.
+func TestCiphersuiteEthereum(t *testing.T) {
+	decode := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+	suite := circl.NewSuite()
+	c, err := NewCiphersuiteOnG2(suite, ProofOfPossession)
+	require.NoError(t, err)
+
+	private := suite.G1().Scalar()
+	require.NoError(t, private.UnmarshalBinary(decode("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")))
+	sig, err := c.Sign(private, make([]byte, 32))
+	require.NoError(t, err)
+	require.Equal(t, "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb5158"+
+		"090352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+		hex.EncodeToString(sig))
+}
+
+func TestCiphersuiteSignVerify(t *testing.T) {
+	for _, mode := range []Mode{Basic, MessageAugmentation, ProofOfPossession} {
+		for name, c := range ciphersuites(t, mode) {
+			name = fmt.Sprintf("%s/%s", name, mode)
+			msg := []byte("Hello IETF")
+			private, public := c.NewKeyPair(random.New())
+			sig, err := c.Sign(private, msg)
+			require.NoError(t, err, name)
+			require.NoError(t, c.Verify(public, msg, sig), name)
+			require.ErrorIs(t, c.Verify(public, []byte("Hello"), sig), ErrInvalidSignature, name)
+
+			_, other := c.NewKeyPair(random.New())
+			require.ErrorIs(t, c.Verify(other, msg, sig), ErrInvalidSignature, name)
+			require.ErrorIs(t, c.Verify(c.keyGroup.Point().Null(), msg, sig), ErrInvalidPublicKey, name)
+			require.ErrorIs(t, c.Verify(public, msg, sig[1:]), ErrInvalidSignature, name)
+		}
+	}
+}
+
+// The three modes give different signatures.
+func TestCiphersuiteDomainSeparation(t *testing.T) {
+	suite := circl.NewSuite()
+	msg := []byte("Hello IETF")
+	var sigs []string
+	var private kyber.Scalar
+	for _, mode := range []Mode{Basic, MessageAugmentation, ProofOfPossession} {
+		c, err := NewCiphersuiteOnG2(suite, mode)
+		require.NoError(t, err)
+		if private == nil {
+			private, _ = c.NewKeyPair(random.New())
+		}
+		sig, err := c.Sign(private, msg)
+		require.NoError(t, err)
+		require.NotContains(t, sigs, string(sig))
+		sigs = append(sigs, string(sig))
+
+		if mode == ProofOfPossession {
+			// the proof of possession isn't a signature of the public key
+			pub, err := c.keyGroup.Point().Mul(private, nil).MarshalBinary()
+			require.NoError(t, err)
+			proof, err := c.PopProve(private)
+			require.NoError(t, err)
+			sig, err := c.Sign(private, pub)
+			require.NoError(t, err)
+			require.NotEqual(t, proof, sig)
+		}
+	}
+}
+
+func TestCiphersuiteAggregateVerify(t *testing.T) {
+	for _, mode := range []Mode{Basic, MessageAugmentation, ProofOfPossession} {
+		for name, c := range ciphersuites(t, mode) {
+			name = fmt.Sprintf("%s/%s", name, mode)
+			n := 5
+			publics := make([]kyber.Point, n)
+			msgs := make([][]byte, n)
+			sigs := make([][]byte, n)
+			for i := range sigs {
+				var private kyber.Scalar
+				private, publics[i] = c.NewKeyPair(random.New())
+				msgs[i] = []byte(fmt.Sprintf("message %d", i))
+				sig, err := c.Sign(private, msgs[i])
+				require.NoError(t, err, name)
+				sigs[i] = sig
+			}
+			aggregate, err := c.AggregateSignatures(sigs...)
+			require.NoError(t, err, name)
+			require.NoError(t, c.AggregateVerify(publics, msgs, aggregate), name)
+
+			msgs[0], msgs[1] = msgs[1], msgs[0]
+			require.ErrorIs(t, c.AggregateVerify(publics, msgs, aggregate), ErrInvalidSignature, name)
+			require.Error(t, c.AggregateVerify(publics[1:], msgs, aggregate), name)
+			require.Error(t, c.AggregateVerify(nil, nil, aggregate), name)
+
+			// The basic scheme rejects repeated messages.
+			msgs[0] = msgs[1]
+			err = c.AggregateVerify(publics, msgs, aggregate)
+			if mode == Basic {
+				require.ErrorIs(t, err, ErrDuplicateMessages, name)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidSignature, name)
+			}
+		}
+	}
+}
+
+// The message augmentation scheme accepts aggregate signatures of the same
+// message by several keys.
+func TestCiphersuiteAugmentationSameMessage(t *testing.T) {
+	for name, c := range ciphersuites(t, MessageAugmentation) {
+		msg := []byte("same message")
+		var publics []kyber.Point
+		var msgs, sigs [][]byte
+		for i := 0; i < 3; i++ {
+			private, public := c.NewKeyPair(random.New())
+			sig, err := c.Sign(private, msg)
+			require.NoError(t, err, name)
+			publics, msgs, sigs = append(publics, public), append(msgs, msg), append(sigs, sig)
+		}
+		aggregate, err := c.AggregateSignatures(sigs...)
+		require.NoError(t, err, name)
+		require.NoError(t, c.AggregateVerify(publics, msgs, aggregate), name)
+	}
+}
+
+func TestCiphersuiteProofOfPossession(t *testing.T) {
+	for name, c := range ciphersuites(t, ProofOfPossession) {
+		msg := []byte("same message")
+		n := 4
+		publics := make([]kyber.Point, n)
+		sigs := make([][]byte, n)
+		for i := range publics {
+			var private kyber.Scalar
+			private, publics[i] = c.NewKeyPair(random.New())
+			proof, err := c.PopProve(private)
+			require.NoError(t, err, name)
+			require.NoError(t, c.PopVerify(publics[i], proof), name)
+			if i > 0 {
+				require.ErrorIs(t, c.PopVerify(publics[i-1], proof), ErrInvalidSignature, name)
+			}
+			sigs[i], err = c.Sign(private, msg)
+			require.NoError(t, err, name)
+		}
+
+		aggregate, err := c.AggregateSignatures(sigs...)
+		require.NoError(t, err, name)
+		require.NoError(t, c.FastAggregateVerify(publics, msg, aggregate), name)
+		require.ErrorIs(t, c.FastAggregateVerify(publics[1:], msg, aggregate), ErrInvalidSignature, name)
+		require.ErrorIs(t, c.FastAggregateVerify(publics, []byte("other"), aggregate), ErrInvalidSignature, name)
+		require.Error(t, c.FastAggregateVerify(nil, msg, aggregate), name)
+	}
+
+	// The other modes don't have proofs of possession.
+	for name, c := range ciphersuites(t, Basic) {
+		private, public := c.NewKeyPair(random.New())
+		_, err := c.PopProve(private)
+		require.ErrorIs(t, err, ErrUnsupportedMode, name)
+		require.ErrorIs(t, c.PopVerify(public, nil), ErrUnsupportedMode, name)
+		require.ErrorIs(t, c.FastAggregateVerify([]kyber.Point{public}, nil, nil), ErrUnsupportedMode, name)
+	}
+}
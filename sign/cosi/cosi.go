@@ -50,6 +50,7 @@ import (
 	"fmt"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/sign"
 )
 
 // Commit returns a random scalar v, generated from the given suite,
@@ -159,7 +160,7 @@ func Sign(suite Suite, commitment kyber.Point, response kyber.Scalar, mask *Mask
 	sig := make([]byte, lenSig+mask.Len())
 	copy(sig, VB)
 	copy(sig[lenV:lenSig], RB)
-	copy(sig[lenSig:], mask.mask)
+	copy(sig[lenSig:], mask.bits.Bytes())
 	return sig, nil
 }
 
@@ -240,16 +241,14 @@ func Verify(suite Suite, publics []kyber.Point, message, sig []byte, policy Poli
 
 // ParticipationMask is an interface to get the total number of candidates
 // and the number of participants.
-type ParticipationMask interface {
-	// CountEnabled returns the number of participants
-	CountEnabled() int
-	// CountTotal returns the number of candidates
-	CountTotal() int
-}
+//
+// Deprecated: the interface has moved to the package kyber/sign
+type ParticipationMask = sign.ParticipationMask
 
-// Mask represents a cosigning participation bitmask.
+// Mask represents a cosigning participation bitmask along with the
+// aggregate public key of the participants.
 type Mask struct {
-	mask            []byte
+	bits            *sign.Mask
 	publics         []kyber.Point
 	AggregatePublic kyber.Point
 }
@@ -260,9 +259,9 @@ type Mask struct {
 // bitmask to 1 (enabled).
 func NewMask(suite Suite, publics []kyber.Point, myKey kyber.Point) (*Mask, error) {
 	m := &Mask{
+		bits:    sign.NewMask(len(publics)),
 		publics: publics,
 	}
-	m.mask = make([]byte, m.Len())
 	m.AggregatePublic = suite.Point().Null()
 	if myKey != nil {
 		found := false
@@ -285,65 +284,71 @@ func NewMask(suite Suite, publics []kyber.Point, myKey kyber.Point) (*Mask, erro
 
 // Mask returns a copy of the participation bitmask.
 func (m *Mask) Mask() []byte {
-	clone := make([]byte, len(m.mask))
-	copy(clone, m.mask)
-	return clone
+	return m.bits.Bytes()
+}
+
+// Participation returns a copy of the participation mask.
+func (m *Mask) Participation() *sign.Mask {
+	return m.bits.Clone()
 }
 
 // Len returns the mask length in bytes.
 func (m *Mask) Len() int {
-	return (len(m.publics) + 7) >> 3
+	return m.bits.Len()
 }
 
 // SetMask sets the participation bitmask according to the given byte slice
 // interpreted in little-endian order, i.e., bits 0-7 of byte 0 correspond to
 // cosigners 0-7, bits 0-7 of byte 1 correspond to cosigners 8-15, etc.
 func (m *Mask) SetMask(mask []byte) error {
-	if m.Len() != len(mask) {
+	bits, err := sign.NewMaskFromBytes(len(m.publics), mask)
+	if err != nil {
 		return fmt.Errorf("mismatching mask lengths")
 	}
-	for i := range m.publics {
-		byt := i >> 3
-		msk := byte(1) << uint(i&7)
-		if ((m.mask[byt] & msk) == 0) && ((mask[byt] & msk) != 0) {
-			m.mask[byt] ^= msk // flip bit in mask from 0 to 1
-			m.AggregatePublic.Add(m.AggregatePublic, m.publics[i])
-		}
-		if ((m.mask[byt] & msk) != 0) && ((mask[byt] & msk) == 0) {
-			m.mask[byt] ^= msk // flip bit in mask from 1 to 0
-			m.AggregatePublic.Sub(m.AggregatePublic, m.publics[i])
-		}
+	return m.SetParticipation(bits)
+}
+
+// SetParticipation sets the participation mask, which must have as many
+// candidates as there are public keys.
+func (m *Mask) SetParticipation(bits *sign.Mask) error {
+	added, removed := bits.Clone(), m.bits.Clone()
+	if err := added.Difference(m.bits); err != nil {
+		return err
 	}
+	if err := removed.Difference(bits); err != nil {
+		return err
+	}
+	added.ForEachEnabled(func(i int) bool {
+		m.AggregatePublic.Add(m.AggregatePublic, m.publics[i])
+		return true
+	})
+	removed.ForEachEnabled(func(i int) bool {
+		m.AggregatePublic.Sub(m.AggregatePublic, m.publics[i])
+		return true
+	})
+	m.bits = bits.Clone()
 	return nil
 }
 
 // SetBit enables (enable: true) or disables (enable: false) the bit
 // in the participation mask of the given cosigner.
 func (m *Mask) SetBit(i int, enable bool) error {
-	if i >= len(m.publics) {
-		return errors.New("index out of range")
+	enabled, err := m.bits.IndexEnabled(i)
+	if err != nil {
+		return err
 	}
-	byt := i >> 3
-	msk := byte(1) << uint(i&7)
-	if ((m.mask[byt] & msk) == 0) && enable {
-		m.mask[byt] ^= msk // flip bit in mask from 0 to 1
+	if !enabled && enable {
 		m.AggregatePublic.Add(m.AggregatePublic, m.publics[i])
 	}
-	if ((m.mask[byt] & msk) != 0) && !enable {
-		m.mask[byt] ^= msk // flip bit in mask from 1 to 0
+	if enabled && !enable {
 		m.AggregatePublic.Sub(m.AggregatePublic, m.publics[i])
 	}
-	return nil
+	return m.bits.SetBit(i, enable)
 }
 
 // IndexEnabled checks whether the given index is enabled in the mask or not.
 func (m *Mask) IndexEnabled(i int) (bool, error) {
-	if i >= len(m.publics) {
-		return false, errors.New("index out of range")
-	}
-	byt := i >> 3
-	msk := byte(1) << uint(i&7)
-	return ((m.mask[byt] & msk) != 0), nil
+	return m.bits.IndexEnabled(i)
 }
 
 // KeyEnabled checks whether the index, corresponding to the given key, is
@@ -360,21 +365,12 @@ func (m *Mask) KeyEnabled(public kyber.Point) (bool, error) {
 // CountEnabled returns the number of enabled nodes in the CoSi participation
 // mask.
 func (m *Mask) CountEnabled() int {
-	// hw is hamming weight
-	hw := 0
-	for i := range m.publics {
-		byt := i >> 3
-		msk := byte(1) << uint(i&7)
-		if (m.mask[byt] & msk) != 0 {
-			hw++
-		}
-	}
-	return hw
+	return m.bits.CountEnabled()
 }
 
 // CountTotal returns the total number of nodes this CoSi instance knows.
 func (m *Mask) CountTotal() int {
-	return len(m.publics)
+	return m.bits.CountTotal()
 }
 
 // AggregateMasks computes the bitwise OR of the two given participation masks.
@@ -391,48 +387,27 @@ func AggregateMasks(a, b []byte) ([]byte, error) {
 
 // Policy represents a fully customizable cosigning policy deciding what
 // cosigner sets are and aren't sufficient for a collective signature to be
-// considered acceptable to a verifier. The Check method may inspect the set of
-// participants that cosigned by invoking cosi.Mask and/or cosi.MaskBit, and may
-// use any other relevant contextual information (e.g., how security-critical
-// the operation relying on the collective signature is) in determining whether
-// the collective signature was produced by an acceptable set of cosigners.
+// considered acceptable to a verifier.
 //
 // Deprecated: the policies have moved to the package kyber/sign
-type Policy interface {
-	Check(m ParticipationMask) bool
-}
+type Policy = sign.Policy
 
 // CompletePolicy is the default policy requiring that all participants have
 // cosigned to make a collective signature valid.
 //
 // Deprecated: the policy has moved to the package kyber/sign
-type CompletePolicy struct {
-}
-
-// Check verifies that all participants have contributed to a collective
-// signature.
-func (p CompletePolicy) Check(m ParticipationMask) bool {
-	return m.CountEnabled() == m.CountTotal()
-}
+type CompletePolicy = sign.CompletePolicy
 
 // ThresholdPolicy allows to specify a simple t-of-n policy requring that at
 // least the given threshold number of participants t have cosigned to make a
 // collective signature valid.
 //
 // Deprecated: the policy has moved to the package kyber/sign
-type ThresholdPolicy struct {
-	thold int
-}
+type ThresholdPolicy = sign.ThresholdPolicy
 
 // NewThresholdPolicy returns a new ThresholdPolicy with the given threshold.
 //
 // Deprecated: the policy has moved to the package kyber/sign
 func NewThresholdPolicy(thold int) *ThresholdPolicy {
-	return &ThresholdPolicy{thold: thold}
-}
-
-// Check verifies that at least a threshold number of participants have
-// contributed to a collective signature.
-func (p ThresholdPolicy) Check(m ParticipationMask) bool {
-	return m.CountEnabled() >= p.thold
+	return sign.NewThresholdPolicy(thold)
 }
@@ -9,6 +9,7 @@ import (
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/sign/eddsa"
 	"go.dedis.ch/kyber/v4/util/key"
 	"go.dedis.ch/kyber/v4/xof/blake2xb"
@@ -210,3 +211,80 @@ func TestMask(t *testing.T) {
 		}
 	}
 }
+
+func TestMaskParticipation(t *testing.T) {
+	n := 5
+	var publics []kyber.Point
+	for i := 0; i < n; i++ {
+		publics = append(publics, key.NewKeyPair(testSuite).Public)
+	}
+	m, err := NewMask(testSuite, publics, publics[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The aggregate public key follows the participants.
+	bits := sign.NewMask(n)
+	for _, i := range []int{1, 3, 4} {
+		if err := bits.SetBit(i, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.SetParticipation(bits); err != nil {
+		t.Fatal(err)
+	}
+	expected := testSuite.Point().Add(publics[1], publics[3])
+	expected.Add(expected, publics[4])
+	if !m.AggregatePublic.Equal(expected) {
+		t.Fatal(errors.New("wrong aggregate public key"))
+	}
+	if !m.Participation().Equal(bits) {
+		t.Fatal(errors.New("wrong participation mask"))
+	}
+
+	if err := m.SetParticipation(sign.NewMask(n + 1)); err == nil {
+		t.Fatal(errors.New("mask of another size accepted"))
+	}
+}
+
+// maskPolicy records the mask given to its Check method.
+type maskPolicy struct {
+	mask sign.ParticipationMask
+}
+
+func (p *maskPolicy) Check(m sign.ParticipationMask) bool {
+	p.mask = m
+	return true
+}
+
+// The policies are given the *Mask of cosi, as they always have been.
+func TestPolicyMask(t *testing.T) {
+	kp := key.NewKeyPair(testSuite)
+	publics := []kyber.Point{kp.Public}
+	message := []byte("Hello World Cosi")
+	mask, err := NewMask(testSuite, publics, kp.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, V := Commit(testSuite)
+	c, err := Challenge(testSuite, V, mask.AggregatePublic, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Response(testSuite, kp.Private, v, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := Sign(testSuite, V, r, mask)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &maskPolicy{}
+	if err := Verify(testSuite, publics, message, sig, policy); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := policy.mask.(*Mask); !ok {
+		t.Fatalf("policy checked a %T instead of a *Mask", policy.mask)
+	}
+}
@@ -0,0 +1,460 @@
+/*
+Package ecdsa implements the Elliptic Curve Digital Signature Algorithm over
+the short Weierstrass curves of the p256 and s256 groups, with the
+deterministic nonces of RFC 6979.
+
+Signatures are always normalized to a low S, i.e. S <= N/2, and signatures
+with a high S are rejected, which prevents their malleability as required by
+Bitcoin and Ethereum. They can be encoded in ASN.1 DER, or in the compact
+form R || S || V used by Ethereum, where V is the recovery identifier from
+which RecoverPublicKey computes the public key of a signature.
+*/
+package ecdsa
+
+import (
+	"crypto/cipher"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
+	"go.dedis.ch/kyber/v4/sign"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+	"golang.org/x/crypto/sha3"
+)
+
+// Suite represents the set of functionalities needed by the package ecdsa,
+// as provided by the suites of the p256 and s256 packages: a group of points
+// of a short Weierstrass curve with an uncompressed encoding, and the hash
+// function used for the messages and the nonces.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	Params() *elliptic.CurveParams
+}
+
+var (
+	// ErrInvalidSignature is returned when a signature doesn't verify.
+	ErrInvalidSignature = errors.New("ecdsa: invalid signature")
+	// ErrHighS is returned for a signature whose S is higher than N/2,
+	// which is valid for the ECDSA standard but rejected by this package.
+	ErrHighS = errors.New("ecdsa: signature with high S")
+	// ErrMalformedSignature is returned for an encoding which is not a
+	// well-formed signature.
+	ErrMalformedSignature = errors.New("ecdsa: malformed signature")
+)
+
+// Signature is an ECDSA signature (R, S). V is its recovery identifier,
+// whose low bit is the parity of the y-coordinate of the commitment and
+// whose second bit tells whether its x-coordinate was reduced modulo N.
+type Signature struct {
+	R, S kyber.Scalar
+	V    byte
+}
+
+// Scheme implements sign.Scheme with ECDSA signatures of the messages hashed
+// with the hash function of the suite, encoded in ASN.1 DER.
+type Scheme struct {
+	s Suite
+}
+
+// NewScheme returns the ECDSA signature scheme of the suite s.
+func NewScheme(s Suite) sign.Scheme {
+	return &Scheme{s}
+}
+
+// NewKeyPair returns a fresh secret key and its public point.
+func (s *Scheme) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	priv := s.s.Scalar().Pick(random)
+	for priv.Equal(s.s.Scalar().Zero()) {
+		priv.Pick(random)
+	}
+	pub := s.s.Point().Mul(priv, nil)
+	return priv, pub
+}
+
+// Sign returns the DER encoding of the signature of msg by private.
+func (s *Scheme) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	sig, err := Sign(s.s, private, msg)
+	if err != nil {
+		return nil, err
+	}
+	return sig.MarshalDER()
+}
+
+// Verify checks the DER encoded signature sig of msg by public.
+func (s *Scheme) Verify(public kyber.Point, msg, sig []byte) error {
+	signature, err := ParseDER(s.s, sig)
+	if err != nil {
+		return err
+	}
+	return Verify(s.s, public, msg, signature)
+}
+
+// Sign returns the signature of msg, hashed with the hash function of the
+// suite, by the secret key private.
+func Sign(s Suite, private kyber.Scalar, msg []byte) (*Signature, error) {
+	return SignDigest(s, private, digest(s, msg))
+}
+
+// SignDigest returns the signature of the message digest by the secret key
+// private. The nonce is derived from the secret key and the digest with
+// HMAC over the hash function of the suite, as specified by RFC 6979.
+func SignDigest(s Suite, private kyber.Scalar, digest []byte) (*Signature, error) {
+	c := newCurve(s)
+	x := c.toInt(private)
+	if x.Sign() == 0 {
+		return nil, errors.New("ecdsa: zero secret key")
+	}
+	e := c.toScalar(c.bits2int(digest))
+
+	next := c.nonces(x, digest)
+	for {
+		k := c.toScalar(next())
+
+		// r = x(k*G) mod N
+		Rx, Ry := c.coordinates(s.Point().Mul(k, nil))
+		r := c.toScalar(Rx)
+		if r.Equal(s.Scalar().Zero()) {
+			continue
+		}
+
+		// s = (e + r*x) / k
+		sc := s.Scalar().Mul(r, private)
+		sc.Add(e, sc).Div(sc, k)
+		if sc.Equal(s.Scalar().Zero()) {
+			continue
+		}
+
+		v := byte(Ry.Bit(0))
+		if Rx.Cmp(c.n) >= 0 {
+			v |= 2
+		}
+		if c.isHigh(sc) {
+			sc.Neg(sc)
+			v ^= 1
+		}
+		return &Signature{R: r, S: sc, V: v}, nil
+	}
+}
+
+// Verify returns nil if sig is a valid signature of msg, hashed with the
+// hash function of the suite, by public, or an error otherwise.
+func Verify(s Suite, public kyber.Point, msg []byte, sig *Signature) error {
+	return VerifyDigest(s, public, digest(s, msg), sig)
+}
+
+// VerifyDigest returns nil if sig is a valid signature of the message
+// digest by public, or an error otherwise. Signatures with a high S are
+// rejected with ErrHighS.
+func VerifyDigest(s Suite, public kyber.Point, digest []byte, sig *Signature) error {
+	c := newCurve(s)
+	zero := s.Scalar().Zero()
+	if sig.R.Equal(zero) || sig.S.Equal(zero) {
+		return ErrInvalidSignature
+	}
+	if c.isHigh(sig.S) {
+		return ErrHighS
+	}
+	if public.Equal(s.Point().Null()) {
+		return errors.New("ecdsa: invalid public key")
+	}
+
+	// X = (e/s)*G + (r/s)*Q
+	e := c.toScalar(c.bits2int(digest))
+	w := s.Scalar().Inv(sig.S)
+	u1 := s.Scalar().Mul(e, w)
+	u2 := s.Scalar().Mul(sig.R, w)
+	X := msm.MultiScalarMul(s, []kyber.Scalar{u1, u2}, []kyber.Point{nil, public})
+	if X.Equal(s.Point().Null()) {
+		return ErrInvalidSignature
+	}
+	Xx, _ := c.coordinates(X)
+	if !c.toScalar(Xx).Equal(sig.R) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// RecoverPublicKey returns the public key whose signature of the message
+// digest is sig, using its recovery identifier.
+func RecoverPublicKey(s Suite, digest []byte, sig *Signature) (kyber.Point, error) {
+	c := newCurve(s)
+	zero := s.Scalar().Zero()
+	if sig.R.Equal(zero) || sig.S.Equal(zero) || sig.V > 3 {
+		return nil, ErrInvalidSignature
+	}
+	if c.isHigh(sig.S) {
+		return nil, ErrHighS
+	}
+
+	// R is the point of x-coordinate r (+ N) and of parity v.
+	x := c.toInt(sig.R)
+	if sig.V&2 != 0 {
+		x.Add(x, c.n)
+	}
+	R, err := c.liftX(x, uint(sig.V&1))
+	if err != nil {
+		return nil, err
+	}
+
+	// Q = (s*R - e*G) / r
+	e := c.toScalar(c.bits2int(digest))
+	rInv := s.Scalar().Inv(sig.R)
+	u1 := s.Scalar().Mul(e, rInv)
+	u1.Neg(u1)
+	u2 := s.Scalar().Mul(sig.S, rInv)
+	Q := msm.MultiScalarMul(s, []kyber.Scalar{u1, u2}, []kyber.Point{nil, R})
+	if Q.Equal(s.Point().Null()) {
+		return nil, ErrInvalidSignature
+	}
+	return Q, nil
+}
+
+// EthereumAddress returns the 20 bytes Ethereum address of a secp256k1
+// public key, i.e. the last 20 bytes of the Keccak-256 digest of its
+// uncompressed encoding without its prefix.
+func EthereumAddress(public kyber.Point) ([]byte, error) {
+	buf, err := public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) != 65 || buf[0] != 4 {
+		return nil, errors.New("ecdsa: not an uncompressed secp256k1 point")
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write(buf[1:])
+	return h.Sum(nil)[12:], nil
+}
+
+// MarshalDER returns the ASN.1 DER encoding of the signature, as the
+// SEQUENCE of the INTEGERs R and S.
+func (sig *Signature) MarshalDER() ([]byte, error) {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(scalarInt(sig.R))
+		b.AddASN1BigInt(scalarInt(sig.S))
+	})
+	return b.Bytes()
+}
+
+// MarshalCompact returns the compact encoding R || S || V of the signature,
+// where R and S are big-endian integers of the size of the scalars.
+func (sig *Signature) MarshalCompact() ([]byte, error) {
+	r, err := sig.R.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	s, err := sig.S.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if sig.R.ByteOrder() == kyber.LittleEndian {
+		r, s = reverse(r), reverse(s)
+	}
+	out := append(r, s...)
+	return append(out, sig.V), nil
+}
+
+// ParseDER returns the signature of the suite s encoded in ASN.1 DER. Its
+// recovery identifier is unknown and left to zero.
+func ParseDER(s Suite, der []byte) (*Signature, error) {
+	var r, sc = new(big.Int), new(big.Int)
+	var inner cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&inner, asn1.SEQUENCE) || !input.Empty() ||
+		!inner.ReadASN1Integer(r) || !inner.ReadASN1Integer(sc) || !inner.Empty() {
+		return nil, ErrMalformedSignature
+	}
+	return newSignature(s, r, sc, 0)
+}
+
+// ParseCompact returns the signature of the suite s in the compact encoding
+// R || S || V.
+func ParseCompact(s Suite, b []byte) (*Signature, error) {
+	size := s.Scalar().MarshalSize()
+	if len(b) != 2*size+1 {
+		return nil, fmt.Errorf("%w: length %d instead of %d", ErrMalformedSignature, len(b), 2*size+1)
+	}
+	r := new(big.Int).SetBytes(b[:size])
+	sc := new(big.Int).SetBytes(b[size : 2*size])
+	return newSignature(s, r, sc, b[2*size])
+}
+
+// newSignature returns the signature (r, s) with the recovery identifier v,
+// checking that r and s are in the range [1, N-1].
+func newSignature(s Suite, r, sc *big.Int, v byte) (*Signature, error) {
+	c := newCurve(s)
+	if r.Sign() <= 0 || sc.Sign() <= 0 || r.Cmp(c.n) >= 0 || sc.Cmp(c.n) >= 0 {
+		return nil, fmt.Errorf("%w: R or S out of range", ErrMalformedSignature)
+	}
+	return &Signature{R: c.toScalar(r), S: c.toScalar(sc), V: v}, nil
+}
+
+// digest returns the hash of msg with the hash function of the suite.
+func digest(s Suite, msg []byte) []byte {
+	h := s.Hash()
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// curve holds the parameters of the curve of a suite.
+type curve struct {
+	s      Suite
+	params *elliptic.CurveParams
+	n      *big.Int
+	size   int
+}
+
+func newCurve(s Suite) *curve {
+	params := s.Params()
+	return &curve{s: s, params: params, n: params.N, size: (params.BitSize + 7) / 8}
+}
+
+// bits2int returns the integer of the leftmost bits of b, as many as in N,
+// as defined by RFC 6979, section 2.3.2.
+func (c *curve) bits2int(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - c.n.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+	return i
+}
+
+// nonces returns the generator of the nonces of RFC 6979, section 3.2, for
+// the secret key x and the message digest h1.
+func (c *curve) nonces(x *big.Int, h1 []byte) func() *big.Int {
+	rolen := (c.n.BitLen() + 7) / 8
+	int2octets := func(i *big.Int) []byte {
+		return i.FillBytes(make([]byte, rolen))
+	}
+	bits2octets := func(b []byte) []byte {
+		return int2octets(new(big.Int).Mod(c.bits2int(b), c.n))
+	}
+	mac := func(key []byte, parts ...[]byte) []byte {
+		h := hmac.New(func() hash.Hash { return c.s.Hash() }, key)
+		for _, p := range parts {
+			h.Write(p)
+		}
+		return h.Sum(nil)
+	}
+
+	hlen := c.s.Hash().Size()
+	V := make([]byte, hlen)
+	for i := range V {
+		V[i] = 1
+	}
+	K := make([]byte, hlen)
+	xb, hb := int2octets(x), bits2octets(h1)
+	K = mac(K, V, []byte{0}, xb, hb)
+	V = mac(K, V)
+	K = mac(K, V, []byte{1}, xb, hb)
+	V = mac(K, V)
+
+	first := true
+	return func() *big.Int {
+		for {
+			if !first {
+				K = mac(K, V, []byte{0})
+				V = mac(K, V)
+			}
+			first = false
+
+			var T []byte
+			for len(T) < rolen {
+				V = mac(K, V)
+				T = append(T, V...)
+			}
+			k := c.bits2int(T[:rolen])
+			if k.Sign() > 0 && k.Cmp(c.n) < 0 {
+				return k
+			}
+		}
+	}
+}
+
+// isHigh tells whether the scalar is higher than N/2.
+func (c *curve) isHigh(s kyber.Scalar) bool {
+	return c.toInt(s).Cmp(new(big.Int).Rsh(c.n, 1)) > 0
+}
+
+// toScalar returns the scalar i mod N.
+func (c *curve) toScalar(i *big.Int) kyber.Scalar {
+	b := new(big.Int).Mod(i, c.n).FillBytes(make([]byte, (c.n.BitLen()+7)/8))
+	s := c.s.Scalar()
+	if s.ByteOrder() == kyber.LittleEndian {
+		b = reverse(b)
+	}
+	return s.SetBytes(b)
+}
+
+// toInt returns the integer value of the scalar s.
+func (c *curve) toInt(s kyber.Scalar) *big.Int {
+	return scalarInt(s)
+}
+
+// coordinates returns the affine coordinates of P.
+func (c *curve) coordinates(P kyber.Point) (*big.Int, *big.Int) {
+	buf, _ := P.MarshalBinary()
+	x := new(big.Int).SetBytes(buf[1 : 1+c.size])
+	y := new(big.Int).SetBytes(buf[1+c.size:])
+	return x, y
+}
+
+// liftX returns the point of the curve whose x-coordinate is x and whose
+// y-coordinate has the given parity.
+func (c *curve) liftX(x *big.Int, parity uint) (kyber.Point, error) {
+	p, b := c.params.P, c.params.B
+	if x.Cmp(p) >= 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	// The curve is y^2 = x^3 + a*x + b, where a is recovered from the
+	// base point, since it isn't part of the parameters.
+	gx, gy := c.params.Gx, c.params.Gy
+	a := new(big.Int).Mul(gy, gy)
+	a.Sub(a, new(big.Int).Exp(gx, big.NewInt(3), p)).Sub(a, b)
+	a.Mul(a, new(big.Int).ModInverse(gx, p)).Mod(a, p)
+
+	y2 := new(big.Int).Exp(x, big.NewInt(3), p)
+	y2.Add(y2, new(big.Int).Mul(a, x)).Add(y2, b).Mod(y2, p)
+	y := new(big.Int).ModSqrt(y2, p)
+	if y == nil {
+		return nil, ErrInvalidSignature
+	}
+	if y.Bit(0) != parity {
+		y.Sub(p, y)
+	}
+
+	buf := make([]byte, 1+2*c.size)
+	buf[0] = 4
+	x.FillBytes(buf[1 : 1+c.size])
+	y.FillBytes(buf[1+c.size:])
+	R := c.s.Point()
+	if err := R.UnmarshalBinary(buf); err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return R, nil
+}
+
+// scalarInt returns the integer value of the scalar s.
+func scalarInt(s kyber.Scalar) *big.Int {
+	b, _ := s.MarshalBinary()
+	if s.ByteOrder() == kyber.LittleEndian {
+		b = reverse(b)
+	}
+	return new(big.Int).SetBytes(b)
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
@@ -0,0 +1,266 @@
+package ecdsa
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	decred "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/group/s256"
+	"go.dedis.ch/kyber/v4/internal/test"
+	"go.dedis.ch/kyber/v4/internal/wycheproof"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suiteP256 = p256.NewBlakeSHA256P256()
+var suiteS256 = s256.NewSuite()
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func pointFromCoordinates(t *testing.T, s Suite, x, y []byte) kyber.Point {
+	P := s.Point()
+	require.NoError(t, P.UnmarshalBinary(append(append([]byte{4}, x...), y...)))
+	return P
+}
+
+func TestScheme(t *testing.T) {
+	test.SchemeTesting(t, NewScheme(suiteP256))
+	test.SchemeTesting(t, NewScheme(suiteS256))
+}
+
+// Test vectors from RFC 6979, appendix A.2.5, with SHA-256.
+func TestRFC6979P256(t *testing.T) {
+	x := decodeHex(t, "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721")
+	private := suiteP256.Scalar().SetBytes(x)
+	public := pointFromCoordinates(t, suiteP256,
+		decodeHex(t, "60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6"),
+		decodeHex(t, "7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299"))
+	require.True(t, suiteP256.Point().Mul(private, nil).Equal(public))
+
+	vectors := []struct{ msg, r, s string }{
+		{"sample",
+			"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+			"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8"},
+		{"test",
+			"F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+			"019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083"},
+	}
+	n := suiteP256.Params().N
+	for _, v := range vectors {
+		sig, err := Sign(suiteP256, private, []byte(v.msg))
+		require.NoError(t, err)
+		require.Equal(t, decodeHex(t, v.r), scalarInt(sig.R).FillBytes(make([]byte, 32)), v.msg)
+
+		// The expected S is normalized to the lower of S and N - S.
+		s, _ := new(big.Int).SetString(v.s, 16)
+		if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+			s.Sub(n, s)
+		}
+		require.Equal(t, s, scalarInt(sig.S), v.msg)
+		require.NoError(t, Verify(suiteP256, public, []byte(v.msg), sig))
+
+		// The signature verifies with the standard library.
+		der, err := sig.MarshalDER()
+		require.NoError(t, err)
+		h := sha256.Sum256([]byte(v.msg))
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(),
+			X: new(big.Int).SetBytes(decodeHex(t, "60FED4BA255A9D31C961EB74C6356D68C049B8923B61FA6CE669622E60F29FB6")),
+			Y: new(big.Int).SetBytes(decodeHex(t, "7903FE1008B8BC99A41AE9E95628BC64F2F1B20C2D7E9F5177A3C294D4462299"))}
+		require.True(t, ecdsa.VerifyASN1(pub, h[:], der))
+	}
+}
+
+func TestStandardLibraryP256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	public := pointFromCoordinates(t, suiteP256, key.X.FillBytes(make([]byte, 32)), key.Y.FillBytes(make([]byte, 32)))
+
+	high := 0
+	for i := 0; i < 16; i++ {
+		msg := []byte{byte(i)}
+		h := sha256.Sum256(msg)
+		der, err := ecdsa.SignASN1(rand.Reader, key, h[:])
+		require.NoError(t, err)
+		sig, err := ParseDER(suiteP256, der)
+		require.NoError(t, err)
+
+		// Half of the signatures of the standard library have a high S.
+		err = Verify(suiteP256, public, msg, sig)
+		if err != nil {
+			require.ErrorIs(t, err, ErrHighS)
+			high++
+			sig.S.Neg(sig.S)
+			require.NoError(t, Verify(suiteP256, public, msg, sig))
+		}
+	}
+	require.Less(t, high, 16)
+}
+
+// The signatures of secp256k1 match the ones of the decred and go-ethereum
+// libraries, which also use RFC 6979 and a low S.
+func TestSecp256k1Interop(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		private := suiteS256.Scalar().Pick(random.New())
+		x, err := private.MarshalBinary()
+		require.NoError(t, err)
+		msg := []byte{byte(i)}
+		h := sha256.Sum256(msg)
+
+		sig, err := Sign(suiteS256, private, msg)
+		require.NoError(t, err)
+		der, err := sig.MarshalDER()
+		require.NoError(t, err)
+		key := secp256k1.PrivKeyFromBytes(x)
+		require.Equal(t, decred.Sign(key, h[:]).Serialize(), der)
+
+		expected, err := decred.ParseDERSignature(der)
+		require.NoError(t, err)
+		require.True(t, expected.Verify(h[:], key.PubKey()))
+
+		compact, err := sig.MarshalCompact()
+		require.NoError(t, err)
+		ethKey, err := crypto.ToECDSA(x)
+		require.NoError(t, err)
+		ethSig, err := crypto.Sign(h[:], ethKey)
+		require.NoError(t, err)
+		require.Equal(t, ethSig, compact)
+
+		// ecrecover and addresses
+		public := suiteS256.Point().Mul(private, nil)
+		recovered, err := RecoverPublicKey(suiteS256, h[:], sig)
+		require.NoError(t, err)
+		require.True(t, public.Equal(recovered))
+		ethPub, err := crypto.Ecrecover(h[:], compact)
+		require.NoError(t, err)
+		pub, err := public.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, ethPub, pub)
+
+		address, err := EthereumAddress(recovered)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(ethKey.PublicKey).Bytes(), address)
+
+		parsed, err := ParseCompact(suiteS256, compact)
+		require.NoError(t, err)
+		require.Equal(t, sig.V, parsed.V)
+		require.True(t, sig.R.Equal(parsed.R) && sig.S.Equal(parsed.S))
+	}
+}
+
+func TestRecoverP256(t *testing.T) {
+	private, public := NewScheme(suiteP256).NewKeyPair(random.New())
+	digest := sha256.Sum256([]byte("recover me"))
+	sig, err := SignDigest(suiteP256, private, digest[:])
+	require.NoError(t, err)
+	Q, err := RecoverPublicKey(suiteP256, digest[:], sig)
+	require.NoError(t, err)
+	require.True(t, public.Equal(Q))
+
+	sig.V ^= 1
+	Q, err = RecoverPublicKey(suiteP256, digest[:], sig)
+	require.NoError(t, err)
+	require.False(t, public.Equal(Q))
+	sig.V = 4
+	_, err = RecoverPublicKey(suiteP256, digest[:], sig)
+	require.Error(t, err)
+}
+
+// Known address of the secret key 1.
+func TestEthereumAddress(t *testing.T) {
+	G := suiteS256.Point().Base()
+	address, err := EthereumAddress(G)
+	require.NoError(t, err)
+	require.Equal(t, "7e5f4552091a69125d5dfcb7b8c2659029395bdf", hex.EncodeToString(address))
+}
+
+func TestMalformedSignatures(t *testing.T) {
+	private, public := NewScheme(suiteS256).NewKeyPair(random.New())
+	sig, err := Sign(suiteS256, private, []byte("malformed"))
+	require.NoError(t, err)
+	der, err := sig.MarshalDER()
+	require.NoError(t, err)
+
+	_, err = ParseDER(suiteS256, append(der, 0))
+	require.ErrorIs(t, err, ErrMalformedSignature)
+	_, err = ParseDER(suiteS256, der[:len(der)-1])
+	require.ErrorIs(t, err, ErrMalformedSignature)
+
+	// A zero R, a non minimal encoding of R and an S equal to N.
+	_, err = ParseDER(suiteS256, decodeHex(t, "3006020100020101"))
+	require.ErrorIs(t, err, ErrMalformedSignature)
+	_, err = ParseDER(suiteS256, decodeHex(t, "300702020001020101"))
+	require.ErrorIs(t, err, ErrMalformedSignature)
+	n := suiteS256.Params().N.FillBytes(make([]byte, 33))
+	_, err = ParseDER(suiteS256, append(decodeHex(t, "30260201010221"), n...))
+	require.ErrorIs(t, err, ErrMalformedSignature)
+
+	_, err = ParseCompact(suiteS256, make([]byte, 64))
+	require.ErrorIs(t, err, ErrMalformedSignature)
+
+	// The high S is rejected.
+	sig.S.Neg(sig.S)
+	require.ErrorIs(t, Verify(suiteS256, public, []byte("malformed"), sig), ErrHighS)
+}
+
+// Test vectors from https://github.com/C2SP/wycheproof/tree/main/testvectors_v1,
+// vendored in testdata.
+func TestWycheProof(t *testing.T) {
+	files := []struct {
+		name  string
+		suite Suite
+		// bitcoin tells whether high S signatures are invalid
+		bitcoin bool
+	}{
+		{"ecdsa_secp256r1_sha256_test.json", suiteP256, false},
+		{"ecdsa_secp256k1_sha256_test.json", suiteS256, false},
+		{"ecdsa_secp256k1_sha256_bitcoin_test.json", suiteS256, true},
+	}
+	for _, f := range files {
+		data, err := os.ReadFile("testdata/" + f.name)
+		require.NoError(t, err)
+
+		var vectors wycheproof.EcdsaTestV1
+		require.NoError(t, json.Unmarshal(data, &vectors))
+		require.NotEmpty(t, vectors.TestGroups)
+
+		for _, group := range vectors.TestGroups {
+			public := f.suite.Point()
+			require.NoError(t, public.UnmarshalBinary(decodeHex(t, group.PublicKey.Uncompressed)))
+
+			for _, v := range group.Tests {
+				msg := decodeHex(t, v.Msg)
+				sig, err := ParseDER(f.suite, decodeHex(t, v.Sig))
+				if err == nil {
+					err = Verify(f.suite, public, msg, sig)
+				}
+				if v.Result == "invalid" {
+					require.Error(t, err, "%s test %d", f.name, v.TcID)
+					continue
+				}
+				if !f.bitcoin && errors.Is(err, ErrHighS) {
+					sig.S.Neg(sig.S)
+					err = Verify(f.suite, public, msg, sig)
+				}
+				if v.Result == "valid" {
+					require.NoError(t, err, "%s test %d", f.name, v.TcID)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package eddsa
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/ed448"
+	"golang.org/x/crypto/sha3"
+)
+
+var group448 = new(ed448.Curve)
+
+const (
+	// Ed448PointSize is the size of the Ed448 public keys and of the R
+	// part of the signatures.
+	Ed448PointSize = 57
+	// Ed448SignatureSize is the size of the Ed448 signatures, R || S.
+	Ed448SignatureSize = 2 * Ed448PointSize
+	// Ed448ContextMaxSize is the maximum size of the Ed448 contexts.
+	Ed448ContextMaxSize = 255
+)
+
+// ErrContextTooLong is returned when signing or verifying with a context
+// longer than Ed25519ContextMaxSize or Ed448ContextMaxSize bytes.
+var ErrContextTooLong = errors.New("context is too long")
+
+// Ed448 is a structure holding the data necessary to make a series of
+// Ed448 signatures, as specified in RFC 8032, section 5.2.
+type Ed448 struct {
+	// Secret being already hashed + bit tweaked
+	Secret kyber.Scalar
+	// Public is the corresponding public key
+	Public kyber.Point
+
+	seed   []byte
+	prefix []byte
+}
+
+// NewEd448 will return a freshly generated key pair to use for generating
+// Ed448 signatures.
+func NewEd448(stream cipher.Stream) *Ed448 {
+	if stream == nil {
+		panic("stream is required")
+	}
+
+	secret, buffer, prefix := group448.NewKeyAndSeed(stream)
+	public := group448.Point().Mul(secret, nil)
+
+	return &Ed448{
+		seed:   buffer,
+		prefix: prefix,
+		Secret: secret,
+		Public: public,
+	}
+}
+
+// MarshalBinary will return the representation "seed || Public", in 114
+// bytes.
+func (e *Ed448) MarshalBinary() ([]byte, error) {
+	pBuff, err := e.Public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	buff := make([]byte, ed448.SeedSize+Ed448PointSize)
+	copy(buff, e.seed)
+	copy(buff[ed448.SeedSize:], pBuff)
+	return buff, nil
+}
+
+// UnmarshalBinary transforms a slice of bytes into an Ed448 key pair.
+func (e *Ed448) UnmarshalBinary(buff []byte) error {
+	if len(buff) != ed448.SeedSize+Ed448PointSize {
+		return fmt.Errorf("error: %w", ErrEdDSAWrongLength)
+	}
+
+	secret, _, prefix := group448.NewKeyAndSeedWithInput(buff[:ed448.SeedSize])
+
+	e.seed = buff[:ed448.SeedSize]
+	e.prefix = prefix
+	e.Secret = secret
+	e.Public = group448.Point().Mul(e.Secret, nil)
+	return nil
+}
+
+// Sign will return an Ed448 signature of the message msg, with an empty
+// context.
+func (e *Ed448) Sign(msg []byte) ([]byte, error) {
+	return e.SignWithContext(nil, msg)
+}
+
+// SignWithContext will return an Ed448 signature of the message msg under
+// the context ctx, which is at most 255 bytes long.
+func (e *Ed448) SignWithContext(ctx, msg []byte) ([]byte, error) {
+	if len(ctx) > Ed448ContextMaxSize {
+		return nil, fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+
+	// deterministic random secret and its commit
+	r := group448.Scalar().SetBytes(hash448(ctx, e.prefix, msg))
+	R := group448.Point().Mul(r, nil)
+
+	// challenge
+	// H(dom4(0, ctx) || R || Public || Msg)
+	Rbuff, err := R.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	Abuff, err := e.Public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	h := group448.Scalar().SetBytes(hash448(ctx, Rbuff, Abuff, msg))
+
+	// response
+	// s = r + h * s
+	s := group448.Scalar().Mul(e.Secret, h)
+	s.Add(r, s)
+
+	sBuff, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// return R || s, where s is padded to the size of a point
+	var sig [Ed448SignatureSize]byte
+	copy(sig[:], Rbuff)
+	copy(sig[Ed448PointSize:], sBuff)
+
+	return sig[:], nil
+}
+
+// hash448 returns the 114 bytes SHAKE256 digest of dom4(0, ctx) followed
+// by the parts, as defined in RFC 8032, section 5.2.
+func hash448(ctx []byte, parts ...[]byte) []byte {
+	hash := sha3.NewShake256()
+	_, _ = hash.Write([]byte("SigEd448"))
+	_, _ = hash.Write([]byte{0, byte(len(ctx))})
+	_, _ = hash.Write(ctx)
+	for _, p := range parts {
+		_, _ = hash.Write(p)
+	}
+	digest := make([]byte, Ed448SignatureSize)
+	_, _ = hash.Read(digest)
+	return digest
+}
+
+// VerifyEd448WithChecks uses a public key buffer, a message and a
+// signature. It will return nil if sig is a valid Ed448 signature for msg
+// with an empty context created by key public, or an error otherwise. Like
+// VerifyWithChecks, it checks the canonicality of the encodings and
+// ensures that neither the public key nor R have a small order.
+func VerifyEd448WithChecks(pub, msg, sig []byte) error {
+	return VerifyEd448WithContext(pub, nil, msg, sig)
+}
+
+// VerifyEd448WithContext is VerifyEd448WithChecks for a signature made
+// under the context ctx.
+func VerifyEd448WithContext(pub, ctx, msg, sig []byte) error {
+	if len(sig) != Ed448SignatureSize {
+		return fmt.Errorf("error: %w: expect %d but got %v", ErrSignatureLength,
+			Ed448SignatureSize, len(sig))
+	}
+	if len(ctx) > Ed448ContextMaxSize {
+		return fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+
+	type scalarCanCheckCanonical interface {
+		IsCanonical(b []byte) bool
+	}
+
+	sBuff := sig[Ed448PointSize:]
+	if sBuff[Ed448PointSize-1] != 0 ||
+		!group448.Scalar().(scalarCanCheckCanonical).IsCanonical(sBuff[:Ed448PointSize-1]) {
+		return fmt.Errorf("error: %w", ErrSignatureNotCanonical)
+	}
+
+	type pointCanCheckCanonicalAndSmallOrder interface {
+		HasSmallOrder() bool
+		IsCanonical(b []byte) bool
+	}
+
+	R := group448.Point()
+	if !R.(pointCanCheckCanonicalAndSmallOrder).IsCanonical(sig[:Ed448PointSize]) {
+		return fmt.Errorf("error: %w", ErrPointRNotCanonical)
+	}
+	if err := R.UnmarshalBinary(sig[:Ed448PointSize]); err != nil {
+		return fmt.Errorf("error: %w: %w", ErrPointRInvalid, err)
+	}
+	if R.(pointCanCheckCanonicalAndSmallOrder).HasSmallOrder() {
+		return fmt.Errorf("error: %w", ErrPointRSmallOrder)
+	}
+
+	s := group448.Scalar()
+	if err := s.UnmarshalBinary(sBuff[:Ed448PointSize-1]); err != nil {
+		return fmt.Errorf("error: %w: %w", ErrSchnorrInvalidScalar, err)
+	}
+
+	public := group448.Point()
+	if !public.(pointCanCheckCanonicalAndSmallOrder).IsCanonical(pub) {
+		return fmt.Errorf("error: %w", ErrPKNotCanonical)
+	}
+	if err := public.UnmarshalBinary(pub); err != nil {
+		return fmt.Errorf("error: %w: %w", ErrPKInvalid, err)
+	}
+	if public.(pointCanCheckCanonicalAndSmallOrder).HasSmallOrder() {
+		return fmt.Errorf("error: %w", ErrPKSmallOrder)
+	}
+
+	// reconstruct h = H(dom4(0, ctx) || R || Public || Msg)
+	h := group448.Scalar().SetBytes(hash448(ctx, sig[:Ed448PointSize], pub, msg))
+	// reconstruct S == k*A + R
+	S := group448.Point().Mul(s, nil)
+	hA := group448.Point().Mul(h, public)
+	RhA := group448.Point().Add(R, hA)
+
+	if !RhA.Equal(S) {
+		return fmt.Errorf("error: %w", ErrSignatureRecNotEqual)
+	}
+	return nil
+}
+
+// VerifyEd448 uses a public key, a message and a signature. It will return
+// nil if sig is a valid Ed448 signature for msg with an empty context
+// created by key public, or an error otherwise.
+func VerifyEd448(public kyber.Point, msg, sig []byte) error {
+	PBuf, err := public.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error: %w: %w", ErrPKMarshalling, err)
+	}
+	return VerifyEd448WithChecks(PBuf, msg, sig)
+}
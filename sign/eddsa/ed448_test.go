@@ -0,0 +1,167 @@
+package eddsa
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/internal/wycheproof"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// Ed448TestVectors taken from RFC8032 section 7.4
+var Ed448TestVectors = []struct {
+	private   string
+	public    string
+	message   string
+	context   string
+	signature string
+}{
+	{"6c82a562cb808d10d632be89c8513ebf6c929f34ddfa8c9f63c9960ef6e348a3528c8a3fcc2f044e39a3fc5b94492f8f032e7549a20098f95b",
+		"5fd7449b59b461fd2ce787ec616ad46a1da1342485a70e1f8a0ea75d80e96778edf124769b46c7061bd6783df1e50f6cd1fa1abeafe8256180",
+		"",
+		"",
+		"533a37f6bbe457251f023c0d88f976ae2dfb504a843e34d2074fd823d41a591f2b233f034f628281f2fd7a22ddd47d7828c59bd0a21bfd3980ff0d2028d4b18a9df63e006c5d1c2d345b925d8dc00b4104852db99ac5c7cdda8530a113a0f4dbb61149f05a7363268c71d95808ff2e652600"},
+	{"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		"03",
+		"",
+		"26b8f91727bd62897af15e41eb43c377efb9c610d48f2335cb0bd0087810f4352541b143c4b981b7e18f62de8ccdf633fc1bf037ab7cd779805e0dbcc0aae1cbcee1afb2e027df36bc04dcecbf154336c19f0af7e0a6472905e799f1953d2a0ff3348ab21aa4adafd1d234441cf807c03a00"},
+	{"c4eab05d357007c632f3dbb48489924d552b08fe0c353a0d4a1f00acda2c463afbea67c5e8d2877c5e3bc397a659949ef8021e954e0a12274e",
+		"43ba28f430cdff456ae531545f7ecd0ac834a55d9358c0372bfa0c6c6798c0866aea01eb00742802b8438ea4cb82169c235160627b4c3a9480",
+		"03",
+		"666f6f",
+		"d4f8f6131770dd46f40867d6fd5d5055de43541f8c5e35abbcd001b32a89f7d2151f7647f11d8ca2ae279fb842d607217fce6e042f6815ea000c85741de5c8da1144a6a1aba7f96de42505d7a7298524fda538fccbbb754f578c1cad10d54d0d5428407e85dcbc98a49155c13764e66c3c00"},
+	{"cd23d24f714274e744343237b93290f511f6425f98e64459ff203e8985083ffdf60500553abc0e05cd02184bdb89c4ccd67e187951267eb328",
+		"dcea9e78f35a1bf3499a831b10b86c90aac01cd84b67a0109b55a36e9328b1e365fce161d71ce7131a543ea4cb5f7e9f1d8b00696447001400",
+		"0c3e544074ec63b0265e0c",
+		"",
+		"1f0a8888ce25e8d458a21130879b840a9089d999aaba039eaf3e3afa090a09d389dba82c4ff2ae8ac5cdfb7c55e94d5d961a29fe0109941e00b8dbdeea6d3b051068df7254c0cdc129cbe62db2dc957dbb47b51fd3f213fb8698f064774250a5028961c9bf8ffd973fe5d5c206492b140e00"},
+	{"258cdd4ada32ed9c9ff54e63756ae582fb8fab2ac721f2c8e676a72768513d939f63dddb55609133f29adf86ec9929dccb52c1c5fd2ff7e21b",
+		"3ba16da0c6f2cc1f30187740756f5e798d6bc5fc015d7c63cc9510ee3fd44adc24d8e968b6e46e6f94d19b945361726bd75e149ef09817f580",
+		"64a65f3cdedcdd66811e2915",
+		"",
+		"7eeeab7c4e50fb799b418ee5e3197ff6bf15d43a14c34389b59dd1a7b1b85b4ae90438aca634bea45e3a2695f1270f07fdcdf7c62b8efeaf00b45c2c96ba457eb1a8bf075a3db28e5c24f6b923ed4ad747c3c9e03c7079efb87cb110d3a99861e72003cbae6d6b8b827e4e6c143064ff3c00"},
+	{"7ef4e84544236752fbb56b8f31a23a10e42814f5f55ca037cdcc11c64c9a3b2949c1bb60700314611732a6c2fea98eebc0266a11a93970100e",
+		"b3da079b0aa493a5772029f0467baebee5a8112d9d3a22532361da294f7bb3815c5dc59e176b4d9f381ca0938e13c6c07b174be65dfa578e80",
+		"64a65f3cdedcdd66811e2915e7",
+		"",
+		"6a12066f55331b6c22acd5d5bfc5d71228fbda80ae8dec26bdd306743c5027cb4890810c162c027468675ecf645a83176c0d7323a2ccde2d80efe5a1268e8aca1d6fbc194d3f77c44986eb4ab4177919ad8bec33eb47bbb5fc6e28196fd1caf56b4e7e0ba5519234d047155ac727a1053100"},
+	{"d65df341ad13e008567688baedda8e9dcdc17dc024974ea5b4227b6530e339bff21f99e68ca6968f3cca6dfe0fb9f4fab4fa135d5542ea3f01",
+		"df9705f58edbab802c7f8363cfe5560ab1c6132c20a9f1dd163483a26f8ac53a39d6808bf4a1dfbd261b099bb03b3fb50906cb28bd8a081f00",
+		"bd0f6a3747cd561bdddf4640a332461a4a30a12a434cd0bf40d766d9c6d458e5512204a30c17d1f50b5079631f64eb3112182da3005835461113718d1a5ef944",
+		"",
+		"554bc2480860b49eab8532d2a533b7d578ef473eeb58c98bb2d0e1ce488a98b18dfde9b9b90775e67f47d4a1c3482058efc9f40d2ca033a0801b63d45b3b722ef552bad3b4ccb667da350192b61c508cf7b6b5adadc2c8d9a446ef003fb05cba5f30e88e36ec2703b349ca229c2670833900"},
+}
+
+func TestEd448Vectors(t *testing.T) {
+	for i, vec := range Ed448TestVectors {
+		seed, _ := hex.DecodeString(vec.private)
+		public, _ := hex.DecodeString(vec.public)
+		msg, _ := hex.DecodeString(vec.message)
+		ctx, _ := hex.DecodeString(vec.context)
+		expected, _ := hex.DecodeString(vec.signature)
+
+		ed := NewEd448(ConstantStream(seed))
+		pub, err := ed.Public.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, public, pub, "vector %d", i)
+
+		sig, err := ed.SignWithContext(ctx, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig, "vector %d", i)
+
+		require.NoError(t, VerifyEd448WithContext(public, ctx, msg, sig))
+		if len(ctx) == 0 {
+			require.NoError(t, VerifyEd448(ed.Public, msg, sig))
+		} else {
+			require.Error(t, VerifyEd448(ed.Public, msg, sig))
+		}
+		require.Error(t, VerifyEd448WithContext(public, []byte("bar"), msg, sig))
+	}
+}
+
+func TestEd448Marshalling(t *testing.T) {
+	rng := random.New()
+	for i := 0; i < 10; i++ {
+		ed := NewEd448(rng)
+		buff, err := ed.MarshalBinary()
+		require.NoError(t, err)
+
+		ed2 := &Ed448{}
+		require.NoError(t, ed2.UnmarshalBinary(buff))
+		require.True(t, ed.Public.Equal(ed2.Public))
+		require.True(t, ed.Secret.Equal(ed2.Secret))
+	}
+	require.ErrorIs(t, new(Ed448).UnmarshalBinary(make([]byte, 64)), ErrEdDSAWrongLength)
+}
+
+func TestEd448VerifyChecks(t *testing.T) {
+	ed := NewEd448(random.New())
+	msg := []byte("Hello Ed448")
+	sig, err := ed.Sign(msg)
+	require.NoError(t, err)
+	pub, err := ed.Public.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, VerifyEd448WithChecks(pub, msg, sig))
+
+	// S + l is rejected, as well as a non-zero last byte.
+	order := group448.Scalar().GroupOrder().FillBytes(make([]byte, 56))
+	malleable := append([]byte{}, sig...)
+	carry := 0
+	for i := 0; i < 56; i++ {
+		v := int(malleable[Ed448PointSize+i]) + int(order[55-i]) + carry
+		malleable[Ed448PointSize+i] = byte(v)
+		carry = v >> 8
+	}
+	malleable[Ed448SignatureSize-1] = byte(carry)
+	require.ErrorIs(t, VerifyEd448WithChecks(pub, msg, malleable), ErrSignatureNotCanonical)
+
+	// The neutral element has small order.
+	identity := make([]byte, Ed448PointSize)
+	identity[0] = 1
+	badR := append(append([]byte{}, identity...), sig[Ed448PointSize:]...)
+	require.ErrorIs(t, VerifyEd448WithChecks(pub, msg, badR), ErrPointRSmallOrder)
+	require.ErrorIs(t, VerifyEd448WithChecks(identity, msg, sig), ErrPKSmallOrder)
+
+	require.ErrorIs(t, VerifyEd448WithChecks(pub, msg, sig[:64]), ErrSignatureLength)
+	_, err = ed.SignWithContext(make([]byte, 256), msg)
+	require.ErrorIs(t, err, ErrContextTooLong)
+}
+
+// Test vectors from: https://github.com/cloudflare/circl/blob/v1.3.9/sign/ed448/testdata/wycheproof_Ed448.json
+func TestEd448WycheProof(t *testing.T) {
+	jsonFile, err := os.Open("testdata/ed448_test.json")
+	require.NoError(t, err)
+	defer jsonFile.Close()
+
+	jsonByte, err := io.ReadAll(jsonFile)
+	require.NoError(t, err)
+
+	var wycheproofTestData wycheproof.TestV0
+	err = json.Unmarshal(jsonByte, &wycheproofTestData)
+	require.NoError(t, err)
+	require.NotEmpty(t, wycheproofTestData.TestGroups)
+
+	for _, testGroup := range wycheproofTestData.TestGroups {
+		pkBytes, err := hex.DecodeString(testGroup.Key.PK)
+		require.NoError(t, err)
+
+		for _, test := range testGroup.Tests {
+			sigByte, err := hex.DecodeString(test.Sig)
+			require.NoError(t, err)
+			msgByte, err := hex.DecodeString(test.Msg)
+			require.NoError(t, err)
+			err = VerifyEd448WithChecks(pkBytes, msgByte, sigByte)
+
+			if test.Result == "valid" {
+				require.NoError(t, err, "test %d", test.TcID)
+			} else {
+				require.Error(t, err, "test %d", test.TcID)
+			}
+		}
+	}
+}
@@ -1,13 +1,18 @@
 // Package eddsa implements the EdDSA signature algorithm according to
-// RFC8032.
+// RFC8032, with Ed25519, its Ed25519ctx and Ed25519ph variants, and Ed448.
 package eddsa
 
 import (
 	"crypto/cipher"
 	"crypto/sha512"
 	"fmt"
+	"io"
+
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/msm"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
 )
 
 var group = new(edwards25519.Curve)
@@ -26,6 +31,13 @@ var ErrPointRSmallOrder = fmt.Errorf("point R has small order")
 var ErrPointRNotCanonical = fmt.Errorf("point R is not canonical")
 var ErrPointRInvalid = fmt.Errorf("point R invalid")
 
+var ErrContextEmpty = fmt.Errorf("context is empty")
+var ErrBatchLength = fmt.Errorf("mismatching numbers of keys, messages and signatures")
+
+// Ed25519ContextMaxSize is the maximum size of the contexts of Ed25519ctx
+// and Ed25519ph.
+const Ed25519ContextMaxSize = 255
+
 // EdDSA is a structure holding the data necessary to make a series of
 // EdDSA signatures.
 type EdDSA struct {
@@ -87,7 +99,43 @@ func (e *EdDSA) UnmarshalBinary(buff []byte) error {
 
 // Sign will return a EdDSA signature of the message msg using Ed25519.
 func (e *EdDSA) Sign(msg []byte) ([]byte, error) {
+	return e.sign(nil, msg)
+}
+
+// SignWithContext will return an Ed25519ctx signature of the message msg
+// under the context ctx, which is between 1 and 255 bytes long, as defined
+// in RFC 8032, section 5.1.
+func (e *EdDSA) SignWithContext(ctx, msg []byte) ([]byte, error) {
+	if len(ctx) == 0 {
+		return nil, fmt.Errorf("error: %w", ErrContextEmpty)
+	}
+	if len(ctx) > Ed25519ContextMaxSize {
+		return nil, fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+	return e.sign(dom2(0, ctx), msg)
+}
+
+// SignPrehashed will return an Ed25519ph signature of the message read from
+// r under the context ctx, which is at most 255 bytes long, as defined in
+// RFC 8032, section 5.1. The message is hashed with SHA-512 as it is read,
+// so that it is never held in memory.
+func (e *EdDSA) SignPrehashed(ctx []byte, r io.Reader) ([]byte, error) {
+	if len(ctx) > Ed25519ContextMaxSize {
+		return nil, fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+	ph, err := prehash(r)
+	if err != nil {
+		return nil, err
+	}
+	return e.sign(dom2(1, ctx), ph)
+}
+
+// sign returns the signature of msg, whose hashes are prefixed with dom.
+func (e *EdDSA) sign(dom, msg []byte) ([]byte, error) {
 	hash := sha512.New()
+	if _, err := hash.Write(dom); err != nil {
+		return nil, err
+	}
 	if _, err := hash.Write(e.prefix); err != nil {
 		return nil, err
 	}
@@ -100,7 +148,7 @@ func (e *EdDSA) Sign(msg []byte) ([]byte, error) {
 	R := group.Point().Mul(r, nil)
 
 	// challenge
-	// H( R || Public || Msg)
+	// H(dom || R || Public || Msg)
 	hash.Reset()
 	Rbuff, err := R.MarshalBinary()
 	if err != nil {
@@ -111,6 +159,9 @@ func (e *EdDSA) Sign(msg []byte) ([]byte, error) {
 		return nil, err
 	}
 
+	if _, err := hash.Write(dom); err != nil {
+		return nil, err
+	}
 	if _, err := hash.Write(Rbuff); err != nil {
 		return nil, err
 	}
@@ -141,14 +192,77 @@ func (e *EdDSA) Sign(msg []byte) ([]byte, error) {
 	return sig[:], nil
 }
 
+// dom2 returns the prefix of the hashes of Ed25519ctx and Ed25519ph, as
+// defined in RFC 8032, section 5.1.
+func dom2(phflag byte, ctx []byte) []byte {
+	dom := make([]byte, 0, 34+len(ctx))
+	dom = append(dom, "SigEd25519 no Ed25519 collisions"...)
+	dom = append(dom, phflag, byte(len(ctx)))
+	return append(dom, ctx...)
+}
+
+// prehash returns the SHA-512 digest of the content of r.
+func prehash(r io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
+}
+
 // VerifyWithChecks uses a public key buffer, a message and a signature.
 // It will return nil if sig is a valid signature for msg created by
 // key public, or an error otherwise. Compared to `Verify`, it performs
 // additional checks around the canonicality and ensures the public key
 // does not have a small order.
 func VerifyWithChecks(pub, msg, sig []byte) error {
+	R, s, public, err := decodeWithChecks(pub, sig)
+	if err != nil {
+		return err
+	}
+	return verify(nil, pub, msg, sig, R, s, public)
+}
+
+// VerifyWithContext is VerifyWithChecks for an Ed25519ctx signature made
+// under the context ctx.
+func VerifyWithContext(pub, ctx, msg, sig []byte) error {
+	if len(ctx) == 0 {
+		return fmt.Errorf("error: %w", ErrContextEmpty)
+	}
+	if len(ctx) > Ed25519ContextMaxSize {
+		return fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+	R, s, public, err := decodeWithChecks(pub, sig)
+	if err != nil {
+		return err
+	}
+	return verify(dom2(0, ctx), pub, msg, sig, R, s, public)
+}
+
+// VerifyPrehashed is VerifyWithChecks for an Ed25519ph signature made under
+// the context ctx of the message read from r. The public key and the
+// signature are checked before the message is read.
+func VerifyPrehashed(pub, ctx []byte, r io.Reader, sig []byte) error {
+	if len(ctx) > Ed25519ContextMaxSize {
+		return fmt.Errorf("error: %w", ErrContextTooLong)
+	}
+	R, s, public, err := decodeWithChecks(pub, sig)
+	if err != nil {
+		return err
+	}
+	ph, err := prehash(r)
+	if err != nil {
+		return err
+	}
+	return verify(dom2(1, ctx), pub, ph, sig, R, s, public)
+}
+
+// decodeWithChecks returns the commitment R and the response s of the
+// signature, and the public key, checking the canonicality of their
+// encodings and that the points don't have a small order.
+func decodeWithChecks(pub, sig []byte) (kyber.Point, kyber.Scalar, kyber.Point, error) {
 	if len(sig) != 64 {
-		return fmt.Errorf("error: %w: expect 64 but got %v", ErrSignatureLength, len(sig))
+		return nil, nil, nil, fmt.Errorf("error: %w: expect 64 but got %v", ErrSignatureLength, len(sig))
 	}
 
 	type scalarCanCheckCanonical interface {
@@ -156,7 +270,7 @@ func VerifyWithChecks(pub, msg, sig []byte) error {
 	}
 
 	if !group.Scalar().(scalarCanCheckCanonical).IsCanonical(sig[32:]) {
-		return fmt.Errorf("error: %w", ErrSignatureNotCanonical)
+		return nil, nil, nil, fmt.Errorf("error: %w", ErrSignatureNotCanonical)
 	}
 
 	type pointCanCheckCanonicalAndSmallOrder interface {
@@ -166,44 +280,40 @@ func VerifyWithChecks(pub, msg, sig []byte) error {
 
 	R := group.Point()
 	if !R.(pointCanCheckCanonicalAndSmallOrder).IsCanonical(sig[:32]) {
-		return fmt.Errorf("error: %w", ErrPointRNotCanonical)
+		return nil, nil, nil, fmt.Errorf("error: %w", ErrPointRNotCanonical)
 	}
 	if err := R.UnmarshalBinary(sig[:32]); err != nil {
-		return fmt.Errorf("error: %w: %w", ErrPointRInvalid, err)
+		return nil, nil, nil, fmt.Errorf("error: %w: %w", ErrPointRInvalid, err)
 	}
 	if R.(pointCanCheckCanonicalAndSmallOrder).HasSmallOrder() {
-		return fmt.Errorf("error: %w", ErrPointRSmallOrder)
+		return nil, nil, nil, fmt.Errorf("error: %w", ErrPointRSmallOrder)
 	}
 
 	s := group.Scalar()
 	if err := s.UnmarshalBinary(sig[32:]); err != nil {
-		return fmt.Errorf("error: %w: %w", ErrSchnorrInvalidScalar, err)
+		return nil, nil, nil, fmt.Errorf("error: %w: %w", ErrSchnorrInvalidScalar, err)
 	}
 
 	public := group.Point()
 	if !public.(pointCanCheckCanonicalAndSmallOrder).IsCanonical(pub) {
-		return fmt.Errorf("error: %w", ErrPKNotCanonical)
+		return nil, nil, nil, fmt.Errorf("error: %w", ErrPKNotCanonical)
 	}
 	if err := public.UnmarshalBinary(pub); err != nil {
-		return fmt.Errorf("error: %w: %w", ErrPKInvalid, err)
+		return nil, nil, nil, fmt.Errorf("error: %w: %w", ErrPKInvalid, err)
 	}
 	if public.(pointCanCheckCanonicalAndSmallOrder).HasSmallOrder() {
-		return fmt.Errorf("error: %w", ErrPKSmallOrder)
+		return nil, nil, nil, fmt.Errorf("error: %w", ErrPKSmallOrder)
 	}
+	return R, s, public, nil
+}
 
-	// reconstruct h = H(R || Public || Msg)
-	hash := sha512.New()
-	if _, err := hash.Write(sig[:32]); err != nil {
-		return err
-	}
-	if _, err := hash.Write(pub); err != nil {
-		return err
-	}
-	if _, err := hash.Write(msg); err != nil {
+// verify checks the verification equation of the decoded signature of msg,
+// whose hashes are prefixed with dom.
+func verify(dom, pub, msg, sig []byte, R kyber.Point, s kyber.Scalar, public kyber.Point) error {
+	h, err := challenge(dom, pub, msg, sig)
+	if err != nil {
 		return err
 	}
-
-	h := group.Scalar().SetBytes(hash.Sum(nil))
 	// reconstruct S == k*A + R
 	S := group.Point().Mul(s, nil)
 	hA := group.Point().Mul(h, public)
@@ -215,6 +325,24 @@ func VerifyWithChecks(pub, msg, sig []byte) error {
 	return nil
 }
 
+// challenge returns h = H(dom || R || Public || Msg).
+func challenge(dom, pub, msg, sig []byte) (kyber.Scalar, error) {
+	hash := sha512.New()
+	if _, err := hash.Write(dom); err != nil {
+		return nil, err
+	}
+	if _, err := hash.Write(sig[:32]); err != nil {
+		return nil, err
+	}
+	if _, err := hash.Write(pub); err != nil {
+		return nil, err
+	}
+	if _, err := hash.Write(msg); err != nil {
+		return nil, err
+	}
+	return group.Scalar().SetBytes(hash.Sum(nil)), nil
+}
+
 // Verify uses a public key, a message and a signature. It will return nil if
 // sig is a valid signature for msg created by key public, or an error otherwise.
 func Verify(public kyber.Point, msg, sig []byte) error {
@@ -224,3 +352,90 @@ func Verify(public kyber.Point, msg, sig []byte) error {
 	}
 	return VerifyWithChecks(PBuf, msg, sig)
 }
+
+// BatchVerify verifies that each sigs[i] is a valid signature of msgs[i]
+// created by key publics[i]. It returns nil if they all are, or else a
+// *sign.BatchError holding the invalid signatures, as decided by the
+// cofactored equation of BatchVerifyWithChecks.
+func BatchVerify(publics []kyber.Point, msgs, sigs [][]byte) error {
+	pubs := make([][]byte, len(publics))
+	for i, public := range publics {
+		pub, err := public.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("error: %w: %w", ErrPKMarshalling, err)
+		}
+		pubs[i] = pub
+	}
+	return BatchVerifyWithChecks(pubs, msgs, sigs)
+}
+
+// BatchVerifyWithChecks is the batch version of VerifyWithChecks. The
+// signatures failing the checks of VerifyWithChecks are reported right away,
+// and the others are verified at once by checking a random linear
+// combination of their equations with a single multi-scalar multiplication.
+// Only if this check fails are they verified one by one, to find the invalid
+// ones.
+//
+// The equations are multiplied by the cofactor 8, as allowed by RFC 8032,
+// section 5.1.7, so that the torsion components of crafted public keys or R
+// can't make the result depend on the random combination. A signature is
+// thus accepted if 8*s*B == 8*R + 8*h*A, which holds for all the signatures
+// that VerifyWithChecks accepts, but also for the ones whose points have a
+// torsion component that VerifyWithChecks rejects. An invalid signature is
+// detected except with probability 1/l.
+func BatchVerifyWithChecks(pubs, msgs, sigs [][]byte) error {
+	if len(pubs) != len(msgs) || len(pubs) != len(sigs) {
+		return fmt.Errorf("error: %w", ErrBatchLength)
+	}
+
+	// 8*(sum(a_i*s_i)*B - sum(a_i*R_i) - sum(a_i*h_i*A_i)) == 0, with random a_i
+	rand := random.New()
+	invalid := make(map[int]error)
+	indices := make([]int, 0, len(sigs))
+	equations := make([][]kyber.Scalar, 0, len(sigs))
+	scalars := make([]kyber.Scalar, 1, 2*len(sigs)+1)
+	points := make([]kyber.Point, 1, 2*len(sigs)+1)
+	sum := group.Scalar().Zero()
+	for i := range sigs {
+		R, s, public, err := decodeWithChecks(pubs[i], sigs[i])
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+		h, err := challenge(nil, pubs[i], msgs[i], sigs[i])
+		if err != nil {
+			return err
+		}
+
+		a := group.Scalar().Pick(rand)
+		sum.Add(sum, group.Scalar().Mul(a, s))
+		scalars = append(scalars, group.Scalar().Neg(a), group.Scalar().Neg(group.Scalar().Mul(a, h)))
+		points = append(points, R, public)
+		indices = append(indices, i)
+		equations = append(equations, []kyber.Scalar{s, group.Scalar().SetInt64(-1), h.Neg(h)})
+	}
+	scalars[0] = sum
+
+	if len(indices) > 0 && !hasSmallOrder(msm.MultiScalarMul(group, scalars, points)) {
+		for j, i := range indices {
+			// s*B - R - h*A
+			P := msm.MultiScalarMul(group, equations[j], []kyber.Point{nil, points[2*j+1], points[2*j+2]})
+			if !hasSmallOrder(P) {
+				invalid[i] = fmt.Errorf("error: %w", ErrSignatureRecNotEqual)
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &sign.BatchError{Invalid: invalid}
+	}
+	return nil
+}
+
+// hasSmallOrder tells whether the multiple of P by the cofactor is the
+// neutral element.
+func hasSmallOrder(P kyber.Point) bool {
+	for i := 0; i < 3; i++ {
+		P.Add(P, P)
+	}
+	return P.Equal(group.Point().Null())
+}
@@ -4,17 +4,23 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"crypto"
 	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/sha512"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"math/rand"
 	"os"
 	"strings"
 	"testing"
 
+	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
 	"go.dedis.ch/kyber/v4/internal/wycheproof"
+	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/util/random"
 
 	"github.com/stretchr/testify/assert"
@@ -106,6 +112,178 @@ func TestEdDSASigning(t *testing.T) {
 	}
 }
 
+// Ed25519ctxTestVectors taken from RFC8032 section 7.2
+var Ed25519ctxTestVectors = []struct {
+	private   string
+	public    string
+	message   string
+	context   string
+	signature string
+}{
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"f726936d19c800494e3fdaff20b276a8",
+		"666f6f",
+		"55a4cc2f70a54e04288c5f4cd1e45a7bb520b36292911876cada7323198dd87a8b36950b95130022907a7fb7c4e9b2d5f6cca685a587b4b21f4b888e4e7edb0d"},
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"f726936d19c800494e3fdaff20b276a8",
+		"626172",
+		"fc60d5872fc46b3aa69f8b5b4351d5808f92bcc044606db097abab6dbcb1aee3216c48e8b3b66431b5b186d1d28f8ee15a5ca2df6668346291c2043d4eb3e90d"},
+	{"0305334e381af78f141cb666f6199f57bc3495335a256a95bd2a55bf546663f6",
+		"dfc9425e4f968f7f0c29f0259cf5f9aed6851c2bb4ad8bfb860cfee0ab248292",
+		"508e9e6882b979fea900f62adceaca35",
+		"666f6f",
+		"8b70c1cc8310e1de20ac53ce28ae6e7207f33c3295e03bb5c0732a1d20dc64908922a8b052cf99b7c4fe107a5abb5b2c4085ae75890d02df26269d8945f84b0b"},
+	{"ab9c2853ce297ddab85c993b3ae14bcad39b2c682beabc27d6d4eb20711d6560",
+		"0f1d1274943b91415889152e893d80e93275a1fc0b65fd71b4b0dda10ad7d772",
+		"f726936d19c800494e3fdaff20b276a8",
+		"666f6f",
+		"21655b5f1aa965996b3f97b3c849eafba922a0a62992f73b3d1b73106a84ad85e9b86a7b6005ea868337ff2d20a7f5fbd4cd10b0be49a68da2b2e0dc0ad8960f"},
+}
+
+func TestEd25519ctxVectors(t *testing.T) {
+	for i, vec := range Ed25519ctxTestVectors {
+		seed, _ := hex.DecodeString(vec.private)
+		public, _ := hex.DecodeString(vec.public)
+		msg, _ := hex.DecodeString(vec.message)
+		ctx, _ := hex.DecodeString(vec.context)
+		expected, _ := hex.DecodeString(vec.signature)
+
+		ed := NewEdDSA(ConstantStream(seed))
+		sig, err := ed.SignWithContext(ctx, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig, "vector %d", i)
+
+		require.NoError(t, VerifyWithContext(public, ctx, msg, sig))
+		require.ErrorIs(t, VerifyWithContext(public, []byte("baz"), msg, sig), ErrSignatureRecNotEqual)
+		require.Error(t, VerifyWithChecks(public, msg, sig))
+	}
+}
+
+// Test vector taken from RFC8032 section 7.3
+func TestEd25519phVector(t *testing.T) {
+	seed, _ := hex.DecodeString("833fe62409237b9d62ec77587520911e9a759cec1d19755b7da901b96dca3d42")
+	public, _ := hex.DecodeString("ec172b93ad5e563bf4932c70e1245034c35467ef2efd4d64ebf819683467e2bf")
+	expected, _ := hex.DecodeString("98a70222f0b8121aa9d30f813d683f809e462b469c7ff87639499bb94e6dae41" +
+		"31f85042463c2a355a2003d062adf5aaa10b8c61e636062aaad11c2a26083406")
+
+	ed := NewEdDSA(ConstantStream(seed))
+	sig, err := ed.SignPrehashed(nil, strings.NewReader("abc"))
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	require.NoError(t, VerifyPrehashed(public, nil, strings.NewReader("abc"), sig))
+	require.Error(t, VerifyPrehashed(public, nil, strings.NewReader("abd"), sig))
+	require.Error(t, VerifyPrehashed(public, []byte("foo"), strings.NewReader("abc"), sig))
+	require.Error(t, VerifyWithChecks(public, []byte("abc"), sig))
+}
+
+// patternReader returns n bytes of a repeated pattern, without holding
+// them in memory.
+type patternReader struct {
+	n int64
+}
+
+func (r *patternReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, io.EOF
+	}
+	if int64(len(p)) > r.n {
+		p = p[:r.n]
+	}
+	for i := range p {
+		p[i] = byte(r.n - int64(i))
+	}
+	r.n -= int64(len(p))
+	return len(p), nil
+}
+
+// Ed25519ph signatures of a stream match the ones of the standard library
+// on the SHA-512 digest of its content.
+func TestEd25519phStream(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	random.Bytes(seed, random.New())
+	ed := NewEdDSA(ConstantStream(seed))
+	ctx := []byte("artifact")
+	size := int64(32 << 20)
+
+	sig, err := ed.SignPrehashed(ctx, &patternReader{n: size})
+	require.NoError(t, err)
+
+	digest := sha512.New()
+	_, err = io.Copy(digest, &patternReader{n: size})
+	require.NoError(t, err)
+	key := ed25519.NewKeyFromSeed(seed)
+	expected, err := key.Sign(nil, digest.Sum(nil), &ed25519.Options{Hash: crypto.SHA512, Context: string(ctx)})
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	pub, err := ed.Public.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, VerifyPrehashed(pub, ctx, &patternReader{n: size}, sig))
+	require.Error(t, VerifyPrehashed(pub, ctx, &patternReader{n: size - 1}, sig))
+}
+
+// The variants keep the checks of VerifyWithChecks.
+func TestEd25519ctxAndPhChecks(t *testing.T) {
+	ed := NewEdDSA(random.New())
+	pub, err := ed.Public.MarshalBinary()
+	require.NoError(t, err)
+	msg := []byte("checks")
+	ctx := []byte("ctx")
+
+	sigCtx, err := ed.SignWithContext(ctx, msg)
+	require.NoError(t, err)
+	sigPh, err := ed.SignPrehashed(ctx, bytes.NewReader(msg))
+	require.NoError(t, err)
+	verifyCtx := func(pub, sig []byte) error { return VerifyWithContext(pub, ctx, msg, sig) }
+	verifyPh := func(pub, sig []byte) error { return VerifyPrehashed(pub, ctx, bytes.NewReader(msg), sig) }
+
+	/* l = 2^252+27742317777372353535851937790883648493, prime order of the base point */
+	L := []uint16{0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7,
+		0xa2, 0xde, 0xf9, 0xde, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10}
+	smallOrder := []byte{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b,
+		0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39,
+		0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a}
+
+	for _, c := range []struct {
+		sig    []byte
+		verify func(pub, sig []byte) error
+	}{{sigCtx, verifyCtx}, {sigPh, verifyPh}} {
+		require.NoError(t, c.verify(pub, c.sig))
+
+		// Add l to the signature
+		malleable := append([]byte{}, c.sig...)
+		var carry uint16
+		for i := 0; i < 32; i++ {
+			carry += uint16(malleable[32+i]) + L[i]
+			malleable[32+i] = byte(carry)
+			carry >>= 8
+		}
+		require.ErrorIs(t, c.verify(pub, malleable), ErrSignatureNotCanonical)
+
+		badR := append(append([]byte{}, smallOrder...), c.sig[32:]...)
+		require.ErrorIs(t, c.verify(pub, badR), ErrPointRSmallOrder)
+		require.ErrorIs(t, c.verify(smallOrder, c.sig), ErrPKSmallOrder)
+		require.ErrorIs(t, c.verify(pub, c.sig[:63]), ErrSignatureLength)
+	}
+
+	// Signatures of a mode don't verify in the other ones.
+	require.Error(t, VerifyWithChecks(pub, msg, sigCtx))
+	require.Error(t, verifyPh(pub, sigCtx))
+	require.Error(t, verifyCtx(pub, sigPh))
+
+	_, err = ed.SignWithContext(nil, msg)
+	require.ErrorIs(t, err, ErrContextEmpty)
+	_, err = ed.SignWithContext(make([]byte, 256), msg)
+	require.ErrorIs(t, err, ErrContextTooLong)
+	_, err = ed.SignPrehashed(make([]byte, 256), bytes.NewReader(msg))
+	require.ErrorIs(t, err, ErrContextTooLong)
+	require.ErrorIs(t, VerifyWithContext(pub, nil, msg, sigCtx), ErrContextEmpty)
+}
+
 // Test signature malleability
 func TestEdDSAVerifyMalleability(t *testing.T) {
 	/* l = 2^252+27742317777372353535851937790883648493, prime order of the base point */
@@ -392,3 +570,187 @@ func TestWycheProof(t *testing.T) {
 		}
 	}
 }
+
+// batch returns n signatures of random messages by random keys.
+func batch(t require.TestingT, n int) ([][]byte, [][]byte, [][]byte) {
+	pubs := make([][]byte, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := range sigs {
+		ed := NewEdDSA(random.New())
+		pub, err := ed.Public.MarshalBinary()
+		require.NoError(t, err)
+		msgs[i] = random.Bits(256, false, random.New())
+		sig, err := ed.Sign(msgs[i])
+		require.NoError(t, err)
+		pubs[i], sigs[i] = pub, sig
+	}
+	return pubs, msgs, sigs
+}
+
+func TestBatchVerify(t *testing.T) {
+	pubs, msgs, sigs := batch(t, 100)
+	require.NoError(t, BatchVerifyWithChecks(pubs, msgs, sigs))
+	require.NoError(t, BatchVerifyWithChecks(nil, nil, nil))
+	require.ErrorIs(t, BatchVerifyWithChecks(pubs, msgs[1:], sigs), ErrBatchLength)
+
+	publics := make([]kyber.Point, len(pubs))
+	for i, pub := range pubs {
+		publics[i] = group.Point()
+		require.NoError(t, publics[i].UnmarshalBinary(pub))
+	}
+	require.NoError(t, BatchVerify(publics, msgs, sigs))
+
+	// The RFC 8032 test vectors.
+	for _, vec := range EdDSATestVectors {
+		pub, _ := hex.DecodeString(vec.public)
+		msg, _ := hex.DecodeString(vec.message)
+		sig, _ := hex.DecodeString(vec.signature)
+		pubs, msgs, sigs = append(pubs, pub), append(msgs, msg), append(sigs, sig)
+	}
+	require.NoError(t, BatchVerifyWithChecks(pubs, msgs, sigs))
+}
+
+// The invalid signatures of a batch are the ones VerifyWithChecks rejects,
+// whether they fail its checks or the verification equation.
+func TestBatchVerifyInvalid(t *testing.T) {
+	pubs, msgs, sigs := batch(t, 64)
+
+	// wrong message and wrong key
+	msgs[3] = []byte("wrong message")
+	pubs[5] = pubs[6]
+	// add l to S
+	L := []uint16{0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7,
+		0xa2, 0xde, 0xf9, 0xde, 0x14, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10}
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		carry += uint16(sigs[7][32+i]) + L[i]
+		sigs[7][32+i] = byte(carry)
+		carry >>= 8
+	}
+	// small order R and public key, and a short signature
+	smallOrder := []byte{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b,
+		0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39,
+		0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a}
+	copy(sigs[11][:32], smallOrder)
+	pubs[20] = smallOrder
+	sigs[42] = sigs[42][:63]
+
+	err := BatchVerifyWithChecks(pubs, msgs, sigs)
+	var batchErr *sign.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{3, 5, 7, 11, 20, 42}, batchErr.Indices())
+	for i := range sigs {
+		expected := VerifyWithChecks(pubs[i], msgs[i], sigs[i])
+		require.Equal(t, expected, batchErr.Invalid[i], "signature %d", i)
+	}
+	require.ErrorIs(t, batchErr.Invalid[7], ErrSignatureNotCanonical)
+	require.ErrorIs(t, batchErr.Invalid[11], ErrPointRSmallOrder)
+	require.ErrorIs(t, batchErr.Invalid[20], ErrPKSmallOrder)
+	require.ErrorIs(t, batchErr.Invalid[42], ErrSignatureLength)
+}
+
+// A signature whose public key and R have torsion components that cancel
+// out in the cofactorless equation is valid, and accepted by the batch too.
+func TestBatchVerifyMixedOrder(t *testing.T) {
+	smallOrder := []byte{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b,
+		0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39,
+		0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a}
+	T := group.Point()
+	require.NoError(t, T.UnmarshalBinary(smallOrder))
+
+	x := group.Scalar().Pick(random.New())
+	A := group.Point().Add(group.Point().Mul(x, nil), T)
+	pub, err := A.MarshalBinary()
+	require.NoError(t, err)
+	msg := []byte("mixed order")
+
+	// Find R = k*B + j*T such that j*T + h*T = 0.
+	var sig []byte
+	for sig == nil {
+		k := group.Scalar().Pick(random.New())
+		for j := int64(0); j < 8 && sig == nil; j++ {
+			R := group.Point().Add(group.Point().Mul(k, nil), group.Point().Mul(group.Scalar().SetInt64(j), T))
+			Rbuf, err := R.MarshalBinary()
+			require.NoError(t, err)
+			h, err := challenge(nil, pub, msg, append(Rbuf, make([]byte, 32)...))
+			require.NoError(t, err)
+			jT := group.Point().Sub(R, group.Point().Mul(k, nil))
+			if !jT.Add(jT, group.Point().Mul(h, T)).Equal(group.Point().Null()) {
+				continue
+			}
+			s := group.Scalar().Add(k, h.Mul(h, x))
+			sBuf, err := s.MarshalBinary()
+			require.NoError(t, err)
+			sig = append(Rbuf, sBuf...)
+		}
+	}
+	require.NoError(t, VerifyWithChecks(pub, msg, sig))
+
+	pubs, msgs, sigs := batch(t, 16)
+	pubs, msgs, sigs = append(pubs, pub), append(msgs, msg), append(sigs, sig)
+	require.NoError(t, BatchVerifyWithChecks(pubs, msgs, sigs))
+}
+
+// A signature whose public key has a torsion component which doesn't cancel
+// out is rejected by VerifyWithChecks, but accepted by the cofactored
+// equation of the batch, whatever the other signatures of the batch.
+func TestBatchVerifyTorsion(t *testing.T) {
+	smallOrder := []byte{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b,
+		0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39,
+		0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a}
+	T := group.Point()
+	require.NoError(t, T.UnmarshalBinary(smallOrder))
+
+	x := group.Scalar().Pick(random.New())
+	A := group.Point().Add(group.Point().Mul(x, nil), T)
+	pub, err := A.MarshalBinary()
+	require.NoError(t, err)
+
+	// s*B - R - h*A = -h*T, which isn't the neutral element unless 8 | h.
+	var msg, sig []byte
+	for i := 0; sig == nil; i++ {
+		msg = []byte(fmt.Sprintf("torsion %d", i))
+		k := group.Scalar().Pick(random.New())
+		Rbuf, err := group.Point().Mul(k, nil).MarshalBinary()
+		require.NoError(t, err)
+		h, err := challenge(nil, pub, msg, append(Rbuf, make([]byte, 32)...))
+		require.NoError(t, err)
+		sBuf, err := group.Scalar().Add(k, group.Scalar().Mul(h, x)).MarshalBinary()
+		require.NoError(t, err)
+		if err := VerifyWithChecks(pub, msg, append(Rbuf, sBuf...)); err != nil {
+			sig = append(Rbuf, sBuf...)
+		}
+	}
+	require.ErrorIs(t, VerifyWithChecks(pub, msg, sig), ErrSignatureRecNotEqual)
+
+	pubs, msgs, sigs := batch(t, 16)
+	pubs, msgs, sigs = append(pubs, pub), append(msgs, msg), append(sigs, sig)
+	require.NoError(t, BatchVerifyWithChecks(pubs, msgs, sigs))
+
+	// the signatures are also verified with the cofactored equation when
+	// the batch is invalid
+	msgs[3] = []byte("wrong message")
+	err = BatchVerifyWithChecks(pubs, msgs, sigs)
+	var batchErr *sign.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{3}, batchErr.Indices())
+	require.ErrorIs(t, batchErr.Invalid[3], ErrSignatureRecNotEqual)
+}
+
+func BenchmarkVerify(b *testing.B) {
+	pubs, msgs, sigs := batch(b, 1024)
+	b.Run("OneByOne", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := range sigs {
+				_ = VerifyWithChecks(pubs[j], msgs[j], sigs[j])
+			}
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = BatchVerifyWithChecks(pubs, msgs, sigs)
+		}
+	})
+}
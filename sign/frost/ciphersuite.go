@@ -0,0 +1,243 @@
+package frost
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/s256"
+	"go.dedis.ch/kyber/v4/internal/h2c"
+	"go.dedis.ch/kyber/v4/sign/bip340"
+)
+
+// Ciphersuite is a FROST ciphersuite of RFC 9591, Section 6: a prime-order
+// group with the encodings of its elements and scalars, and the five hash
+// functions of the protocol.
+type Ciphersuite interface {
+	kyber.Group
+
+	// ContextString returns the context string of the ciphersuite, e.g.
+	// "FROST-ED25519-SHA512-v1", which prefixes the inputs of the hashes.
+	ContextString() string
+
+	// H1 computes the binding factors.
+	H1(m []byte) kyber.Scalar
+	// H2 computes the challenge of the Schnorr signatures.
+	H2(m []byte) kyber.Scalar
+	// H3 derives the nonces.
+	H3(m []byte) kyber.Scalar
+	// H4 hashes the message.
+	H4(m []byte) []byte
+	// H5 hashes the encoded list of commitments.
+	H5(m []byte) []byte
+
+	// SerializeElement encodes a group element, which mustn't be the
+	// identity.
+	SerializeElement(P kyber.Point) ([]byte, error)
+	// DeserializeElement decodes a group element, rejecting the identity,
+	// non-canonical encodings and elements outside the prime-order
+	// subgroup.
+	DeserializeElement(buf []byte) (kyber.Point, error)
+	// SerializeScalar encodes a scalar.
+	SerializeScalar(s kyber.Scalar) ([]byte, error)
+	// DeserializeScalar decodes a scalar, rejecting the encodings of
+	// integers which aren't lower than the group order.
+	DeserializeScalar(buf []byte) (kyber.Scalar, error)
+}
+
+// ErrInvalidElement is returned when decoding an invalid group element.
+var ErrInvalidElement = errors.New("frost: invalid group element")
+
+// ErrInvalidScalar is returned when decoding an invalid scalar.
+var ErrInvalidScalar = errors.New("frost: invalid scalar")
+
+type ed25519Suite struct {
+	edwards25519.Curve
+}
+
+// Ed25519 returns the FROST(Ed25519, SHA-512) ciphersuite, whose signatures
+// are Ed25519 signatures that can be verified with sign/eddsa.
+func Ed25519() Ciphersuite {
+	return &ed25519Suite{}
+}
+
+func (s *ed25519Suite) ContextString() string {
+	return "FROST-ED25519-SHA512-v1"
+}
+
+func (s *ed25519Suite) hash(tag string, m []byte) []byte {
+	h := sha512.New()
+	h.Write([]byte(s.ContextString() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+func (s *ed25519Suite) H1(m []byte) kyber.Scalar {
+	return s.Scalar().SetBytes(s.hash("rho", m))
+}
+
+// H2 is the hash of the Ed25519 challenge, without context string.
+func (s *ed25519Suite) H2(m []byte) kyber.Scalar {
+	digest := sha512.Sum512(m)
+	return s.Scalar().SetBytes(digest[:])
+}
+
+func (s *ed25519Suite) H3(m []byte) kyber.Scalar {
+	return s.Scalar().SetBytes(s.hash("nonce", m))
+}
+
+func (s *ed25519Suite) H4(m []byte) []byte {
+	return s.hash("msg", m)
+}
+
+func (s *ed25519Suite) H5(m []byte) []byte {
+	return s.hash("com", m)
+}
+
+func (s *ed25519Suite) SerializeElement(P kyber.Point) ([]byte, error) {
+	if P.Equal(s.Point().Null()) {
+		return nil, fmt.Errorf("%w: identity", ErrInvalidElement)
+	}
+	return P.MarshalBinary()
+}
+
+func (s *ed25519Suite) DeserializeElement(buf []byte) (kyber.Point, error) {
+	type pointCanCheckCanonical interface {
+		IsCanonical(b []byte) bool
+	}
+
+	P := s.Point()
+	if len(buf) != P.MarshalSize() || !P.(pointCanCheckCanonical).IsCanonical(buf) {
+		return nil, fmt.Errorf("%w: non-canonical encoding", ErrInvalidElement)
+	}
+	if err := P.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidElement, err)
+	}
+	null := s.Point().Null()
+	if P.Equal(null) {
+		return nil, fmt.Errorf("%w: identity", ErrInvalidElement)
+	}
+	// [L]P = [L-1]P + P is the identity iff P is in the prime-order subgroup
+	LP := s.Point().Mul(s.Scalar().SetInt64(-1), P)
+	if !LP.Add(LP, P).Equal(null) {
+		return nil, fmt.Errorf("%w: not in the prime-order subgroup", ErrInvalidElement)
+	}
+	return P, nil
+}
+
+func (s *ed25519Suite) SerializeScalar(x kyber.Scalar) ([]byte, error) {
+	return x.MarshalBinary()
+}
+
+func (s *ed25519Suite) DeserializeScalar(buf []byte) (kyber.Scalar, error) {
+	type scalarCanCheckCanonical interface {
+		IsCanonical(b []byte) bool
+	}
+
+	x := s.Scalar()
+	if len(buf) != x.MarshalSize() || !x.(scalarCanCheckCanonical).IsCanonical(buf) {
+		return nil, fmt.Errorf("%w: non-canonical encoding", ErrInvalidScalar)
+	}
+	if err := x.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScalar, err)
+	}
+	return x, nil
+}
+
+type secp256k1Suite struct {
+	kyber.Group
+	order *big.Int
+}
+
+// Secp256k1 returns the FROST(secp256k1, SHA-256) ciphersuite. Its elements
+// are encoded as 33 bytes compressed SEC 1 points.
+func Secp256k1() Ciphersuite {
+	group := s256.NewSuite()
+	return &secp256k1Suite{Group: group, order: group.Order()}
+}
+
+func (s *secp256k1Suite) ContextString() string {
+	return "FROST-secp256k1-SHA256-v1"
+}
+
+// hashToField is hash_to_field of RFC 9380 with expand_message_xmd and
+// SHA-256, which reduces 48 bytes modulo the group order.
+func (s *secp256k1Suite) hashToField(tag string, m []byte) kyber.Scalar {
+	uniform, err := h2c.ExpandMessageXMD(sha256.New(), m, s.ContextString()+tag, 48)
+	if err != nil {
+		panic("frost: " + err.Error())
+	}
+	x := new(big.Int).SetBytes(uniform)
+	return s.Scalar().SetBytes(x.Mod(x, s.order).Bytes())
+}
+
+func (s *secp256k1Suite) hash(tag string, m []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(s.ContextString() + tag))
+	h.Write(m)
+	return h.Sum(nil)
+}
+
+func (s *secp256k1Suite) H1(m []byte) kyber.Scalar {
+	return s.hashToField("rho", m)
+}
+
+func (s *secp256k1Suite) H2(m []byte) kyber.Scalar {
+	return s.hashToField("chal", m)
+}
+
+func (s *secp256k1Suite) H3(m []byte) kyber.Scalar {
+	return s.hashToField("nonce", m)
+}
+
+func (s *secp256k1Suite) H4(m []byte) []byte {
+	return s.hash("msg", m)
+}
+
+func (s *secp256k1Suite) H5(m []byte) []byte {
+	return s.hash("com", m)
+}
+
+func (s *secp256k1Suite) SerializeElement(P kyber.Point) ([]byte, error) {
+	if P.Equal(s.Point().Null()) {
+		return nil, fmt.Errorf("%w: identity", ErrInvalidElement)
+	}
+	// uncompressed encoding 0x04 || x || y
+	buf, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	out := append([]byte{0x02 | buf[len(buf)-1]&1}, buf[1:33]...)
+	return out, nil
+}
+
+func (s *secp256k1Suite) DeserializeElement(buf []byte) (kyber.Point, error) {
+	if len(buf) != 33 || (buf[0] != 0x02 && buf[0] != 0x03) {
+		return nil, fmt.Errorf("%w: not a compressed point", ErrInvalidElement)
+	}
+	// LiftX returns the point with the even y-coordinate
+	P, err := bip340.LiftX(buf[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidElement, err)
+	}
+	if buf[0] == 0x03 {
+		P.Neg(P)
+	}
+	return P, nil
+}
+
+func (s *secp256k1Suite) SerializeScalar(x kyber.Scalar) ([]byte, error) {
+	return x.MarshalBinary()
+}
+
+func (s *secp256k1Suite) DeserializeScalar(buf []byte) (kyber.Scalar, error) {
+	x := s.Scalar()
+	if err := x.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidScalar, err)
+	}
+	return x, nil
+}
@@ -0,0 +1,439 @@
+/*
+Package frost implements FROST, the two-round threshold Schnorr signatures of
+RFC 9591 (https://www.rfc-editor.org/rfc/rfc9591).
+
+The signers hold the shares of a key generated by a distributed key
+generation, such as the DistKeyShare of share/dkg/pedersen. Their FROST
+identifiers are the evaluation points I+1 of their shares. A signature is
+produced in two rounds, usually driven by a coordinator:
+
+ 1. Every signer draws a single-use Nonce with Signer.Commit and sends the
+    matching Commitment to the coordinator. This round doesn't depend on the
+    message, so the signers can precompute many commitments in advance.
+ 2. The coordinator picks the commitments of at least t signers and sends
+    them to these signers with the message. Each of them returns its
+    SignatureShare, computed with Signer.Sign, which consumes the nonce.
+
+The coordinator then computes the signature with Aggregate, which is a plain
+Schnorr signature of the ciphersuite that can be checked with Verify. The
+signatures of the Ed25519 ciphersuite are Ed25519 signatures, which can be
+verified with sign/eddsa.
+
+Unlike sign/dss, there is no need for a distributed key generation per
+signature.
+*/
+package frost
+
+import (
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/share"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var (
+	// ErrInvalidCommitments is returned for a list of commitments which
+	// can't be used to sign.
+	ErrInvalidCommitments = errors.New("frost: invalid list of commitments")
+	// ErrNonceUsed is returned when signing twice with the same nonce.
+	ErrNonceUsed = errors.New("frost: nonce already used")
+	// ErrInvalidShare is returned for an invalid signature share.
+	ErrInvalidShare = errors.New("frost: invalid signature share")
+	// ErrInvalidSignature is returned when a signature doesn't verify.
+	ErrInvalidSignature = errors.New("frost: invalid signature")
+)
+
+// DistKeyShare is the key share of a signer, as given by the distributed
+// key generation of share/dkg/pedersen.
+type DistKeyShare interface {
+	PriShare() *share.PriShare
+	Commitments() []kyber.Point
+}
+
+// Commitment is the public commitment of a signer to its nonces.
+type Commitment struct {
+	// Index is the index of the share of the signer, whose FROST
+	// identifier is Index+1.
+	Index   uint32
+	Hiding  kyber.Point
+	Binding kyber.Point
+}
+
+// Nonce holds the secret nonces of a commitment. It must be kept secret and
+// can only be used to sign once.
+type Nonce struct {
+	hiding     kyber.Scalar
+	binding    kyber.Scalar
+	commitment *Commitment
+	used       bool
+}
+
+// Commitment returns the commitment to the nonces.
+func (n *Nonce) Commitment() *Commitment {
+	return n.commitment
+}
+
+// SignatureShare is the share of a signature issued by a signer.
+type SignatureShare struct {
+	// Index is the index of the share of the signer.
+	Index uint32
+	Z     kyber.Scalar
+}
+
+// Signer issues signature shares with a share of the group key.
+type Signer struct {
+	suite  Ciphersuite
+	share  *share.PriShare
+	public *share.PubPoly
+}
+
+// NewSigner returns the signer holding the key share, which must belong to
+// the group of the ciphersuite.
+func NewSigner(suite Ciphersuite, key DistKeyShare) (*Signer, error) {
+	commits := key.Commitments()
+	if len(commits) == 0 {
+		return nil, errors.New("frost: no commitments in the key share")
+	}
+	public := share.NewPubPoly(suite, nil, commits)
+	if !public.Check(key.PriShare()) {
+		return nil, errors.New("frost: the key share doesn't match its commitments")
+	}
+	return &Signer{
+		suite:  suite,
+		share:  key.PriShare(),
+		public: public,
+	}, nil
+}
+
+// Index returns the index of the share of the signer.
+func (s *Signer) Index() uint32 {
+	return s.share.I
+}
+
+// Public returns the public polynomial of the group key, whose commitment
+// is the group public key.
+func (s *Signer) Public() *share.PubPoly {
+	return s.public
+}
+
+// Commit runs the first round of the protocol and returns a fresh nonce
+// with its commitment. The nonces are derived from the randomness of the
+// stream and the secret share, as in RFC 9591, so that a weak random stream
+// alone doesn't leak the key. A nil stream uses crypto/rand.
+func (s *Signer) Commit(rand cipher.Stream) (*Nonce, error) {
+	if rand == nil {
+		rand = random.New()
+	}
+	hiding, err := s.nonceGenerate(rand)
+	if err != nil {
+		return nil, err
+	}
+	binding, err := s.nonceGenerate(rand)
+	if err != nil {
+		return nil, err
+	}
+	return &Nonce{
+		hiding:  hiding,
+		binding: binding,
+		commitment: &Commitment{
+			Index:   s.share.I,
+			Hiding:  s.suite.Point().Mul(hiding, nil),
+			Binding: s.suite.Point().Mul(binding, nil),
+		},
+	}, nil
+}
+
+func (s *Signer) nonceGenerate(rand cipher.Stream) (kyber.Scalar, error) {
+	secret, err := s.suite.SerializeScalar(s.share.V)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 32, 32+len(secret))
+	random.Bytes(buf, rand)
+	return s.suite.H3(append(buf, secret...)), nil
+}
+
+// Sign runs the second round of the protocol and returns the share of the
+// signature of msg by the signers of the commitments, which must include
+// the commitment of the nonce. The nonce can't be used again afterwards.
+func (s *Signer) Sign(msg []byte, nonce *Nonce, commitments []*Commitment) (*SignatureShare, error) {
+	if nonce.used {
+		return nil, ErrNonceUsed
+	}
+	list, err := sortCommitments(s.suite, s.public.Threshold(), commitments)
+	if err != nil {
+		return nil, err
+	}
+	own := -1
+	for i, c := range list {
+		if c.Index == s.share.I {
+			own = i
+		}
+	}
+	if own < 0 || !list[own].Hiding.Equal(nonce.commitment.Hiding) ||
+		!list[own].Binding.Equal(nonce.commitment.Binding) {
+		return nil, fmt.Errorf("%w: the commitment of the nonce is missing", ErrInvalidCommitments)
+	}
+
+	ctx, err := newSigningContext(s.suite, s.public.Commit(), msg, list)
+	if err != nil {
+		return nil, err
+	}
+	lambda := ctx.lagrange(own)
+
+	// z = hiding + binding * rho + lambda * secret * c
+	z := s.suite.Scalar().Mul(lambda, s.share.V)
+	z.Mul(z, ctx.challenge)
+	z.Add(z, s.suite.Scalar().Mul(nonce.binding, ctx.rhos[own]))
+	z.Add(z, nonce.hiding)
+
+	nonce.used = true
+	nonce.hiding.Zero()
+	nonce.binding.Zero()
+	return &SignatureShare{Index: s.share.I, Z: z}, nil
+}
+
+// VerifyShare returns nil if sig is a valid signature share of msg with the
+// commitments, for the group key of the public polynomial. The coordinator
+// can use it to identify the signers returning invalid shares.
+func VerifyShare(suite Ciphersuite, public *share.PubPoly, msg []byte, commitments []*Commitment,
+	sig *SignatureShare) error {
+	list, err := sortCommitments(suite, public.Threshold(), commitments)
+	if err != nil {
+		return err
+	}
+	ctx, err := newSigningContext(suite, public.Commit(), msg, list)
+	if err != nil {
+		return err
+	}
+	return ctx.verifyShare(public, sig)
+}
+
+// Aggregate returns the signature of msg aggregated from the signature
+// shares of the signers of the commitments, one per commitment. If the
+// signature is invalid, it returns a *sign.BatchError holding the invalid
+// shares.
+func Aggregate(suite Ciphersuite, public *share.PubPoly, msg []byte, commitments []*Commitment,
+	shares []*SignatureShare) ([]byte, error) {
+	list, err := sortCommitments(suite, public.Threshold(), commitments)
+	if err != nil {
+		return nil, err
+	}
+	if len(shares) != len(list) {
+		return nil, fmt.Errorf("frost: %d signature shares for %d commitments", len(shares), len(list))
+	}
+	ctx, err := newSigningContext(suite, public.Commit(), msg, list)
+	if err != nil {
+		return nil, err
+	}
+
+	z := suite.Scalar().Zero()
+	for _, sh := range shares {
+		z.Add(z, sh.Z)
+	}
+	sig, err := encodeSignature(suite, ctx.R, z)
+	if err != nil {
+		return nil, err
+	}
+	if Verify(suite, public.Commit(), msg, sig) == nil {
+		return sig, nil
+	}
+
+	invalid := make(map[int]error)
+	for i, sh := range shares {
+		if err := ctx.verifyShare(public, sh); err != nil {
+			invalid[i] = err
+		}
+	}
+	if len(invalid) == 0 {
+		// the shares are valid but some are repeated
+		return nil, fmt.Errorf("%w: the shares don't match the commitments", ErrInvalidSignature)
+	}
+	return nil, &sign.BatchError{Invalid: invalid}
+}
+
+// Verify returns nil if sig is a valid signature of msg by the group public
+// key, i.e. if z*B == R + c*public for the signature R || z.
+func Verify(suite Ciphersuite, public kyber.Point, msg, sig []byte) error {
+	elemLen := len(sig) - suite.ScalarLen()
+	if elemLen <= 0 {
+		return fmt.Errorf("%w: too short", ErrInvalidSignature)
+	}
+	R, err := suite.DeserializeElement(sig[:elemLen])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	z, err := suite.DeserializeScalar(sig[elemLen:])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	c, err := challenge(suite, R, public, msg)
+	if err != nil {
+		return err
+	}
+	left := suite.Point().Mul(z, nil)
+	right := suite.Point().Mul(c, public)
+	if !left.Equal(right.Add(right, R)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// signingContext holds the values shared by all the signers of a message.
+type signingContext struct {
+	suite       Ciphersuite
+	commitments []*Commitment
+	ids         []kyber.Scalar
+	rhos        []kyber.Scalar
+	R           kyber.Point
+	challenge   kyber.Scalar
+}
+
+// newSigningContext computes the binding factors, the group commitment and
+// the challenge of the sorted commitments.
+func newSigningContext(suite Ciphersuite, public kyber.Point, msg []byte,
+	commitments []*Commitment) (*signingContext, error) {
+	ctx := &signingContext{
+		suite:       suite,
+		commitments: commitments,
+		ids:         make([]kyber.Scalar, len(commitments)),
+		rhos:        make([]kyber.Scalar, len(commitments)),
+	}
+
+	// encode_group_commitment_list
+	var encoded []byte
+	encodedIDs := make([][]byte, len(commitments))
+	for i, c := range commitments {
+		ctx.ids[i] = suite.Scalar().SetInt64(int64(c.Index) + 1)
+		id, err := suite.SerializeScalar(ctx.ids[i])
+		if err != nil {
+			return nil, err
+		}
+		encodedIDs[i] = id
+		encoded = append(encoded, id...)
+		for _, P := range []kyber.Point{c.Hiding, c.Binding} {
+			buf, err := suite.SerializeElement(P)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, buf...)
+		}
+	}
+
+	// compute_binding_factors
+	prefix, err := suite.SerializeElement(public)
+	if err != nil {
+		return nil, err
+	}
+	prefix = append(prefix, suite.H4(msg)...)
+	prefix = append(prefix, suite.H5(encoded)...)
+	for i := range commitments {
+		input := append(append([]byte{}, prefix...), encodedIDs[i]...)
+		ctx.rhos[i] = suite.H1(input)
+	}
+
+	// compute_group_commitment
+	ctx.R = suite.Point().Null()
+	for i, c := range commitments {
+		ctx.R.Add(ctx.R, c.Hiding)
+		ctx.R.Add(ctx.R, suite.Point().Mul(ctx.rhos[i], c.Binding))
+	}
+
+	ctx.challenge, err = challenge(suite, ctx.R, public, msg)
+	if err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// lagrange returns the Lagrange coefficient of the i-th signer at zero.
+func (ctx *signingContext) lagrange(i int) kyber.Scalar {
+	num := ctx.suite.Scalar().One()
+	den := ctx.suite.Scalar().One()
+	for j, id := range ctx.ids {
+		if j == i {
+			continue
+		}
+		num.Mul(num, id)
+		den.Mul(den, ctx.suite.Scalar().Sub(id, ctx.ids[i]))
+	}
+	return num.Div(num, den)
+}
+
+// verifyShare checks that z*B == hiding + rho*binding + c*lambda*public_i.
+func (ctx *signingContext) verifyShare(public *share.PubPoly, sig *SignatureShare) error {
+	pos := -1
+	for i, c := range ctx.commitments {
+		if c.Index == sig.Index {
+			pos = i
+		}
+	}
+	if pos < 0 {
+		return fmt.Errorf("%w: no commitment of signer %d", ErrInvalidShare, sig.Index)
+	}
+	c := ctx.commitments[pos]
+	cl := ctx.suite.Scalar().Mul(ctx.challenge, ctx.lagrange(pos))
+	right := ctx.suite.Point().Mul(cl, public.Eval(sig.Index).V)
+	right.Add(right, c.Hiding)
+	right.Add(right, ctx.suite.Point().Mul(ctx.rhos[pos], c.Binding))
+	if !ctx.suite.Point().Mul(sig.Z, nil).Equal(right) {
+		return fmt.Errorf("%w: signer %d", ErrInvalidShare, sig.Index)
+	}
+	return nil
+}
+
+func challenge(suite Ciphersuite, R, public kyber.Point, msg []byte) (kyber.Scalar, error) {
+	r, err := suite.SerializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := suite.SerializeElement(public)
+	if err != nil {
+		return nil, err
+	}
+	return suite.H2(append(append(r, pub...), msg...)), nil
+}
+
+func encodeSignature(suite Ciphersuite, R kyber.Point, z kyber.Scalar) ([]byte, error) {
+	r, err := suite.SerializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := suite.SerializeScalar(z)
+	if err != nil {
+		return nil, err
+	}
+	return append(r, buf...), nil
+}
+
+// sortCommitments returns the commitments sorted by index, checking that
+// there are at least t of them, that the indices are distinct and that the
+// points are valid elements of the ciphersuite.
+func sortCommitments(suite Ciphersuite, t int, commitments []*Commitment) ([]*Commitment, error) {
+	if len(commitments) < t {
+		return nil, fmt.Errorf("%w: %d commitments for a threshold of %d", ErrInvalidCommitments,
+			len(commitments), t)
+	}
+	list := append([]*Commitment{}, commitments...)
+	sort.Slice(list, func(i, j int) bool { return list[i].Index < list[j].Index })
+	for i, c := range list {
+		if i > 0 && list[i-1].Index == c.Index {
+			return nil, fmt.Errorf("%w: repeated index %d", ErrInvalidCommitments, c.Index)
+		}
+		for _, P := range []kyber.Point{c.Hiding, c.Binding} {
+			buf, err := suite.SerializeElement(P)
+			if err == nil {
+				_, err = suite.DeserializeElement(buf)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%w: signer %d: %w", ErrInvalidCommitments, c.Index, err)
+			}
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,431 @@
+package frost
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/s256"
+	"go.dedis.ch/kyber/v4/share"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/sign/eddsa"
+	"go.dedis.ch/kyber/v4/sign/schnorr"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+// runDKG returns the key shares of n nodes running a pedersen DKG.
+func runDKG(t *testing.T, suite dkg.Suite, n, thr int) []*dkg.DistKeyShare {
+	privates := make([]kyber.Scalar, n)
+	nodes := make([]dkg.Node, n)
+	for i := range nodes {
+		privates[i] = suite.Scalar().Pick(random.New())
+		nodes[i] = dkg.Node{Index: uint32(i), Public: suite.Point().Mul(privates[i], nil)}
+	}
+	nonce := dkg.GetNonce()
+	gens := make([]*dkg.DistKeyGenerator, n)
+	var deals []*dkg.DealBundle
+	for i := range gens {
+		var err error
+		gens[i], err = dkg.NewDistKeyHandler(&dkg.Config{
+			Suite:     suite,
+			Longterm:  privates[i],
+			NewNodes:  nodes,
+			Threshold: thr,
+			Nonce:     nonce,
+			Auth:      schnorr.NewScheme(suite),
+		})
+		require.NoError(t, err)
+		deal, err := gens[i].Deals()
+		require.NoError(t, err)
+		deals = append(deals, deal)
+	}
+	var responses []*dkg.ResponseBundle
+	for _, gen := range gens {
+		resp, err := gen.ProcessDeals(deals)
+		require.NoError(t, err)
+		if resp != nil {
+			responses = append(responses, resp)
+		}
+	}
+	shares := make([]*dkg.DistKeyShare, n)
+	for _, gen := range gens {
+		res, _, err := gen.ProcessResponses(responses)
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		shares[res.Key.Share.I] = res.Key
+	}
+	return shares
+}
+
+// signers returns the signers of the key shares of a DKG.
+func signers(t *testing.T, suite Ciphersuite, dkgSuite dkg.Suite, n, thr int) []*Signer {
+	keys := runDKG(t, dkgSuite, n, thr)
+	signers := make([]*Signer, n)
+	for i, key := range keys {
+		var err error
+		signers[i], err = NewSigner(suite, key)
+		require.NoError(t, err)
+	}
+	return signers
+}
+
+// run returns the shares of the signature of msg by the signers, and their
+// commitments.
+func run(t *testing.T, signers []*Signer, msg []byte) ([]*Commitment, []*SignatureShare) {
+	nonces := make([]*Nonce, len(signers))
+	commitments := make([]*Commitment, len(signers))
+	for i, s := range signers {
+		var err error
+		nonces[i], err = s.Commit(nil)
+		require.NoError(t, err)
+		commitments[i] = nonces[i].Commitment()
+	}
+	shares := make([]*SignatureShare, len(signers))
+	for i, s := range signers {
+		var err error
+		shares[i], err = s.Sign(msg, nonces[i], commitments)
+		require.NoError(t, err)
+	}
+	return commitments, shares
+}
+
+func TestSignEd25519(t *testing.T) {
+	suite := Ed25519()
+	all := signers(t, suite, edwards25519.NewBlakeSHA256Ed25519(), 5, 3)
+	public := all[0].Public()
+	msg := []byte("Hello FROST")
+
+	for _, subset := range [][]*Signer{all[:3], {all[4], all[1], all[2]}, all} {
+		commitments, shares := run(t, subset, msg)
+		for _, sh := range shares {
+			require.NoError(t, VerifyShare(suite, public, msg, commitments, sh))
+		}
+		sig, err := Aggregate(suite, public, msg, commitments, shares)
+		require.NoError(t, err)
+		require.NoError(t, Verify(suite, public.Commit(), msg, sig))
+
+		// the signature is a standard Ed25519 signature
+		require.NoError(t, eddsa.Verify(public.Commit(), msg, sig))
+		pub, err := public.Commit().MarshalBinary()
+		require.NoError(t, err)
+		require.True(t, ed25519.Verify(pub, msg, sig))
+		require.Error(t, eddsa.Verify(public.Commit(), []byte("Hello"), sig))
+	}
+}
+
+func TestSignSecp256k1(t *testing.T) {
+	suite := Secp256k1()
+	all := signers(t, suite, s256.NewSuite(), 4, 3)
+	public := all[0].Public()
+	msg := []byte("Hello FROST")
+
+	for _, subset := range [][]*Signer{all[1:], all} {
+		commitments, shares := run(t, subset, msg)
+		sig, err := Aggregate(suite, public, msg, commitments, shares)
+		require.NoError(t, err)
+		require.Len(t, sig, 65)
+		require.NoError(t, Verify(suite, public.Commit(), msg, sig))
+		require.ErrorIs(t, Verify(suite, public.Commit(), []byte("Hello"), sig), ErrInvalidSignature)
+		require.ErrorIs(t, Verify(suite, public.Commit(), msg, sig[1:]), ErrInvalidSignature)
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	suite := Ed25519()
+	all := signers(t, suite, edwards25519.NewBlakeSHA256Ed25519(), 5, 3)
+	msg := []byte("Hello FROST")
+
+	nonces := make([]*Nonce, len(all))
+	commitments := make([]*Commitment, len(all))
+	for i, s := range all {
+		var err error
+		nonces[i], err = s.Commit(random.New())
+		require.NoError(t, err)
+		commitments[i] = nonces[i].Commitment()
+	}
+
+	// not enough commitments
+	_, err := all[0].Sign(msg, nonces[0], commitments[:2])
+	require.ErrorIs(t, err, ErrInvalidCommitments)
+	// missing commitment of the signer
+	_, err = all[0].Sign(msg, nonces[0], commitments[1:])
+	require.ErrorIs(t, err, ErrInvalidCommitments)
+	// repeated commitment
+	_, err = all[0].Sign(msg, nonces[0], append(commitments[:3:3], commitments[1]))
+	require.ErrorIs(t, err, ErrInvalidCommitments)
+	// invalid commitment
+	bad := *commitments[1]
+	bad.Binding = suite.Point().Null()
+	_, err = all[0].Sign(msg, nonces[0], []*Commitment{commitments[0], &bad, commitments[2]})
+	require.ErrorIs(t, err, ErrInvalidCommitments)
+	// commitment of another nonce
+	_, err = all[0].Sign(msg, nonces[0], []*Commitment{nonces[1].Commitment(), commitments[1], commitments[2]})
+	require.ErrorIs(t, err, ErrInvalidCommitments)
+
+	// a nonce can only be used once
+	_, err = all[0].Sign(msg, nonces[0], commitments)
+	require.NoError(t, err)
+	_, err = all[0].Sign(msg, nonces[0], commitments)
+	require.ErrorIs(t, err, ErrNonceUsed)
+
+	// the key share must match its commitments
+	key := &dkg.DistKeyShare{Share: all[0].share}
+	_, err = NewSigner(suite, key)
+	require.Error(t, err)
+	_, commits := all[0].Public().Info()
+	key = &dkg.DistKeyShare{Commits: commits, Share: &share.PriShare{I: 1, V: all[0].share.V}}
+	_, err = NewSigner(suite, key)
+	require.Error(t, err)
+}
+
+func TestAggregateInvalidShares(t *testing.T) {
+	suite := Secp256k1()
+	all := signers(t, suite, s256.NewSuite(), 5, 3)
+	public := all[0].Public()
+	msg := []byte("Hello FROST")
+
+	commitments, shares := run(t, all, msg)
+	shares[1].Z = suite.Scalar().Pick(random.New())
+	shares[3].Z.Add(shares[3].Z, suite.Scalar().One())
+	_, err := Aggregate(suite, public, msg, commitments, shares)
+	var batchErr *sign.BatchError
+	require.True(t, errors.As(err, &batchErr))
+	require.Equal(t, []int{1, 3}, batchErr.Indices())
+	require.ErrorIs(t, batchErr.Invalid[1], ErrInvalidShare)
+	require.ErrorIs(t, VerifyShare(suite, public, msg, commitments, shares[3]), ErrInvalidShare)
+	require.NoError(t, VerifyShare(suite, public, msg, commitments, shares[0]))
+
+	_, err = Aggregate(suite, public, msg, commitments, shares[1:])
+	require.Error(t, err)
+}
+
+func TestEd25519Elements(t *testing.T) {
+	suite := Ed25519()
+	P := suite.Point().Pick(random.New())
+	buf, err := suite.SerializeElement(P)
+	require.NoError(t, err)
+	Q, err := suite.DeserializeElement(buf)
+	require.NoError(t, err)
+	require.True(t, P.Equal(Q))
+
+	_, err = suite.SerializeElement(suite.Point().Null())
+	require.ErrorIs(t, err, ErrInvalidElement)
+	null, err := suite.Point().Null().MarshalBinary()
+	require.NoError(t, err)
+	_, err = suite.DeserializeElement(null)
+	require.ErrorIs(t, err, ErrInvalidElement)
+
+	// a point of order 8, and its sum with P, aren't in the prime-order
+	// subgroup
+	torsion, err := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	require.NoError(t, err)
+	T := suite.Point()
+	require.NoError(t, T.UnmarshalBinary(torsion))
+	require.True(t, suite.Point().Mul(suite.Scalar().SetInt64(8), T).Equal(suite.Point().Null()))
+	_, err = suite.DeserializeElement(torsion)
+	require.ErrorIs(t, err, ErrInvalidElement)
+	buf, err = suite.Point().Add(P, T).MarshalBinary()
+	require.NoError(t, err)
+	_, err = suite.DeserializeElement(buf)
+	require.ErrorIs(t, err, ErrInvalidElement)
+
+	// the group order isn't a canonical scalar
+	order, err := hex.DecodeString("edd3f55c1a631258d69cf7a2def9de1400000000000000000000000000000010")
+	require.NoError(t, err)
+	_, err = suite.DeserializeScalar(order)
+	require.ErrorIs(t, err, ErrInvalidScalar)
+}
+
+func TestSecp256k1Elements(t *testing.T) {
+	suite := Secp256k1()
+	for i := 0; i < 8; i++ {
+		P := suite.Point().Pick(random.New())
+		buf, err := suite.SerializeElement(P)
+		require.NoError(t, err)
+		require.Len(t, buf, 33)
+		Q, err := suite.DeserializeElement(buf)
+		require.NoError(t, err)
+		require.True(t, P.Equal(Q))
+	}
+
+	// the compressed encoding of the generator
+	buf, err := suite.SerializeElement(suite.Point().Base())
+	require.NoError(t, err)
+	require.Equal(t, "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", hex.EncodeToString(buf))
+
+	_, err = suite.SerializeElement(suite.Point().Null())
+	require.ErrorIs(t, err, ErrInvalidElement)
+	buf[0] = 0x04
+	_, err = suite.DeserializeElement(buf)
+	require.ErrorIs(t, err, ErrInvalidElement)
+	_, err = suite.DeserializeElement(make([]byte, 33))
+	require.ErrorIs(t, err, ErrInvalidElement)
+
+	order, err := hex.DecodeString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	require.NoError(t, err)
+	_, err = suite.DeserializeScalar(order)
+	require.ErrorIs(t, err, ErrInvalidScalar)
+}
+
+// vectorStream is a cipher.Stream returning fixed bytes, the nonce
+// randomness of the test vectors.
+type vectorStream struct {
+	buf []byte
+}
+
+func (v *vectorStream) XORKeyStream(dst, src []byte) {
+	for i := range dst {
+		dst[i] = src[i] ^ v.buf[i]
+	}
+	v.buf = v.buf[len(dst):]
+}
+
+// vectorShare is a key share of the test vectors.
+type vectorShare struct {
+	share   *share.PriShare
+	commits []kyber.Point
+}
+
+func (v *vectorShare) PriShare() *share.PriShare  { return v.share }
+func (v *vectorShare) Commitments() []kyber.Point { return v.commits }
+
+// Test vectors of RFC 9591, Appendix E.1 and E.5, with the signers 1 and 3
+// of a 2-of-3 key.
+func TestVectors(t *testing.T) {
+	for _, v := range []struct {
+		suite       Ciphersuite
+		secret      string
+		coefficient string
+		public      string
+		shares      [3]string
+		randomness  [2][2]string
+		nonces      [2][2]string
+		sigShares   [2]string
+		signature   string
+	}{{
+		suite:       Ed25519(),
+		secret:      "7b1c33d3f5291d85de664833beb1ad469f7fb6025a0ec78b3a790c6e13a98304",
+		coefficient: "178199860edd8c62f5212ee91eff1295d0d670ab4ed4506866bae57e7030b204",
+		public:      "15d21ccd7ee42959562fc8aa63224c8851fb3ec85a3faf66040d380fb9738673",
+		shares: [3]string{
+			"929dcc590407aae7d388761cddb0c0db6f5627aea8e217f4a033f2ec83d93509",
+			"a91e66e012e4364ac9aaa405fcafd370402d9859f7b6685c07eed76bf409e80d",
+			"d3cb090a075eb154e82fdb4b3cb507f110040905468bb9c46da8bdea643a9a02",
+		},
+		randomness: [2][2]string{{
+			"0fd2e39e111cdc266f6c0f4d0fd45c947761f1f5d3cb583dfcb9bbaf8d4c9fec",
+			"69cd85f631d5f7f2721ed5e40519b1366f340a87c2f6856363dbdcda348a7501",
+		}, {
+			"86d64a260059e495d0fb4fcc17ea3da7452391baa494d4b00321098ed2a0062f",
+			"13e6b25afb2eba51716a9a7d44130c0dbae0004a9ef8d7b5550c8a0e07c61775",
+		}},
+		nonces: [2][2]string{{
+			"812d6104142944d5a55924de6d49940956206909f2acaeedecda2b726e630407",
+			"b1110165fc2334149750b28dd813a39244f315cff14d4e89e6142f262ed83301",
+		}, {
+			"c256de65476204095ebdc01bd11dc10e57b36bc96284595b8215222374f99c0e",
+			"243d71944d929063bc51205714ae3c2218bd3451d0214dfb5aeec2a90c35180d",
+		}},
+		sigShares: [2]string{
+			"001719ab5a53ee1a12095cd088fd149702c0720ce5fd2f29dbecf24b7281b603",
+			"bd86125de990acc5e1f13781d8e32c03a9bbd4c53539bbc106058bfd14326007",
+		},
+		signature: "36282629c383bb820a88b71cae937d41f2f2adfcc3d02e55507e2fb9e2dd3cbe" +
+			"bd9d2b0844e49ae0f3fa935161e1419aab7b47d21a37ebeae1f17d4987b3160b",
+	}, {
+		suite:       Secp256k1(),
+		secret:      "0d004150d27c3bf2a42f312683d35fac7394b1e9e318249c1bfe7f0795a83114",
+		coefficient: "fbf85eadae3058ea14f19148bb72b45e4399c0b16028acaf0395c9b03c823579",
+		public:      "02f37c34b66ced1fb51c34a90bdae006901f10625cc06c4f64663b0eae87d87b4f",
+		shares: [3]string{
+			"08f89ffe80ac94dcb920c26f3f46140bfc7f95b493f8310f5fc1ea2b01f4254c",
+			"04f0feac2edcedc6ce1253b7fab8c86b856a797f44d83d82a385554e6e401984",
+			"00e95d59dd0d46b0e303e500b62b7ccb0e555d49f5b849f5e748c071da8c0dbc",
+		},
+		randomness: [2][2]string{{
+			"7ea5ed09af19f6ff21040c07ec2d2adbd35b759da5a401d4c99dd26b82391cb2",
+			"47acab018f116020c10cb9b9abdc7ac10aae1b48ca6e36dc15acb6ec9be5cdc5",
+		}, {
+			"e6cc56ccbd0502b3f6f831d91e2ebd01c4de0479e0191b66895a4ffd9b68d544",
+			"7203d55eb82a5ca0d7d83674541ab55f6e76f1b85391d2c13706a89a064fd5b9",
+		}},
+		nonces: [2][2]string{{
+			"841d3a6450d7580b4da83c8e618414d0f024391f2aeb511d7579224420aa81f0",
+			"8d2624f532af631377f33cf44b5ac5f849067cae2eacb88680a31e77c79b5a80",
+		}, {
+			"2b19b13f193f4ce83a399362a90cdc1e0ddcd83e57089a7af0bdca71d47869b2",
+			"7a443bde83dc63ef52dda354005225ba0e553243402a4705ce28ffaafe0f5b98",
+		}},
+		sigShares: [2]string{
+			"c4fce1775a1e141fb579944166eab0d65eefe7b98d480a569bbbfcb14f91c197",
+			"0160fd0d388932f4826d2ebcd6b9eaba734f7c71cf25b4279a4ca2581e47b18d",
+		},
+		signature: "0205b6d04d3774c8929413e3c76024d54149c372d57aae62574ed74319b5ea14d0" +
+			"c65dde8492a7471437e6c2fe3da49b90d23f642b5c6dbe7e36089f096dd97324",
+	}} {
+		suite := v.suite
+		t.Run(suite.ContextString(), func(t *testing.T) {
+			scalar := func(s string) kyber.Scalar {
+				buf, err := hex.DecodeString(s)
+				require.NoError(t, err)
+				x, err := suite.DeserializeScalar(buf)
+				require.NoError(t, err)
+				return x
+			}
+			encode := func(m kyber.Marshaling) string {
+				var buf []byte
+				var err error
+				switch m := m.(type) {
+				case kyber.Point:
+					buf, err = suite.SerializeElement(m)
+				case kyber.Scalar:
+					buf, err = suite.SerializeScalar(m)
+				}
+				require.NoError(t, err)
+				return hex.EncodeToString(buf)
+			}
+
+			secret, coefficient := scalar(v.secret), scalar(v.coefficient)
+			commits := []kyber.Point{suite.Point().Mul(secret, nil), suite.Point().Mul(coefficient, nil)}
+			require.Equal(t, v.public, encode(commits[0]))
+			poly := share.CoefficientsToPriPoly(suite, []kyber.Scalar{secret, coefficient})
+			for i, s := range poly.Shares(3) {
+				require.Equal(t, v.shares[i], encode(s.V))
+			}
+
+			msg := []byte("test")
+			var signers []*Signer
+			var nonces []*Nonce
+			var commitments []*Commitment
+			for k, i := range []uint32{0, 2} {
+				signer, err := NewSigner(suite, &vectorShare{poly.Eval(i), commits})
+				require.NoError(t, err)
+				randomness, err := hex.DecodeString(v.randomness[k][0] + v.randomness[k][1])
+				require.NoError(t, err)
+				nonce, err := signer.Commit(&vectorStream{randomness})
+				require.NoError(t, err)
+				require.Equal(t, v.nonces[k][0], encode(nonce.hiding))
+				require.Equal(t, v.nonces[k][1], encode(nonce.binding))
+				signers = append(signers, signer)
+				nonces = append(nonces, nonce)
+				commitments = append(commitments, nonce.Commitment())
+			}
+
+			var shares []*SignatureShare
+			for k, signer := range signers {
+				sh, err := signer.Sign(msg, nonces[k], commitments)
+				require.NoError(t, err)
+				require.Equal(t, v.sigShares[k], encode(sh.Z))
+				shares = append(shares, sh)
+			}
+			sig, err := Aggregate(suite, signers[0].Public(), msg, commitments, shares)
+			require.NoError(t, err)
+			require.Equal(t, v.signature, hex.EncodeToString(sig))
+		})
+	}
+}
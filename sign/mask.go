@@ -0,0 +1,360 @@
+package sign
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Mask is a participation bitmask over a fixed number of candidates, e.g.
+// the signers of a collective signature. The bit of candidate i is the bit
+// i&7 of byte i/8 of the bitmask, as in the signatures of the cosi and bdn
+// packages. It implements ParticipationMask.
+type Mask struct {
+	n    int
+	bits []byte
+}
+
+// Formats of the binary encoding of the masks.
+const (
+	maskBitmap byte = iota
+	maskRuns
+)
+
+// maxMaskCandidates bounds the number of candidates of the decoded masks.
+const maxMaskCandidates = 1 << 24
+
+// ErrMaskLength is returned when combining masks of different numbers of
+// candidates, or setting a bitmask of the wrong length.
+var ErrMaskLength = errors.New("mask: mismatching mask lengths")
+
+// NewMask returns a mask of n candidates with no participant.
+func NewMask(n int) *Mask {
+	if n < 0 {
+		n = 0
+	}
+	return &Mask{n: n, bits: make([]byte, (n+7)/8)}
+}
+
+// NewMaskFromBytes returns the mask of n candidates with the bitmask.
+func NewMaskFromBytes(n int, bitmask []byte) (*Mask, error) {
+	m := NewMask(n)
+	if err := m.SetBytes(bitmask); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Bytes returns a copy of the bitmask.
+func (m *Mask) Bytes() []byte {
+	return append([]byte{}, m.bits...)
+}
+
+// SetBytes replaces the bitmask if the length matches. The bits past the
+// last candidate are ignored.
+func (m *Mask) SetBytes(bitmask []byte) error {
+	if len(bitmask) != len(m.bits) {
+		return ErrMaskLength
+	}
+	copy(m.bits, bitmask)
+	if r := m.n & 7; r != 0 {
+		m.bits[len(m.bits)-1] &= byte(1)<<r - 1
+	}
+	return nil
+}
+
+// Len returns the length in bytes of the bitmask.
+func (m *Mask) Len() int {
+	return len(m.bits)
+}
+
+// CountTotal returns the number of candidates.
+func (m *Mask) CountTotal() int {
+	return m.n
+}
+
+// CountEnabled returns the number of participants.
+func (m *Mask) CountEnabled() int {
+	count := 0
+	for _, b := range m.bits {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
+// IndexEnabled returns true if the candidate i participates.
+func (m *Mask) IndexEnabled(i int) (bool, error) {
+	if i < 0 || i >= m.n {
+		return false, errors.New("index out of range")
+	}
+	return m.bits[i>>3]&(byte(1)<<uint(i&7)) != 0, nil
+}
+
+// SetBit enables or disables the candidate i.
+func (m *Mask) SetBit(i int, enable bool) error {
+	if i < 0 || i >= m.n {
+		return errors.New("index out of range")
+	}
+	if enable {
+		m.bits[i>>3] |= byte(1) << uint(i&7)
+	} else {
+		m.bits[i>>3] &^= byte(1) << uint(i&7)
+	}
+	return nil
+}
+
+// ForEachEnabled calls f with the index of every participant in increasing
+// order, until f returns false.
+func (m *Mask) ForEachEnabled(f func(i int) bool) {
+	for k, b := range m.bits {
+		for b != 0 {
+			j := bits.TrailingZeros8(b)
+			if !f(k*8 + j) {
+				return
+			}
+			b &= b - 1
+		}
+	}
+}
+
+// EnabledIndices returns the indices of the participants in increasing
+// order.
+func (m *Mask) EnabledIndices() []int {
+	indices := make([]int, 0, m.CountEnabled())
+	m.ForEachEnabled(func(i int) bool {
+		indices = append(indices, i)
+		return true
+	})
+	return indices
+}
+
+// IndexOfNthEnabled returns the index of the nth participant, counting
+// from 0, or -1 if there are not enough participants.
+func (m *Mask) IndexOfNthEnabled(nth int) int {
+	index, n := -1, 0
+	m.ForEachEnabled(func(i int) bool {
+		if n == nth {
+			index = i
+			return false
+		}
+		n++
+		return true
+	})
+	return index
+}
+
+// NthEnabledAtIndex returns the number of participants before the index
+// if the candidate at the index participates, and -1 otherwise.
+func (m *Mask) NthEnabledAtIndex(idx int) int {
+	nth, n := -1, 0
+	m.ForEachEnabled(func(i int) bool {
+		if i == idx {
+			nth = n
+		}
+		n++
+		return i < idx
+	})
+	return nth
+}
+
+// Clone returns a copy of the mask.
+func (m *Mask) Clone() *Mask {
+	return &Mask{n: m.n, bits: m.Bytes()}
+}
+
+// Equal returns true if both masks have the same candidates and
+// participants.
+func (m *Mask) Equal(other *Mask) bool {
+	if m.n != other.n {
+		return false
+	}
+	return bytes.Equal(m.bits, other.bits)
+}
+
+// Union enables the participants of the other mask.
+func (m *Mask) Union(other *Mask) error {
+	return m.combine(other, func(a, b byte) byte { return a | b })
+}
+
+// Intersect disables the candidates which don't participate in the other
+// mask.
+func (m *Mask) Intersect(other *Mask) error {
+	return m.combine(other, func(a, b byte) byte { return a & b })
+}
+
+// Difference disables the participants of the other mask.
+func (m *Mask) Difference(other *Mask) error {
+	return m.combine(other, func(a, b byte) byte { return a &^ b })
+}
+
+func (m *Mask) combine(other *Mask, op func(a, b byte) byte) error {
+	if m.n != other.n {
+		return ErrMaskLength
+	}
+	for i := range m.bits {
+		m.bits[i] = op(m.bits[i], other.bits[i])
+	}
+	return nil
+}
+
+// runs returns the lengths of the alternating runs of non-participants and
+// participants, starting with non-participants.
+func (m *Mask) runs() []int {
+	var runs []int
+	enabled, length := false, 0
+	for i := 0; i < m.n; i++ {
+		if (m.bits[i>>3]&(byte(1)<<uint(i&7)) != 0) != enabled {
+			runs = append(runs, length)
+			enabled, length = !enabled, 0
+		}
+		length++
+	}
+	if length > 0 {
+		runs = append(runs, length)
+	}
+	return runs
+}
+
+// setRuns sets the participants from the lengths of the runs, which must
+// add up to the number of candidates.
+func (m *Mask) setRuns(runs []uint64) error {
+	for i := range m.bits {
+		m.bits[i] = 0
+	}
+	index := uint64(0)
+	for k, length := range runs {
+		if length > uint64(m.n)-index {
+			return errors.New("mask: runs longer than the mask")
+		}
+		if k%2 == 1 {
+			for i := index; i < index+length; i++ {
+				m.bits[i>>3] |= byte(1) << uint(i&7)
+			}
+		}
+		index += length
+	}
+	if index != uint64(m.n) {
+		return errors.New("mask: runs shorter than the mask")
+	}
+	return nil
+}
+
+// MarshalBinary encodes the mask in its most compact format: either the
+// bitmask, or the run-length encoding of the participants which is much
+// shorter for large committees where most members participate, or not.
+func (m *Mask) MarshalBinary() ([]byte, error) {
+	header := binary.AppendUvarint(nil, uint64(m.n))
+	runs := m.runs()
+	encoded := binary.AppendUvarint(nil, uint64(len(runs)))
+	for _, length := range runs {
+		encoded = binary.AppendUvarint(encoded, uint64(length))
+		if len(encoded) >= len(m.bits) {
+			break
+		}
+	}
+	if len(encoded) < len(m.bits) {
+		return append(append([]byte{maskRuns}, header...), encoded...), nil
+	}
+	return append(append([]byte{maskBitmap}, header...), m.bits...), nil
+}
+
+// UnmarshalBinary decodes a mask encoded by MarshalBinary.
+func (m *Mask) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("mask: empty encoding")
+	}
+	format, data := data[0], data[1:]
+	n, read := binary.Uvarint(data)
+	if read <= 0 || n > maxMaskCandidates {
+		return errors.New("mask: invalid number of candidates")
+	}
+	data = data[read:]
+	decoded := NewMask(int(n))
+
+	switch format {
+	case maskBitmap:
+		if len(data) != decoded.Len() {
+			return ErrMaskLength
+		}
+		if err := decoded.SetBytes(data); err != nil {
+			return err
+		}
+		if !bytes.Equal(decoded.bits, data) {
+			return errors.New("mask: bits set past the last candidate")
+		}
+	case maskRuns:
+		count, read := binary.Uvarint(data)
+		if read <= 0 || count > uint64(len(data)) {
+			return errors.New("mask: invalid number of runs")
+		}
+		data = data[read:]
+		runs := make([]uint64, count)
+		for i := range runs {
+			runs[i], read = binary.Uvarint(data)
+			if read <= 0 {
+				return errors.New("mask: invalid run")
+			}
+			data = data[read:]
+		}
+		if len(data) != 0 {
+			return errors.New("mask: trailing data")
+		}
+		if err := decoded.setRuns(runs); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("mask: unknown format %d", format)
+	}
+	*m = *decoded
+	return nil
+}
+
+// maskJSON is the JSON encoding of a mask, where the participants are
+// given as half-open ranges [start, end) of indices.
+type maskJSON struct {
+	Total   int      `json:"total"`
+	Enabled [][2]int `json:"enabled"`
+}
+
+// MarshalJSON encodes the mask as its number of candidates and the ranges
+// of participants, e.g. {"total":10,"enabled":[[0,3],[5,6]]} for the
+// participants 0, 1, 2 and 5.
+func (m *Mask) MarshalJSON() ([]byte, error) {
+	enc := maskJSON{Total: m.n, Enabled: [][2]int{}}
+	index := 0
+	for k, length := range m.runs() {
+		if k%2 == 1 {
+			enc.Enabled = append(enc.Enabled, [2]int{index, index + length})
+		}
+		index += length
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON decodes a mask encoded by MarshalJSON. The ranges must be
+// sorted and disjoint.
+func (m *Mask) UnmarshalJSON(data []byte) error {
+	var enc maskJSON
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+	if enc.Total < 0 || enc.Total > maxMaskCandidates {
+		return errors.New("mask: invalid number of candidates")
+	}
+	decoded := NewMask(enc.Total)
+	last := 0
+	for _, r := range enc.Enabled {
+		if r[0] < last || r[1] <= r[0] || r[1] > enc.Total {
+			return fmt.Errorf("mask: invalid range [%d, %d)", r[0], r[1])
+		}
+		for i := r[0]; i < r[1]; i++ {
+			decoded.bits[i>>3] |= byte(1) << uint(i&7)
+		}
+		last = r[1]
+	}
+	*m = *decoded
+	return nil
+}
@@ -0,0 +1,154 @@
+package sign
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var _ ParticipationMask = (*Mask)(nil)
+
+func maskOf(t *testing.T, n int, indices ...int) *Mask {
+	m := NewMask(n)
+	for _, i := range indices {
+		require.NoError(t, m.SetBit(i, true))
+	}
+	return m
+}
+
+func TestMask_Bits(t *testing.T) {
+	m := maskOf(t, 17, 2, 10, 16)
+	require.Equal(t, 3, m.Len())
+	require.Equal(t, []byte{0x4, 0x4, 0x1}, m.Bytes())
+	require.Equal(t, 3, m.CountEnabled())
+	require.Equal(t, 17, m.CountTotal())
+	require.Equal(t, []int{2, 10, 16}, m.EnabledIndices())
+
+	enabled, err := m.IndexEnabled(10)
+	require.NoError(t, err)
+	require.True(t, enabled)
+	_, err = m.IndexEnabled(17)
+	require.Error(t, err)
+	require.Error(t, m.SetBit(-1, true))
+
+	require.NoError(t, m.SetBit(10, false))
+	require.Equal(t, []int{2, 16}, m.EnabledIndices())
+
+	// the bits past the last candidate are ignored
+	require.NoError(t, m.SetBytes([]byte{0, 0, 0xff}))
+	require.Equal(t, []int{16}, m.EnabledIndices())
+	require.ErrorIs(t, m.SetBytes([]byte{0, 0}), ErrMaskLength)
+}
+
+func TestMask_Iteration(t *testing.T) {
+	m := maskOf(t, 20, 1, 3, 8, 19)
+	var visited []int
+	m.ForEachEnabled(func(i int) bool {
+		visited = append(visited, i)
+		return i < 8
+	})
+	require.Equal(t, []int{1, 3, 8}, visited)
+
+	require.Equal(t, 8, m.IndexOfNthEnabled(2))
+	require.Equal(t, -1, m.IndexOfNthEnabled(4))
+	require.Equal(t, 3, m.NthEnabledAtIndex(19))
+	require.Equal(t, -1, m.NthEnabledAtIndex(2))
+}
+
+func TestMask_SetOperations(t *testing.T) {
+	a := maskOf(t, 12, 0, 1, 2, 9)
+	b := maskOf(t, 12, 2, 3, 9, 11)
+
+	union := a.Clone()
+	require.NoError(t, union.Union(b))
+	require.Equal(t, []int{0, 1, 2, 3, 9, 11}, union.EnabledIndices())
+
+	inter := a.Clone()
+	require.NoError(t, inter.Intersect(b))
+	require.Equal(t, []int{2, 9}, inter.EnabledIndices())
+
+	diff := a.Clone()
+	require.NoError(t, diff.Difference(b))
+	require.Equal(t, []int{0, 1}, diff.EnabledIndices())
+
+	// the operands are unchanged
+	require.True(t, a.Equal(maskOf(t, 12, 0, 1, 2, 9)))
+	require.False(t, a.Equal(b))
+
+	require.ErrorIs(t, a.Union(NewMask(13)), ErrMaskLength)
+}
+
+func TestMask_Binary(t *testing.T) {
+	large := NewMask(10000)
+	for i := 0; i < 10000; i++ {
+		if i < 4000 || i > 4100 {
+			require.NoError(t, large.SetBit(i, true))
+		}
+	}
+	sparse := NewMask(10000)
+	require.NoError(t, sparse.SetBit(7777, true))
+
+	for _, m := range []*Mask{
+		NewMask(0), maskOf(t, 17, 2, 10, 16), maskOf(t, 9, 0, 2, 4, 6, 8), large, sparse,
+	} {
+		buf, err := m.MarshalBinary()
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(buf), 1+3+m.Len())
+		decoded := new(Mask)
+		require.NoError(t, decoded.UnmarshalBinary(buf))
+		require.True(t, m.Equal(decoded))
+	}
+
+	// the run-length encoding of large committees is compact
+	buf, err := large.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, maskRuns, buf[0])
+	require.Less(t, len(buf), 12)
+
+	for _, bad := range [][]byte{
+		nil,
+		{maskBitmap},
+		{maskBitmap, 9, 0xff},
+		{maskBitmap, 9, 0xff, 0x02},
+		{maskRuns, 10, 2, 4, 7},
+		{maskRuns, 10, 2, 4, 5},
+		{maskRuns, 10, 2, 4, 6, 0},
+		{2, 0},
+	} {
+		require.Error(t, new(Mask).UnmarshalBinary(bad), "%x", bad)
+	}
+}
+
+func TestMask_JSON(t *testing.T) {
+	m := maskOf(t, 10, 0, 1, 2, 5)
+	buf, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total":10,"enabled":[[0,3],[5,6]]}`, string(buf))
+
+	decoded := new(Mask)
+	require.NoError(t, json.Unmarshal(buf, decoded))
+	require.True(t, m.Equal(decoded))
+
+	buf, err = json.Marshal(NewMask(3))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total":3,"enabled":[]}`, string(buf))
+
+	for _, bad := range []string{
+		`{"total":10,"enabled":[[0,3],[2,6]]}`,
+		`{"total":10,"enabled":[[5,11]]}`,
+		`{"total":10,"enabled":[[4,4]]}`,
+		`{"total":-1,"enabled":[]}`,
+		`{"total":16777217,"enabled":[]}`,
+	} {
+		require.Error(t, json.Unmarshal([]byte(bad), new(Mask)), bad)
+	}
+}
+
+func TestMask_Policy(t *testing.T) {
+	m := maskOf(t, 4, 0, 1, 2)
+	require.False(t, CompletePolicy{}.Check(m))
+	require.True(t, NewThresholdPolicy(3).Check(m))
+	require.NoError(t, m.SetBit(3, true))
+	require.True(t, CompletePolicy{}.Check(m))
+}
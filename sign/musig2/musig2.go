@@ -0,0 +1,416 @@
+/*
+Package musig2 implements the MuSig2 n-of-n multi-signatures of Nick,
+Ruffing and Seurin (https://eprint.iacr.org/2020/1261), following the
+algorithms of BIP-327
+(https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki).
+
+The signers aggregate their public keys with AggregateKeys, which weights
+every key with a coefficient to prevent rogue key attacks. A signature is
+then produced in two rounds:
+
+ 1. Every signer draws a secret nonce with GenerateNonce and broadcasts its
+    PublicNonce. This round doesn't depend on the message, so the nonces can
+    be generated in advance.
+ 2. Once all the public nonces are known, anyone aggregates them with
+    AggregateNonces. Every signer creates a Session with the aggregate nonce
+    and the message, and broadcasts its partial signature returned by
+    Session.Sign, which consumes the secret nonce.
+
+The partial signatures are combined with Session.Aggregate into an ordinary
+Schnorr signature by the aggregate key: a BIP-340 signature in the BIP327
+mode, or an Ed25519 signature in the Ed25519 mode.
+
+Unlike sign/cosi, concurrent signing sessions are secure, and no tree of
+signers is needed.
+*/
+package musig2
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var (
+	// ErrInvalidNonce is returned when signing with a secret nonce which
+	// has already been used or doesn't belong to the signer.
+	ErrInvalidNonce = errors.New("musig2: invalid secret nonce")
+	// ErrUnknownKey is returned when signing with a key which isn't one of
+	// the aggregated keys.
+	ErrUnknownKey = errors.New("musig2: public key not in the aggregate key")
+	// ErrInvalidPartialSignature is returned for an invalid partial
+	// signature.
+	ErrInvalidPartialSignature = errors.New("musig2: invalid partial signature")
+)
+
+// SortKeys returns the public keys sorted by their encodings, which is the
+// order of KeySort in BIP-327. The signers may agree on any other order, as
+// the aggregate key depends on the order of the keys.
+func SortKeys(suite Suite, publics []kyber.Point) ([]kyber.Point, error) {
+	encoded := make([][]byte, len(publics))
+	for i, P := range publics {
+		buf, err := suite.EncodePoint(P)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = buf
+	}
+	sorted := append([]kyber.Point{}, publics...)
+	sort.Sort(&keySorter{sorted, encoded})
+	return sorted, nil
+}
+
+type keySorter struct {
+	keys    []kyber.Point
+	encoded [][]byte
+}
+
+func (k *keySorter) Len() int { return len(k.keys) }
+
+func (k *keySorter) Less(i, j int) bool { return bytes.Compare(k.encoded[i], k.encoded[j]) < 0 }
+
+func (k *keySorter) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.encoded[i], k.encoded[j] = k.encoded[j], k.encoded[i]
+}
+
+// AggregateKey is the aggregation of the public keys of the signers.
+type AggregateKey struct {
+	suite   Suite
+	public  kyber.Point
+	keys    [][]byte
+	coeffs  []kyber.Scalar
+	encoded []byte
+}
+
+// AggregateKeys returns the aggregation Q = a_1*P_1 + ... + a_n*P_n of the
+// public keys, where the coefficients a_i are derived from the hash of all
+// the keys, except the one of the second distinct key which is 1.
+func AggregateKeys(suite Suite, publics []kyber.Point) (*AggregateKey, error) {
+	if len(publics) == 0 {
+		return nil, errors.New("musig2: no public keys")
+	}
+	keys := make([][]byte, len(publics))
+	for i, P := range publics {
+		if P.Equal(suite.Point().Null()) {
+			return nil, fmt.Errorf("%w: public key %d is the identity", ErrInvalidPoint, i)
+		}
+		buf, err := suite.EncodePoint(P)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = buf
+	}
+
+	L := suite.Hash("KeyAgg list", keys...)
+	var second []byte
+	for _, key := range keys[1:] {
+		if !bytes.Equal(key, keys[0]) {
+			second = key
+			break
+		}
+	}
+	k := &AggregateKey{
+		suite:  suite,
+		public: suite.Point().Null(),
+		keys:   keys,
+		coeffs: make([]kyber.Scalar, len(keys)),
+	}
+	for i, key := range keys {
+		if bytes.Equal(key, second) {
+			k.coeffs[i] = suite.Scalar().One()
+		} else {
+			k.coeffs[i] = suite.Scalar().SetBytes(suite.Hash("KeyAgg coefficient", L, key))
+		}
+		k.public.Add(k.public, suite.Point().Mul(k.coeffs[i], publics[i]))
+	}
+	if k.public.Equal(suite.Point().Null()) {
+		return nil, errors.New("musig2: the aggregate key is the identity")
+	}
+	var err error
+	k.encoded, err = suite.SignatureBytes(k.public)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Public returns the aggregate public key. In the BIP327 mode, the
+// signatures are by its x-only encoding.
+func (k *AggregateKey) Public() kyber.Point {
+	return k.public
+}
+
+// coefficient returns the coefficient of the encoded public key.
+func (k *AggregateKey) coefficient(key []byte) (kyber.Scalar, error) {
+	for i, other := range k.keys {
+		if bytes.Equal(key, other) {
+			return k.coeffs[i], nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+// PublicNonce is the pair of public nonces of a signer, or the aggregation
+// of the public nonces of all the signers.
+type PublicNonce struct {
+	R1, R2 kyber.Point
+}
+
+// SecretNonce is the pair of secret nonces of a signer. It must be kept
+// secret and can only be used to sign once.
+type SecretNonce struct {
+	k1, k2 kyber.Scalar
+	public []byte
+	used   bool
+}
+
+// NonceOptions are the optional inputs of GenerateNonce, which are mixed
+// into the nonces to protect against a weak random stream.
+type NonceOptions struct {
+	// Private is the secret key of the signer.
+	Private kyber.Scalar
+	// AggregateKey is the aggregate key of the signers.
+	AggregateKey *AggregateKey
+	// Message is the message to sign. A nil message is absent, unlike an
+	// empty one.
+	Message []byte
+	// Extra is any additional input.
+	Extra []byte
+}
+
+// GenerateNonce returns a fresh secret nonce of the signer with the public
+// key, and the public nonce to send to the other signers, as NonceGen of
+// BIP-327. The options may be nil, so that the nonces can be generated
+// before the message is known. A nil stream uses crypto/rand.
+func GenerateNonce(suite Suite, rand cipher.Stream, public kyber.Point,
+	opts *NonceOptions) (*SecretNonce, *PublicNonce, error) {
+	if rand == nil {
+		rand = random.New()
+	}
+	if opts == nil {
+		opts = &NonceOptions{}
+	}
+	pk, err := suite.EncodePoint(public)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seed := make([]byte, 32)
+	random.Bytes(seed, rand)
+	if opts.Private != nil {
+		sk, err := opts.Private.MarshalBinary()
+		if err != nil {
+			return nil, nil, err
+		}
+		aux := suite.Hash("MuSig/aux", seed)
+		seed = make([]byte, len(sk))
+		for i := range sk {
+			seed[i] = sk[i] ^ aux[i]
+		}
+	}
+	var aggpk []byte
+	if opts.AggregateKey != nil {
+		aggpk = opts.AggregateKey.encoded
+	}
+	msg := []byte{0}
+	if opts.Message != nil {
+		msg = binary.BigEndian.AppendUint64([]byte{1}, uint64(len(opts.Message)))
+		msg = append(msg, opts.Message...)
+	}
+	extra := binary.BigEndian.AppendUint32(nil, uint32(len(opts.Extra)))
+	extra = append(extra, opts.Extra...)
+
+	nonces := make([]kyber.Scalar, 2)
+	for i := range nonces {
+		h := suite.Hash("MuSig/nonce", seed, []byte{byte(len(pk))}, pk, []byte{byte(len(aggpk))}, aggpk,
+			msg, extra, []byte{byte(i)})
+		nonces[i] = suite.Scalar().SetBytes(h)
+		if nonces[i].Equal(suite.Scalar().Zero()) {
+			return nil, nil, errors.New("musig2: zero nonce")
+		}
+	}
+	secret := &SecretNonce{k1: nonces[0], k2: nonces[1], public: pk}
+	return secret, &PublicNonce{
+		R1: suite.Point().Mul(nonces[0], nil),
+		R2: suite.Point().Mul(nonces[1], nil),
+	}, nil
+}
+
+// AggregateNonces returns the sum of the public nonces of the signers.
+func AggregateNonces(suite Suite, nonces []*PublicNonce) (*PublicNonce, error) {
+	if len(nonces) == 0 {
+		return nil, errors.New("musig2: no nonces")
+	}
+	agg := &PublicNonce{R1: suite.Point().Null(), R2: suite.Point().Null()}
+	for _, n := range nonces {
+		agg.R1.Add(agg.R1, n.R1)
+		agg.R2.Add(agg.R2, n.R2)
+	}
+	return agg, nil
+}
+
+// Session holds the values shared by the signers of a message.
+type Session struct {
+	key *AggregateKey
+	msg []byte
+	b   kyber.Scalar
+	R   kyber.Point
+	e   kyber.Scalar
+	// negR and negQ tell whether R and Q are negated in the signature
+	negR, negQ bool
+}
+
+// NewSession returns the signing session of msg with the aggregate nonce
+// of the signers of the aggregate key.
+func NewSession(key *AggregateKey, nonce *PublicNonce, msg []byte) (*Session, error) {
+	suite := key.suite
+	var aggnonce []byte
+	for _, R := range []kyber.Point{nonce.R1, nonce.R2} {
+		buf, err := suite.EncodePoint(R)
+		if err != nil {
+			return nil, err
+		}
+		aggnonce = append(aggnonce, buf...)
+	}
+	b := suite.Scalar().SetBytes(suite.Hash("MuSig/noncecoef", aggnonce, key.encoded, msg))
+	R := suite.Point().Mul(b, nonce.R2)
+	R.Add(R, nonce.R1)
+	if R.Equal(suite.Point().Null()) {
+		R.Base()
+	}
+	e, err := suite.Challenge(R, key.public, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &Session{
+		key:  key,
+		msg:  msg,
+		b:    b,
+		R:    R,
+		e:    e,
+		negR: !suite.HasEvenY(R),
+		negQ: !suite.HasEvenY(key.public),
+	}, nil
+}
+
+// Sign returns the partial signature s = k1 + b*k2 + e*a*d of the signer
+// with the secret key, where a is the coefficient of its key, consuming the
+// secret nonce.
+func (s *Session) Sign(nonce *SecretNonce, private kyber.Scalar) ([]byte, error) {
+	suite := s.key.suite
+	if nonce.used {
+		return nil, fmt.Errorf("%w: already used", ErrInvalidNonce)
+	}
+	pk, err := suite.EncodePoint(suite.Point().Mul(private, nil))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pk, nonce.public) {
+		return nil, fmt.Errorf("%w: generated for another key", ErrInvalidNonce)
+	}
+	a, err := s.key.coefficient(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	k1, k2 := nonce.k1, nonce.k2
+	if s.negR {
+		k1, k2 = suite.Scalar().Neg(k1), suite.Scalar().Neg(k2)
+	}
+	d := private
+	if s.negQ {
+		d = suite.Scalar().Neg(d)
+	}
+	sig := suite.Scalar().Mul(s.e, a)
+	sig.Mul(sig, d)
+	sig.Add(sig, suite.Scalar().Mul(s.b, k2))
+	sig.Add(sig, k1)
+
+	nonce.used = true
+	nonce.k1.Zero()
+	nonce.k2.Zero()
+	return sig.MarshalBinary()
+}
+
+// VerifyPartial returns nil if sig is a valid partial signature of the
+// signer with the public key and the public nonce.
+func (s *Session) VerifyPartial(sig []byte, nonce *PublicNonce, public kyber.Point) error {
+	suite := s.key.suite
+	z, err := s.decodePartial(sig)
+	if err != nil {
+		return err
+	}
+	pk, err := suite.EncodePoint(public)
+	if err != nil {
+		return err
+	}
+	a, err := s.key.coefficient(pk)
+	if err != nil {
+		return err
+	}
+
+	R := suite.Point().Mul(s.b, nonce.R2)
+	R.Add(R, nonce.R1)
+	if s.negR {
+		R.Neg(R)
+	}
+	ea := suite.Scalar().Mul(s.e, a)
+	if s.negQ {
+		ea.Neg(ea)
+	}
+	R.Add(R, suite.Point().Mul(ea, public))
+	if !suite.Point().Mul(z, nil).Equal(R) {
+		return ErrInvalidPartialSignature
+	}
+	return nil
+}
+
+// Aggregate returns the signature R || s of the message, where s is the sum
+// of the partial signatures of all the signers. The signature can be
+// verified with Verify.
+func (s *Session) Aggregate(sigs [][]byte) ([]byte, error) {
+	suite := s.key.suite
+	sum := suite.Scalar().Zero()
+	for i, sig := range sigs {
+		z, err := s.decodePartial(sig)
+		if err != nil {
+			return nil, fmt.Errorf("partial signature %d: %w", i, err)
+		}
+		sum.Add(sum, z)
+	}
+	r, err := suite.SignatureBytes(s.R)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := sum.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(r, buf...), nil
+}
+
+// decodePartial decodes a partial signature, rejecting the non-canonical
+// encodings of the scalars.
+func (s *Session) decodePartial(sig []byte) (kyber.Scalar, error) {
+	z := s.key.suite.Scalar()
+	if err := z.UnmarshalBinary(sig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPartialSignature, err)
+	}
+	if buf, err := z.MarshalBinary(); err != nil || !bytes.Equal(buf, sig) {
+		return nil, fmt.Errorf("%w: non-canonical scalar", ErrInvalidPartialSignature)
+	}
+	return z, nil
+}
+
+// Verify returns nil if sig is a valid signature of msg by the aggregate
+// key.
+func Verify(key *AggregateKey, msg, sig []byte) error {
+	return key.suite.Verify(key.public, msg, sig)
+}
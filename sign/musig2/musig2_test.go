@@ -0,0 +1,386 @@
+package musig2
+
+import (
+	"bytes"
+	stded25519 "crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/sign/bip340"
+	"go.dedis.ch/kyber/v4/sign/eddsa"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suites = map[string]Suite{
+	"bip327":  BIP327(),
+	"ed25519": Ed25519(),
+}
+
+// keys returns n random key pairs.
+func keys(suite Suite, n int) ([]kyber.Scalar, []kyber.Point) {
+	privates := make([]kyber.Scalar, n)
+	publics := make([]kyber.Point, n)
+	for i := range privates {
+		privates[i] = suite.Scalar().Pick(random.New())
+		publics[i] = suite.Point().Mul(privates[i], nil)
+	}
+	return privates, publics
+}
+
+// run returns the signature of msg by all the signers, checking their
+// partial signatures.
+func run(t *testing.T, suite Suite, privates []kyber.Scalar, publics []kyber.Point, msg []byte) (
+	*AggregateKey, []byte) {
+	key, err := AggregateKeys(suite, publics)
+	require.NoError(t, err)
+
+	secrets := make([]*SecretNonce, len(privates))
+	nonces := make([]*PublicNonce, len(privates))
+	for i := range privates {
+		// the first signer generates its nonce without the optional inputs
+		var opts *NonceOptions
+		if i > 0 {
+			opts = &NonceOptions{Private: privates[i], AggregateKey: key, Message: msg, Extra: []byte{byte(i)}}
+		}
+		secrets[i], nonces[i], err = GenerateNonce(suite, nil, publics[i], opts)
+		require.NoError(t, err)
+	}
+	aggnonce, err := AggregateNonces(suite, nonces)
+	require.NoError(t, err)
+
+	session, err := NewSession(key, aggnonce, msg)
+	require.NoError(t, err)
+	sigs := make([][]byte, len(privates))
+	for i := range privates {
+		sigs[i], err = session.Sign(secrets[i], privates[i])
+		require.NoError(t, err)
+		require.NoError(t, session.VerifyPartial(sigs[i], nonces[i], publics[i]))
+	}
+	sig, err := session.Aggregate(sigs)
+	require.NoError(t, err)
+	return key, sig
+}
+
+func TestSign(t *testing.T) {
+	for name, suite := range suites {
+		// several runs to get aggregate keys and nonces of both parities
+		for i := 0; i < 8; i++ {
+			msg := []byte(fmt.Sprintf("Hello MuSig2 %d", i))
+			privates, publics := keys(suite, 1+i%4)
+			key, sig := run(t, suite, privates, publics, msg)
+			require.NoError(t, Verify(key, msg, sig), name)
+			require.Error(t, Verify(key, []byte("Hello"), sig), name)
+		}
+	}
+}
+
+func TestSignBIP340(t *testing.T) {
+	suite := BIP327()
+	msg := []byte("Hello MuSig2")
+	privates, publics := keys(suite, 3)
+	key, sig := run(t, suite, privates, publics, msg)
+	require.NoError(t, bip340.Verify(bip340.XOnly(key.Public()), msg, sig))
+	require.Len(t, sig, bip340.SignatureSize)
+}
+
+func TestSignEd25519(t *testing.T) {
+	suite := Ed25519()
+	msg := []byte("Hello MuSig2")
+	privates, publics := keys(suite, 3)
+	key, sig := run(t, suite, privates, publics, msg)
+	require.NoError(t, eddsa.Verify(key.Public(), msg, sig))
+	pub, err := key.Public().MarshalBinary()
+	require.NoError(t, err)
+	require.True(t, stded25519.Verify(pub, msg, sig))
+}
+
+func TestAggregateKeys(t *testing.T) {
+	for name, suite := range suites {
+		_, publics := keys(suite, 3)
+		key, err := AggregateKeys(suite, publics)
+		require.NoError(t, err, name)
+
+		// the aggregate key depends on the order of the keys
+		other, err := AggregateKeys(suite, []kyber.Point{publics[1], publics[0], publics[2]})
+		require.NoError(t, err, name)
+		require.False(t, key.Public().Equal(other.Public()), name)
+
+		// and isn't the sum of the keys, which would allow rogue keys
+		sum := suite.Point().Null()
+		for _, P := range publics {
+			sum.Add(sum, P)
+		}
+		require.False(t, key.Public().Equal(sum), name)
+
+		// the coefficient of the second distinct key is 1
+		pk, err := suite.EncodePoint(publics[1])
+		require.NoError(t, err, name)
+		a, err := key.coefficient(pk)
+		require.NoError(t, err, name)
+		require.True(t, a.Equal(suite.Scalar().One()), name)
+
+		// the same key can be aggregated several times
+		privates, publics := keys(suite, 1)
+		msg := []byte("Hello MuSig2")
+		key, sig := run(t, suite, append(privates, privates[0]), append(publics, publics[0]), msg)
+		require.NoError(t, Verify(key, msg, sig), name)
+
+		_, err = AggregateKeys(suite, nil)
+		require.Error(t, err, name)
+		_, err = AggregateKeys(suite, []kyber.Point{suite.Point().Null()})
+		require.ErrorIs(t, err, ErrInvalidPoint, name)
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	suite := BIP327()
+	_, publics := keys(suite, 5)
+	sorted, err := SortKeys(suite, publics)
+	require.NoError(t, err)
+	for i := 1; i < len(sorted); i++ {
+		prev, err := suite.EncodePoint(sorted[i-1])
+		require.NoError(t, err)
+		cur, err := suite.EncodePoint(sorted[i])
+		require.NoError(t, err)
+		require.True(t, string(prev) < string(cur))
+	}
+}
+
+func TestSignErrors(t *testing.T) {
+	for name, suite := range suites {
+		msg := []byte("Hello MuSig2")
+		privates, publics := keys(suite, 3)
+		key, err := AggregateKeys(suite, publics[:2])
+		require.NoError(t, err, name)
+
+		secrets := make([]*SecretNonce, 3)
+		nonces := make([]*PublicNonce, 3)
+		for i := range secrets {
+			secrets[i], nonces[i], err = GenerateNonce(suite, random.New(), publics[i], nil)
+			require.NoError(t, err, name)
+		}
+		aggnonce, err := AggregateNonces(suite, nonces[:2])
+		require.NoError(t, err, name)
+		session, err := NewSession(key, aggnonce, msg)
+		require.NoError(t, err, name)
+
+		// the nonce of another signer
+		_, err = session.Sign(secrets[1], privates[0])
+		require.ErrorIs(t, err, ErrInvalidNonce, name)
+		// a signer which isn't part of the aggregate key
+		_, err = session.Sign(secrets[2], privates[2])
+		require.ErrorIs(t, err, ErrUnknownKey, name)
+
+		sig, err := session.Sign(secrets[0], privates[0])
+		require.NoError(t, err, name)
+		_, err = session.Sign(secrets[0], privates[0])
+		require.ErrorIs(t, err, ErrInvalidNonce, name)
+
+		require.NoError(t, session.VerifyPartial(sig, nonces[0], publics[0]), name)
+		require.ErrorIs(t, session.VerifyPartial(sig, nonces[1], publics[0]), ErrInvalidPartialSignature, name)
+		require.ErrorIs(t, session.VerifyPartial(sig, nonces[0], publics[1]), ErrInvalidPartialSignature, name)
+		require.ErrorIs(t, session.VerifyPartial(sig[1:], nonces[0], publics[0]), ErrInvalidPartialSignature,
+			name)
+		_, err = session.Aggregate([][]byte{sig, sig[1:]})
+		require.ErrorIs(t, err, ErrInvalidPartialSignature, name)
+	}
+}
+
+func TestPointEncoding(t *testing.T) {
+	for name, suite := range suites {
+		P := suite.Point().Pick(random.New())
+		buf, err := suite.EncodePoint(P)
+		require.NoError(t, err, name)
+		Q, err := suite.DecodePoint(buf)
+		require.NoError(t, err, name)
+		require.True(t, P.Equal(Q), name)
+
+		buf, err = suite.EncodePoint(suite.Point().Null())
+		require.NoError(t, err, name)
+		_, err = suite.DecodePoint(buf)
+		require.ErrorIs(t, err, ErrInvalidPoint, name)
+	}
+}
+
+// The following tests use cases of the test vectors of BIP-327, from
+// https://github.com/bitcoin/bips/tree/master/bip-0327/vectors.
+
+func unhex(t *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return buf
+}
+
+func decode(t *testing.T, s string) kyber.Point {
+	P, err := BIP327().DecodePoint(unhex(t, s))
+	require.NoError(t, err)
+	return P
+}
+
+// fixedStream is a stream which always returns the same bytes.
+type fixedStream []byte
+
+func (f fixedStream) XORKeyStream(dst, src []byte) {
+	for i := range dst {
+		dst[i] = src[i] ^ f[i]
+	}
+}
+
+// key_agg_vectors.json
+func TestVectorsKeyAgg(t *testing.T) {
+	pubkeys := []string{
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+	}
+	vectors := []struct {
+		keys     []int
+		expected string
+	}{
+		{[]int{0, 1, 2}, "90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C"},
+		{[]int{2, 1, 0}, "6204DE8B083426DC6EAF9502D27024D53FC826BF7D2012148A0575435DF54B2B"},
+		{[]int{0, 0, 0}, "B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935"},
+		{[]int{0, 0, 1, 1}, "69BC22BFA5D106306E48A20679DE1D7389386124D07571D0D872686028C26A3E"},
+	}
+	for _, v := range vectors {
+		publics := make([]kyber.Point, len(v.keys))
+		for i, k := range v.keys {
+			publics[i] = decode(t, pubkeys[k])
+		}
+		key, err := AggregateKeys(BIP327(), publics)
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.expected), key.encoded, v.keys)
+	}
+
+	invalid := []string{
+		// not on the curve
+		"020000000000000000000000000000000000000000000000000000000000000005",
+		// exceeds the field size
+		"02FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC30",
+		// invalid prefix
+		"04F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+	}
+	for _, s := range invalid {
+		_, err := BIP327().DecodePoint(unhex(t, s))
+		require.ErrorIs(t, err, ErrInvalidPoint, s)
+	}
+}
+
+// nonce_gen_vectors.json
+func TestVectorsNonceGen(t *testing.T) {
+	suite := BIP327()
+	rep := func(b byte) []byte { return bytes.Repeat([]byte{b}, 32) }
+	private := suite.Scalar().SetBytes(rep(0x02))
+	vectors := []struct {
+		rand     []byte
+		public   string
+		opts     *NonceOptions
+		expected string
+	}{
+		{
+			rep(0x0F),
+			"024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766",
+			&NonceOptions{Private: private, AggregateKey: &AggregateKey{encoded: rep(0x07)}, Message: rep(0x01),
+				Extra: rep(0x08)},
+			"B114E502BEAA4E301DD08A50264172C84E41650E6CB726B410C0694D59EFFB64" +
+				"95B5CAF28D045B973D63E3C99A44B807BDE375FD6CB39E46DC4A511708D0E9D2",
+		},
+		{
+			rep(0x0F),
+			"024D4B6CD1361032CA9BD2AEB9D900AA4D45D9EAD80AC9423374C451A7254D0766",
+			&NonceOptions{Private: private, AggregateKey: &AggregateKey{encoded: rep(0x07)}, Message: []byte{},
+				Extra: rep(0x08)},
+			"E862B068500320088138468D47E0E6F147E01B6024244AE45EAC40ACE5929B9F" +
+				"0789E051170B9E705D0B9EB49049A323BBBBB206D8E05C19F46C6228742AA7A9",
+		},
+		{
+			rep(0x00),
+			"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			nil,
+			"890E83616A3BC4640AB9B6374F21C81FF89CDDDBAFAA7475AE2A102A92E3EDB2" +
+				"9FD7E874E23342813A60D9646948242646B7951CA046B4B36D7D6078506D3C94",
+		},
+	}
+	for i, v := range vectors {
+		secret, public, err := GenerateNonce(suite, fixedStream(v.rand), decode(t, v.public), v.opts)
+		require.NoError(t, err, i)
+		k1, err := secret.k1.MarshalBinary()
+		require.NoError(t, err)
+		k2, err := secret.k2.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, unhex(t, v.expected), append(k1, k2...), i)
+		require.Equal(t, unhex(t, v.public), secret.public, i)
+		require.True(t, public.R1.Equal(suite.Point().Mul(secret.k1, nil)), i)
+	}
+}
+
+// nonce_agg_vectors.json
+func TestVectorsNonceAgg(t *testing.T) {
+	suite := BIP327()
+	nonces := []*PublicNonce{
+		{
+			decode(t, "020151C80F435648DF67A22B749CD798CE54E0321D034B92B709B567D60A42E666"),
+			decode(t, "03BA47FBC1834437B3212E89A84D8425E7BF12E0245D98262268EBDCB385D50641"),
+		},
+		{
+			decode(t, "03FF406FFD8ADB9CD29877E4985014F66A59F6CD01C0E88CAA8E5F3166B1F676A6"),
+			decode(t, "0248C264CDD57D3C24D79990B0F865674EB62A0F9018277A95011B41BFC193B833"),
+		},
+	}
+	agg, err := AggregateNonces(suite, nonces)
+	require.NoError(t, err)
+	R1, err := suite.EncodePoint(agg.R1)
+	require.NoError(t, err)
+	R2, err := suite.EncodePoint(agg.R2)
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "035FE1873B4F2967F52FEA4A06AD5A8ECCBE9D0FD73068012C894E2E87CCB5804B"), R1)
+	require.Equal(t, unhex(t, "024725377345BDE0E9C33AF3C43C0A29A9249F2F2956FA8CFEB55C8573D0262DC8"), R2)
+}
+
+// sign_verify_vectors.json
+func TestVectorsSignVerify(t *testing.T) {
+	suite := BIP327()
+	private := suite.Scalar().SetBytes(unhex(t, "7FB9E0E687ADA1EEBF7ECFE2F21E73EBDB51A7D450948DFE8D76D7F2D1007671"))
+	public := suite.Point().Mul(private, nil)
+	key, err := AggregateKeys(suite, []kyber.Point{
+		public,
+		decode(t, "02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9"),
+		decode(t, "02DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA661"),
+	})
+	require.NoError(t, err)
+	pk, err := suite.EncodePoint(public)
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "03935F972DA013F80AE011890FA89B67A27B7BE6CCB24D3274D18B2D4067F261A9"), pk)
+
+	secnonce := unhex(t, "508B81A611F100A6B2B6B29656590898AF488BCF2E1F55CF22E5CFB84421FE61"+
+		"FA27FD49B1D50085B481285E1CA205D55C82CC1B31FF5CD54A489829355901F7")
+	secret := &SecretNonce{
+		k1:     suite.Scalar().SetBytes(secnonce[:32]),
+		k2:     suite.Scalar().SetBytes(secnonce[32:]),
+		public: pk,
+	}
+	nonce := &PublicNonce{
+		decode(t, "0337C87821AFD50A8644D820A8F3E02E499C931865C2360FB43D0A0D20DAFE07EA"),
+		decode(t, "0287BF891D2A6DEAEBADC909352AA9405D1428C15F4B75F04DAE642A95C2548480"),
+	}
+	aggnonce := &PublicNonce{
+		decode(t, "028465FCF0BBDBCF443AABCCE533D42B4B5A10966AC09A49655E8C42DAAB8FCD61"),
+		decode(t, "037496A3CC86926D452CAFCFD55D25972CA1675D549310DE296BFF42F72EEEA8C9"),
+	}
+	msg := unhex(t, "F95466D086770E689964664219266FE5ED215C92AE20BAB5C9D79ADDDDF3C0CF")
+
+	session, err := NewSession(key, aggnonce, msg)
+	require.NoError(t, err)
+	sig, err := session.Sign(secret, private)
+	require.NoError(t, err)
+	require.Equal(t, unhex(t, "012ABBCB52B3016AC03AD82395A1A415C48B93DEF78718E62A7A90052FE224FB"), sig)
+	require.NoError(t, session.VerifyPartial(sig, nonce, public))
+
+	// the negation of the partial signature is invalid
+	neg, err := suite.Scalar().Neg(suite.Scalar().SetBytes(sig)).MarshalBinary()
+	require.NoError(t, err)
+	require.ErrorIs(t, session.VerifyPartial(neg, nonce, public), ErrInvalidPartialSignature)
+}
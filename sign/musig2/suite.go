@@ -0,0 +1,165 @@
+package musig2
+
+import (
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/sign/bip340"
+	"go.dedis.ch/kyber/v4/sign/eddsa"
+)
+
+// Suite is the group of a MuSig2 mode, with the encodings and the hashes of
+// its keys, nonces and signatures.
+type Suite interface {
+	kyber.Group
+
+	// EncodePoint returns the encoding of a public key or nonce. The
+	// identity can be encoded, as aggregate nonces may be the identity.
+	EncodePoint(P kyber.Point) ([]byte, error)
+	// DecodePoint decodes a public key or nonce, rejecting the identity.
+	DecodePoint(buf []byte) (kyber.Point, error)
+	// SignatureBytes returns the encoding of a point in the signatures and
+	// the challenges, such as the x-only encoding of BIP-340.
+	SignatureBytes(P kyber.Point) ([]byte, error)
+	// HasEvenY returns true if the point doesn't have to be negated to be
+	// used in a signature. Only the x-only encodings have odd points.
+	HasEvenY(P kyber.Point) bool
+	// Hash returns the hash of the messages with the tag, which is reduced
+	// to a scalar with SetBytes.
+	Hash(tag string, msgs ...[]byte) []byte
+	// Challenge returns the challenge of the Schnorr signature of msg with
+	// the commitment R and the public key Q.
+	Challenge(R, Q kyber.Point, msg []byte) (kyber.Scalar, error)
+	// Verify returns nil if sig is a valid signature of msg by the public
+	// key.
+	Verify(public kyber.Point, msg, sig []byte) error
+}
+
+// ErrInvalidPoint is returned when decoding an invalid key or nonce.
+var ErrInvalidPoint = errors.New("musig2: invalid point")
+
+type bip327 struct {
+	kyber.Group
+}
+
+// BIP327 returns the mode of BIP-327 on secp256k1. The public keys and
+// nonces are 33 bytes compressed points, and the signatures are BIP-340
+// signatures by the x-only encoding of the aggregate key, which can be
+// verified with sign/bip340. Tweaking the aggregate key isn't supported.
+func BIP327() Suite {
+	return &bip327{Group: bip340.Group()}
+}
+
+func (s *bip327) EncodePoint(P kyber.Point) ([]byte, error) {
+	if P.Equal(s.Point().Null()) {
+		return make([]byte, 33), nil
+	}
+	// uncompressed encoding 0x04 || x || y
+	buf, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{0x02 | buf[len(buf)-1]&1}, buf[1:33]...), nil
+}
+
+func (s *bip327) DecodePoint(buf []byte) (kyber.Point, error) {
+	if len(buf) != 33 || (buf[0] != 0x02 && buf[0] != 0x03) {
+		return nil, fmt.Errorf("%w: not a compressed point", ErrInvalidPoint)
+	}
+	P, err := bip340.LiftX(buf[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPoint, err)
+	}
+	if buf[0] == 0x03 {
+		P.Neg(P)
+	}
+	return P, nil
+}
+
+func (s *bip327) SignatureBytes(P kyber.Point) ([]byte, error) {
+	return bip340.XOnly(P), nil
+}
+
+func (s *bip327) HasEvenY(P kyber.Point) bool {
+	buf, err := P.MarshalBinary()
+	return err == nil && buf[len(buf)-1]&1 == 0
+}
+
+func (s *bip327) Hash(tag string, msgs ...[]byte) []byte {
+	return bip340.TaggedHash(tag, msgs...)
+}
+
+func (s *bip327) Challenge(R, Q kyber.Point, msg []byte) (kyber.Scalar, error) {
+	h := bip340.TaggedHash("BIP0340/challenge", bip340.XOnly(R), bip340.XOnly(Q), msg)
+	return s.Scalar().SetBytes(h), nil
+}
+
+func (s *bip327) Verify(public kyber.Point, msg, sig []byte) error {
+	return bip340.Verify(bip340.XOnly(public), msg, sig)
+}
+
+type ed25519 struct {
+	edwards25519.Curve
+}
+
+// Ed25519 returns the mode of MuSig2 on edwards25519, whose signatures are
+// Ed25519 signatures by the aggregate key, which can be verified with
+// sign/eddsa. It uses the algorithms of BIP-327 with the standard encodings
+// of edwards25519, SHA-512 for the tagged hashes, and no negations.
+func Ed25519() Suite {
+	return &ed25519{}
+}
+
+func (s *ed25519) EncodePoint(P kyber.Point) ([]byte, error) {
+	return P.MarshalBinary()
+}
+
+func (s *ed25519) DecodePoint(buf []byte) (kyber.Point, error) {
+	P := s.Point()
+	if err := P.UnmarshalBinary(buf); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPoint, err)
+	}
+	if P.Equal(s.Point().Null()) {
+		return nil, fmt.Errorf("%w: identity", ErrInvalidPoint)
+	}
+	return P, nil
+}
+
+func (s *ed25519) SignatureBytes(P kyber.Point) ([]byte, error) {
+	return P.MarshalBinary()
+}
+
+func (s *ed25519) HasEvenY(kyber.Point) bool {
+	return true
+}
+
+// Hash is the tagged hash of BIP-340 with SHA-512.
+func (s *ed25519) Hash(tag string, msgs ...[]byte) []byte {
+	t := sha512.Sum512([]byte(tag))
+	h := sha512.New()
+	h.Write(t[:])
+	h.Write(t[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// Challenge is the challenge of Ed25519, SHA512(R || Q || msg).
+func (s *ed25519) Challenge(R, Q kyber.Point, msg []byte) (kyber.Scalar, error) {
+	h := sha512.New()
+	for _, P := range []kyber.Point{R, Q} {
+		if _, err := P.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	h.Write(msg)
+	return s.Scalar().SetBytes(h.Sum(nil)), nil
+}
+
+func (s *ed25519) Verify(public kyber.Point, msg, sig []byte) error {
+	return eddsa.Verify(public, msg, sig)
+}
@@ -19,7 +19,10 @@ import (
 	"fmt"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/fixedbase"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/sign"
+	"go.dedis.ch/kyber/v4/util/random"
 )
 
 // Suite represents the set of functionalities needed by the package schnorr.
@@ -81,63 +84,237 @@ func Sign(s Suite, private kyber.Scalar, msg []byte) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+type scalarCanCheckCanonical interface {
+	IsCanonical(b []byte) bool
+}
+
+type pointCanCheckCanonicalAndSmallOrder interface {
+	HasSmallOrder() bool
+	IsCanonical(b []byte) bool
+}
+
 // VerifyWithChecks uses a public key buffer, a message and a signature.
 // It will return nil if sig is a valid signature for msg created by
 // key public, or an error otherwise. Compared to `Verify`, it performs
 // additional checks around the canonicality and ensures the public key
 // does not have a small order when using `edwards25519` group.
 func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
-	type scalarCanCheckCanonical interface {
-		IsCanonical(b []byte) bool
+	R, s, err := decodeSignature(g, sig)
+	if err != nil {
+		return err
+	}
+	public, err := decodePublic(g, pub)
+	if err != nil {
+		return err
 	}
 
-	type pointCanCheckCanonicalAndSmallOrder interface {
-		HasSmallOrder() bool
-		IsCanonical(b []byte) bool
+	// compute A^h
+	return verify(g, public, R, s, msg, func(h kyber.Scalar) kyber.Point {
+		return g.Point().Mul(h, public)
+	})
+}
+
+// Verify verifies a given Schnorr signature. It returns nil iff the
+// given signature is valid.
+func Verify(g kyber.Group, public kyber.Point, msg, sig []byte) error {
+	PBuf, err := public.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling public key: %w", err)
 	}
+	return VerifyWithChecks(g, PBuf, msg, sig)
+}
+
+// BatchVerify verifies that each sigs[i] is a valid signature of msgs[i]
+// by publics[i]. It returns nil if they all are, or else a *sign.BatchError
+// holding the invalid signatures, as decided by BatchVerifyWithChecks.
+func BatchVerify(g kyber.Group, publics []kyber.Point, msgs, sigs [][]byte) error {
+	pubs := make([][]byte, len(publics))
+	for i, public := range publics {
+		pub, err := public.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("error unmarshalling public key: %w", err)
+		}
+		pubs[i] = pub
+	}
+	return BatchVerifyWithChecks(g, pubs, msgs, sigs)
+}
+
+// BatchVerifyWithChecks is the batch version of VerifyWithChecks. The
+// signatures failing the checks of VerifyWithChecks are reported right away,
+// and the others are verified at once by checking a random linear
+// combination of their equations with a single multi-scalar multiplication.
+// Only if this check fails are they verified one by one, to find the invalid
+// ones. A valid signature is never reported, and an invalid one is detected
+// except with probability 1/l, for the order l of the group.
+//
+// With edwards25519, the equations are multiplied by the cofactor 8, so that
+// the torsion components of crafted public keys or R can't make the result
+// depend on the random combination. The batch thus also accepts the
+// signatures whose points have a torsion component that cancels out in
+// 8*s*G == 8*R + 8*h*A, which VerifyWithChecks may reject.
+func BatchVerifyWithChecks(g kyber.Group, pubs, msgs, sigs [][]byte) error {
+	if len(pubs) != len(msgs) || len(pubs) != len(sigs) {
+		return errors.New("schnorr: mismatching numbers of keys, messages and signatures")
+	}
+
+	// sum(a_i*s_i)*G - sum(a_i*R_i) - sum(a_i*h_i*A_i) == 0, with random a_i
+	rand := random.New()
+	invalid := make(map[int]error)
+	indices := make([]int, 0, len(sigs))
+	equations := make([][]kyber.Scalar, 0, len(sigs))
+	scalars := make([]kyber.Scalar, 1, 2*len(sigs)+1)
+	points := make([]kyber.Point, 1, 2*len(sigs)+1)
+	sum := g.Scalar().Zero()
+	for i := range sigs {
+		R, s, err := decodeSignature(g, sigs[i])
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+		public, err := decodePublic(g, pubs[i])
+		if err != nil {
+			invalid[i] = err
+			continue
+		}
+		h, err := hash(g, public, R, msgs[i])
+		if err != nil {
+			return err
+		}
+
+		a := g.Scalar().Pick(rand)
+		sum.Add(sum, g.Scalar().Mul(a, s))
+		scalars = append(scalars, g.Scalar().Neg(a), g.Scalar().Neg(g.Scalar().Mul(a, h)))
+		points = append(points, R, public)
+		indices = append(indices, i)
+		equations = append(equations, []kyber.Scalar{s, g.Scalar().SetInt64(-1), h.Neg(h)})
+	}
+	scalars[0] = sum
+
+	if len(indices) > 0 && !isNeutral(g, msm.MultiScalarMul(g, scalars, points)) {
+		for j, i := range indices {
+			// s*G - R - h*A
+			P := msm.MultiScalarMul(g, equations[j], []kyber.Point{nil, points[2*j+1], points[2*j+2]})
+			if !isNeutral(g, P) {
+				invalid[i] = errors.New("schnorr: invalid signature")
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &sign.BatchError{Invalid: invalid}
+	}
+	return nil
+}
 
+// isNeutral tells whether P is the neutral element of g, once multiplied by
+// the cofactor 8 if the points of g can have a small order.
+func isNeutral(g kyber.Group, P kyber.Point) bool {
+	if _, ok := P.(pointCanCheckCanonicalAndSmallOrder); ok {
+		for i := 0; i < 3; i++ {
+			P.Add(P, P)
+		}
+	}
+	return P.Equal(g.Point().Null())
+}
+
+// Verifier verifies the signatures of a long-lived public key. With the
+// groups providing their own precomputed tables, it keeps a table of the
+// multiples of the key, so that verifying many signatures is faster than
+// with Verify.
+type Verifier struct {
+	g      kyber.Group
+	public kyber.Point
+	table  kyber.FixedBase
+}
+
+// NewVerifier checks the public key as VerifyWithChecks does, once and
+// for all, and returns a Verifier of its signatures.
+func NewVerifier(g kyber.Group, public kyber.Point) (*Verifier, error) {
+	pub, err := public.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling public key: %w", err)
+	}
+	P, err := decodePublic(g, pub)
+	if err != nil {
+		return nil, err
+	}
+	// The generic tables are slower than the multiplication of the groups
+	// with affine additions, such as p256, thus only native tables are used.
+	table, _ := fixedbase.Native(g, P)
+	return &Verifier{g: g, public: P, table: table}, nil
+}
+
+// Verify returns nil if sig is a valid signature of msg by the public key
+// of the Verifier, or an error otherwise. It is equivalent to Verify.
+func (v *Verifier) Verify(msg, sig []byte) error {
+	R, s, err := decodeSignature(v.g, sig)
+	if err != nil {
+		return err
+	}
+	mulPublic := func(h kyber.Scalar) kyber.Point {
+		return v.g.Point().Mul(h, v.public)
+	}
+	if v.table != nil {
+		mulPublic = v.table.Mul
+	}
+	return verify(v.g, v.public, R, s, msg, mulPublic)
+}
+
+// decodeSignature returns the commitment R and the response s of the
+// signature, checking their encodings.
+func decodeSignature(g kyber.Group, sig []byte) (kyber.Point, kyber.Scalar, error) {
 	R := g.Point()
 	s := g.Scalar()
 	pointSize := R.MarshalSize()
 	scalarSize := s.MarshalSize()
 	sigSize := scalarSize + pointSize
 	if len(sig) != sigSize {
-		return fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
+		return nil, nil, fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
 	}
 	if err := R.UnmarshalBinary(sig[:pointSize]); err != nil {
-		return err
+		return nil, nil, err
 	}
 	if p, ok := R.(pointCanCheckCanonicalAndSmallOrder); ok {
 		if !p.IsCanonical(sig[:pointSize]) {
-			return fmt.Errorf("point R is not canonical")
+			return nil, nil, fmt.Errorf("point R is not canonical")
 		}
 		if p.HasSmallOrder() {
-			return fmt.Errorf("point R has small order")
+			return nil, nil, fmt.Errorf("point R has small order")
 		}
 	}
 	if s, ok := g.Scalar().(scalarCanCheckCanonical); ok && !s.IsCanonical(sig[pointSize:]) {
-		return fmt.Errorf("signature is not canonical")
+		return nil, nil, fmt.Errorf("signature is not canonical")
 	}
 	if sub, ok := R.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
-		return fmt.Errorf("schnorr: point not in correct group")
+		return nil, nil, fmt.Errorf("schnorr: point not in correct group")
 	}
 	if err := s.UnmarshalBinary(sig[pointSize:]); err != nil {
-		return err
+		return nil, nil, err
 	}
+	return R, s, nil
+}
 
+// decodePublic returns the public key encoded in pub, checking that it is
+// canonical and doesn't have a small order.
+func decodePublic(g kyber.Group, pub []byte) (kyber.Point, error) {
 	public := g.Point()
 	err := public.UnmarshalBinary(pub)
 	if err != nil {
-		return fmt.Errorf("schnorr: error unmarshalling public key")
+		return nil, fmt.Errorf("schnorr: error unmarshalling public key")
 	}
 	if p, ok := public.(pointCanCheckCanonicalAndSmallOrder); ok {
 		if !p.IsCanonical(pub) {
-			return fmt.Errorf("public key is not canonical")
+			return nil, fmt.Errorf("public key is not canonical")
 		}
 		if p.HasSmallOrder() {
-			return fmt.Errorf("public key has small order")
+			return nil, fmt.Errorf("public key has small order")
 		}
 	}
+	return public, nil
+}
+
+// verify checks that g^s == R + A^h, where mulPublic computes A^h.
+func verify(g kyber.Group, public, R kyber.Point, s kyber.Scalar, msg []byte,
+	mulPublic func(h kyber.Scalar) kyber.Point) error {
 	// recompute hash(public || R || msg)
 	h, err := hash(g, public, R, msg)
 	if err != nil {
@@ -147,7 +324,7 @@ func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
 	// compute S = g^s
 	S := g.Point().Mul(s, nil)
 	// compute RAh = R + A^h
-	Ah := g.Point().Mul(h, public)
+	Ah := mulPublic(h)
 	RAs := g.Point().Add(R, Ah)
 
 	if !S.Equal(RAs) {
@@ -155,17 +332,6 @@ func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
 	}
 
 	return nil
-
-}
-
-// Verify verifies a given Schnorr signature. It returns nil iff the
-// given signature is valid.
-func Verify(g kyber.Group, public kyber.Point, msg, sig []byte) error {
-	PBuf, err := public.MarshalBinary()
-	if err != nil {
-		return fmt.Errorf("error unmarshalling public key: %w", err)
-	}
-	return VerifyWithChecks(g, PBuf, msg, sig)
 }
 
 func hash(g kyber.Group, public, r kyber.Point, msg []byte) (kyber.Scalar, error) {
@@ -1,6 +1,7 @@
 package schnorr
 
 import (
+	"fmt"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -8,7 +9,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/sign/eddsa"
 	"go.dedis.ch/kyber/v4/util/key"
 )
@@ -51,6 +55,25 @@ func TestSchnorrSignature(t *testing.T) {
 	assert.Error(t, Verify(suite, wrKp.Public, msg, s))
 }
 
+func TestVerifier(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	v, err := NewVerifier(suite, kp.Public)
+	require.NoError(t, err)
+
+	for _, msg := range []string{"Hello", "Schnorr", "Verifier"} {
+		s, err := Sign(suite, kp.Private, []byte(msg))
+		require.NoError(t, err)
+		require.NoError(t, v.Verify([]byte(msg), s))
+		require.Error(t, v.Verify([]byte("wrong message"), s))
+		require.Error(t, v.Verify([]byte(msg), s[1:]))
+	}
+
+	// small order public keys are rejected once for all
+	_, err = NewVerifier(suite, suite.Point().Null())
+	require.Error(t, err)
+}
+
 func TestEdDSACompatibility(t *testing.T) {
 	msg := []byte("Hello Schnorr")
 	suite := edwards25519.NewBlakeSHA256Ed25519()
@@ -142,3 +165,84 @@ func FuzzSchnorr(f *testing.F) {
 		require.NoError(t, err, "Couldn't verify signature: \n%+v\nfor msg:'%s'. Error:\n%v", s, msg, err)
 	})
 }
+
+func TestBatchVerify(t *testing.T) {
+	for _, suite := range []Suite{edwards25519.NewBlakeSHA256Ed25519(), p256.NewBlakeSHA256P256()} {
+		n := 32
+		publics := make([]kyber.Point, n)
+		msgs := make([][]byte, n)
+		sigs := make([][]byte, n)
+		for i := range sigs {
+			kp := key.NewKeyPair(suite)
+			msgs[i] = []byte(fmt.Sprintf("message %d", i))
+			s, err := Sign(suite, kp.Private, msgs[i])
+			require.NoError(t, err)
+			publics[i], sigs[i] = kp.Public, s
+		}
+		require.NoError(t, BatchVerify(suite, publics, msgs, sigs))
+		require.Error(t, BatchVerify(suite, publics, msgs[1:], sigs))
+
+		// wrong message, wrong key and wrong length
+		msgs[2] = []byte("wrong message")
+		publics[9] = publics[10]
+		sigs[17] = sigs[17][1:]
+		err := BatchVerify(suite, publics, msgs, sigs)
+		var batchErr *sign.BatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, []int{2, 9, 17}, batchErr.Indices())
+		for i := range sigs {
+			require.Equal(t, Verify(suite, publics[i], msgs[i], sigs[i]), batchErr.Invalid[i])
+		}
+	}
+}
+
+// A signature by a public key with a torsion component which doesn't cancel
+// out is rejected by Verify, but accepted by the cofactored equation of the
+// batch, whatever the other signatures of the batch.
+func TestBatchVerifyTorsion(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	smallOrder := []byte{0xc7, 0x17, 0x6a, 0x70, 0x3d, 0x4d, 0xd8, 0x4f, 0xba, 0x3c, 0x0b,
+		0x76, 0x0d, 0x10, 0x67, 0x0f, 0x2a, 0x20, 0x53, 0xfa, 0x2c, 0x39,
+		0xcc, 0xc6, 0x4e, 0xc7, 0xfd, 0x77, 0x92, 0xac, 0x03, 0x7a}
+	T := suite.Point()
+	require.NoError(t, T.UnmarshalBinary(smallOrder))
+
+	n := 16
+	publics := make([]kyber.Point, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := range sigs {
+		kp := key.NewKeyPair(suite)
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		s, err := Sign(suite, kp.Private, msgs[i])
+		require.NoError(t, err)
+		publics[i], sigs[i] = kp.Public, s
+	}
+
+	// s*G - R - h*A = -h*T, which isn't the neutral element unless 8 | h.
+	x := suite.Scalar().Pick(suite.RandomStream())
+	publics[5] = suite.Point().Add(suite.Point().Mul(x, nil), T)
+	for sigs[5] = nil; sigs[5] == nil; {
+		k := suite.Scalar().Pick(suite.RandomStream())
+		R := suite.Point().Mul(k, nil)
+		h, err := hash(suite, publics[5], R, msgs[5])
+		require.NoError(t, err)
+		Rbuf, err := R.MarshalBinary()
+		require.NoError(t, err)
+		sBuf, err := suite.Scalar().Add(k, h.Mul(h, x)).MarshalBinary()
+		require.NoError(t, err)
+		if Verify(suite, publics[5], msgs[5], append(Rbuf, sBuf...)) != nil {
+			sigs[5] = append(Rbuf, sBuf...)
+		}
+	}
+	require.NoError(t, BatchVerify(suite, publics, msgs, sigs))
+
+	// the signatures are also verified with the cofactored equation when
+	// the batch is invalid
+	msgs[3] = []byte("wrong message")
+	err := BatchVerify(suite, publics, msgs, sigs)
+	var batchErr *sign.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, []int{3}, batchErr.Indices())
+	require.Equal(t, Verify(suite, publics[3], msgs[3], sigs[3]), batchErr.Invalid[3])
+}
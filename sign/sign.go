@@ -2,6 +2,9 @@ package sign
 
 import (
 	"crypto/cipher"
+	"fmt"
+	"sort"
+	"strings"
 
 	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/share"
@@ -33,5 +36,35 @@ type ThresholdScheme interface {
 	IndexOf(signature []byte) (int, error)
 	Recover(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error)
 	VerifyPartial(public *share.PubPoly, msg, sig []byte) error
+	// VerifyPartialBatch verifies all the partial signatures at once. It
+	// returns nil if they are all valid, or a *BatchError holding the
+	// invalid ones.
+	VerifyPartialBatch(public *share.PubPoly, msg []byte, sigs [][]byte) error
 	VerifyRecovered(public kyber.Point, msg, sig []byte) error
 }
+
+// BatchError is the error returned by the batch verifications of signatures
+// when some of the signatures are invalid. It maps the index of each invalid
+// signature in the batch to the error returned by its verification.
+type BatchError struct {
+	Invalid map[int]error
+}
+
+// Indices returns the sorted indices of the invalid signatures.
+func (e *BatchError) Indices() []int {
+	indices := make([]int, 0, len(e.Invalid))
+	for i := range e.Invalid {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+func (e *BatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid signature(s) in batch:", len(e.Invalid))
+	for _, i := range e.Indices() {
+		fmt.Fprintf(&b, " [%d] %v;", i, e.Invalid[i])
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
@@ -8,18 +8,32 @@
 // interpolation. The signature S can be verified with the initially
 // established group key X. Signatures are points on curve G1 and public keys
 // are points on curve G2.
+//
+// With many signers, the partial signatures can be verified at once with
+// VerifyPartialBatch, and the RecoverBatch method of the schemes of this
+// package recovers the signature from them while reporting the invalid ones.
+// As NewThresholdSchemeOnG1 and NewThresholdSchemeOnG2 return a
+// sign.ThresholdScheme, RecoverBatch is only reachable through a type
+// assertion to BatchRecoverer:
+//
+//	scheme := tbls.NewThresholdSchemeOnG1(suite)
+//	sig, invalid, err := scheme.(tbls.BatchRecoverer).RecoverBatch(public, msg, sigs, t, n)
 package tbls
 
 import (
 	"bytes"
+	"crypto/cipher"
 	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/pairing"
 	"go.dedis.ch/kyber/v4/share"
 	"go.dedis.ch/kyber/v4/sign"
 	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/util/random"
 )
 
 // SigShare encodes a threshold BLS signature share Si = i || v where the 2-byte
@@ -43,9 +57,18 @@ func (s *SigShare) Value() []byte {
 	return []byte(*s)[2:]
 }
 
+// BatchRecoverer is implemented by the threshold schemes of this package.
+type BatchRecoverer interface {
+	// RecoverBatch is Recover with the partial signatures verified at once.
+	// It also returns the positions in sigs of the invalid ones.
+	RecoverBatch(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, []int, error)
+}
+
 type scheme struct {
+	suite    pairing.Suite
 	keyGroup kyber.Group
 	sigGroup kyber.Group
+	onG1     bool
 	sign.Scheme
 }
 
@@ -53,8 +76,10 @@ type scheme struct {
 // on G1
 func NewThresholdSchemeOnG1(suite pairing.Suite) sign.ThresholdScheme {
 	return &scheme{
+		suite:    suite,
 		keyGroup: suite.G2(),
 		sigGroup: suite.G1(),
+		onG1:     true,
 		Scheme:   bls.NewSchemeOnG1(suite),
 	}
 }
@@ -63,6 +88,7 @@ func NewThresholdSchemeOnG1(suite pairing.Suite) sign.ThresholdScheme {
 // on G2
 func NewThresholdSchemeOnG2(suite pairing.Suite) sign.ThresholdScheme {
 	return &scheme{
+		suite:    suite,
 		keyGroup: suite.G1(),
 		sigGroup: suite.G2(),
 		Scheme:   bls.NewSchemeOnG2(suite),
@@ -149,3 +175,148 @@ func (s *scheme) Recover(public *share.PubPoly, msg []byte, sigs [][]byte, t, n
 	}
 	return sig, nil
 }
+
+// partial is a decoded partial signature.
+type partial struct {
+	// pos is the position of the partial signature in its batch.
+	pos   int
+	index uint32
+	point kyber.Point
+}
+
+// VerifyPartialBatch checks all the partial signatures sigs on the message m
+// at once, with a random linear combination of their verification equations
+// that only takes two pairings. If it fails, the batch is bisected to find
+// the invalid partial signatures, which are returned in a *sign.BatchError
+// holding their positions in sigs.
+func (s *scheme) VerifyPartialBatch(public *share.PubPoly, msg []byte, sigs [][]byte) error {
+	_, invalid, err := s.verifyBatch(public, msg, sigs)
+	if err != nil {
+		return err
+	}
+	if len(invalid) > 0 {
+		return &sign.BatchError{Invalid: invalid}
+	}
+	return nil
+}
+
+// RecoverBatch reconstructs the full BLS signature S = x * H(m) as Recover
+// does, but verifies all the signature shares at once as VerifyPartialBatch
+// does. It also returns the sorted positions in sigs of the invalid shares,
+// whose signers can be found with IndexOf, even when the recovery succeeds.
+func (s *scheme) RecoverBatch(public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, []int, error) {
+	valid, invalid, err := s.verifyBatch(public, msg, sigs)
+	if err != nil {
+		return nil, nil, err
+	}
+	var bad []int
+	if len(invalid) > 0 {
+		bad = (&sign.BatchError{Invalid: invalid}).Indices()
+	}
+
+	if len(valid) < t {
+		return nil, bad, errors.New("not enough valid partial signatures")
+	}
+	pubShares := make([]*share.PubShare, len(valid))
+	for i, p := range valid {
+		pubShares[i] = &share.PubShare{I: p.index, V: p.point}
+	}
+	commit, err := share.RecoverCommit(s.sigGroup, pubShares, t, n)
+	if err != nil {
+		return nil, bad, err
+	}
+	sig, err := commit.MarshalBinary()
+	if err != nil {
+		return nil, bad, err
+	}
+	return sig, bad, nil
+}
+
+// verifyBatch returns the valid partial signatures, and the errors of the
+// invalid ones by position in sigs.
+func (s *scheme) verifyBatch(public *share.PubPoly, msg []byte, sigs [][]byte) ([]*partial, map[int]error, error) {
+	hashable, ok := s.sigGroup.Point().(kyber.HashablePoint)
+	if !ok {
+		return nil, nil, errors.New("point needs to implement hashablePoint")
+	}
+	H := hashable.Hash(msg)
+	_, commits := public.Info()
+
+	invalid := make(map[int]error)
+	partials := make([]*partial, 0, len(sigs))
+	for pos, sig := range sigs {
+		p, err := s.decodePartial(sig)
+		if err != nil {
+			invalid[pos] = err
+			continue
+		}
+		p.pos = pos
+		partials = append(partials, p)
+	}
+
+	rand := random.New()
+	var bisect func(ps []*partial) []*partial
+	bisect = func(ps []*partial) []*partial {
+		switch {
+		case len(ps) == 0:
+			return nil
+		case s.checkBatch(H, commits, ps, rand):
+			return ps
+		case len(ps) == 1:
+			invalid[ps[0].pos] = errors.New("invalid partial signature")
+			return nil
+		}
+		half := len(ps) / 2
+		return append(bisect(ps[:half]), bisect(ps[half:])...)
+	}
+	return bisect(partials), invalid, nil
+}
+
+// decodePartial returns the index and the point of the partial signature,
+// checking that the point is in the prime-order subgroup.
+func (s *scheme) decodePartial(sig []byte) (*partial, error) {
+	i, err := s.IndexOf(sig)
+	if err != nil {
+		return nil, err
+	}
+	sh := SigShare(sig)
+	point := s.sigGroup.Point()
+	if err := point.UnmarshalBinary(sh.Value()); err != nil {
+		return nil, fmt.Errorf("invalid partial signature: %w", err)
+	}
+	if sub, ok := point.(kyber.SubGroupElement); ok && !sub.IsInCorrectGroup() {
+		return nil, errors.New("invalid partial signature: point not in the prime-order subgroup")
+	}
+	return &partial{index: uint32(i), point: point}, nil
+}
+
+// checkBatch checks that e(sum r_i*S_i, B) == e(H, sum r_i*X_i) for random
+// r_i, where S_i are the partial signatures and X_i the public key shares,
+// with the arguments of the pairings swapped for signatures on G2. The sum
+// of the r_i*X_i is computed from the commitments of the public polynomial
+// as sum_j (sum_i r_i*(i+1)^j)*C_j.
+func (s *scheme) checkBatch(H kyber.Point, commits []kyber.Point, ps []*partial, rand cipher.Stream) bool {
+	rs := make([]kyber.Scalar, len(ps))
+	points := make([]kyber.Point, len(ps))
+	coeffs := make([]kyber.Scalar, len(commits))
+	for j := range coeffs {
+		coeffs[j] = s.keyGroup.Scalar().Zero()
+	}
+	for k, p := range ps {
+		rs[k] = s.keyGroup.Scalar().Pick(rand)
+		points[k] = p.point
+		x := s.keyGroup.Scalar().SetInt64(1 + int64(p.index))
+		pow := rs[k].Clone()
+		for j := range coeffs {
+			coeffs[j].Add(coeffs[j], pow)
+			pow.Mul(pow, x)
+		}
+	}
+	S := msm.MultiScalarMul(s.sigGroup, rs, points)
+	X := msm.MultiScalarMul(s.keyGroup, coeffs, commits)
+	B := s.keyGroup.Point().Base()
+	if s.onG1 {
+		return s.suite.ValidatePairing(H, X, S, B)
+	}
+	return s.suite.ValidatePairing(X, H, B, S)
+}
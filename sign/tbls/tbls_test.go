@@ -14,6 +14,7 @@ import (
 	"go.dedis.ch/kyber/v4/pairing/bn256"
 	"go.dedis.ch/kyber/v4/share"
 	"go.dedis.ch/kyber/v4/sign/bls"
+	"go.dedis.ch/kyber/v4/util/random"
 	"go.dedis.ch/kyber/v4/xof/blake2xb"
 )
 
@@ -101,3 +102,37 @@ func TestBN256(t *testing.T) {
 	scheme := NewThresholdSchemeOnG1(suite)
 	test.ThresholdTest(t, suite.G2(), scheme)
 }
+
+func BenchmarkRecover(b *testing.B) {
+	suite := bn256.NewSuite()
+	scheme := NewThresholdSchemeOnG1(suite)
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	n := 128
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, random.New())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	sigs := make([][]byte, 0, n)
+	for _, x := range priPoly.Shares(n) {
+		sig, err := scheme.Sign(x, msg)
+		require.NoError(b, err)
+		sigs = append(sigs, sig)
+	}
+	// A few invalid shares.
+	for i := 0; i < 4; i++ {
+		sigs[i] = append([]byte{}, sigs[n-1]...)
+		sigs[i][1] = byte(i)
+	}
+
+	b.Run("OneByOne", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := scheme.Recover(pubPoly, msg, sigs, t, n)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, err := scheme.(BatchRecoverer).RecoverBatch(pubPoly, msg, sigs, t, n)
+			require.NoError(b, err)
+		}
+	})
+}
@@ -1,8 +1,11 @@
 package suites
 
 import (
+	"go.dedis.ch/kyber/v4/group/ed448"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
 	"go.dedis.ch/kyber/v4/group/p256"
+	"go.dedis.ch/kyber/v4/group/p256ct"
+	"go.dedis.ch/kyber/v4/group/ristretto255"
 	"go.dedis.ch/kyber/v4/group/s256"
 	"go.dedis.ch/kyber/v4/pairing/bls12381/circl"
 	"go.dedis.ch/kyber/v4/pairing/bls12381/kilic"
@@ -23,7 +26,11 @@ func init() {
 	register(bn254.NewSuite())
 	register(circl.NewSuiteBLS12381())
 	register(kilic.NewSuiteBLS12381())
-	// This is a constant time implementation that should be
+	// Those are constant time implementations that should be
 	// used as much as possible
-	register(edwards25519.NewBlakeSHA256Ed25519())
+	registerConstantTime(edwards25519.NewBlakeSHA256Ed25519())
+	registerConstantTime(ristretto255.NewBlakeSHA512Ristretto255())
+	registerConstantTime(p256ct.NewBlakeSHA256P256())
+	registerConstantTime(ed448.NewSHAKE256Ed448())
+	registerConstantTime(ed448.NewSHAKE256Decaf448())
 }
@@ -0,0 +1,237 @@
+package suites
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+)
+
+// EnvelopeVersion is the version of the envelope format written by
+// Envelope. An envelope is laid out as
+//
+//	version (1 byte) || len(name) (1 byte) || name || id (8 bytes) ||
+//	len(payload) (2 bytes, big-endian) || payload
+//
+// where name is the lowercase name of the suite under which it is
+// registered, id is the MarshalID of the object, which tells which kind of
+// object of the suite the payload holds, and payload is the output of its
+// MarshalBinary method.
+const EnvelopeVersion = 1
+
+var (
+	// ErrEnvelopeVersion is returned when reading an envelope of an
+	// unsupported version.
+	ErrEnvelopeVersion = errors.New("unsupported envelope version")
+	// ErrEnvelopeSuite is returned when reading an envelope written under
+	// another suite than the expected one.
+	ErrEnvelopeSuite = errors.New("envelope of another suite")
+	// ErrEnvelopeKind is returned when reading an envelope holding another
+	// kind of object than the expected one, or when writing an object
+	// which does not belong to the suite of the Envelope.
+	ErrEnvelopeKind = errors.New("envelope of another kind")
+)
+
+// marshalIDer is implemented by the Points and Scalars of every registered
+// suite.
+type marshalIDer interface {
+	MarshalID() [8]byte
+}
+
+// Envelope is a kyber.Encoding that writes each Point and Scalar in a
+// self-describing envelope, prefixed with the name of its suite and its
+// MarshalID, so that reading it in the context of another suite or as
+// another kind of object fails instead of returning an unrelated value.
+//
+// Unlike the Write and Read methods of the suites, an Envelope does not
+// walk through structs, slices or arrays: each object given to Write and
+// Read must be a single Point or Scalar, so that every envelope holds
+// exactly one tagged object.
+type Envelope struct {
+	suite Suite
+	name  string
+}
+
+// NewEnvelope returns the Envelope encoding of the suite s.
+func NewEnvelope(s Suite) *Envelope {
+	return &Envelope{suite: s, name: strings.ToLower(s.String())}
+}
+
+// Write writes each of objs in its own envelope. The objects must be Points
+// or Scalars of the suite of the Envelope; structs and slices of them are
+// not supported.
+func (e *Envelope) Write(w io.Writer, objs ...interface{}) error {
+	for _, obj := range objs {
+		m, ok := obj.(kyber.Marshaling)
+		if !ok {
+			return fmt.Errorf("envelope: cannot encode %T", obj)
+		}
+		id, err := e.idOf(m)
+		if err != nil {
+			return err
+		}
+		payload, err := m.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if len(e.name) > 255 || len(payload) > 65535 {
+			return errors.New("envelope: suite name or object too long")
+		}
+
+		buf := make([]byte, 0, 12+len(e.name)+len(payload))
+		buf = append(buf, EnvelopeVersion, byte(len(e.name)))
+		buf = append(buf, e.name...)
+		buf = append(buf, id[:]...)
+		buf = binary.BigEndian.AppendUint16(buf, uint16(len(payload)))
+		buf = append(buf, payload...)
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read reads the envelopes of objs, which are either Points and Scalars of
+// the suite of the Envelope, or pointers to kyber.Point or kyber.Scalar
+// variables, which are then set to freshly allocated values. As for Write,
+// structs and slices are not supported. An error wrapping ErrEnvelopeSuite
+// or ErrEnvelopeKind is returned for an envelope of another suite or of
+// another kind of object.
+func (e *Envelope) Read(r io.Reader, objs ...interface{}) error {
+	for _, obj := range objs {
+		name, id, payload, err := readEnvelope(r)
+		if err != nil {
+			return err
+		}
+		if name != e.name {
+			return fmt.Errorf("envelope: %w: got %q, expected %q",
+				ErrEnvelopeSuite, name, e.name)
+		}
+
+		var m kyber.Marshaling
+		switch o := obj.(type) {
+		case *kyber.Point:
+			p, ok := e.newObject(id).(kyber.Point)
+			if !ok {
+				return fmt.Errorf("envelope: %w: got a %q, expected a point", ErrEnvelopeKind, id[:])
+			}
+			*o, m = p, p
+		case *kyber.Scalar:
+			s, ok := e.newObject(id).(kyber.Scalar)
+			if !ok {
+				return fmt.Errorf("envelope: %w: got a %q, expected a scalar", ErrEnvelopeKind, id[:])
+			}
+			*o, m = s, s
+		case kyber.Marshaling:
+			expected, err := e.idOf(o)
+			if err != nil {
+				return err
+			}
+			if id != expected {
+				return fmt.Errorf("envelope: %w: got a %q, expected a %q", ErrEnvelopeKind, id[:], expected[:])
+			}
+			m = o
+		default:
+			return fmt.Errorf("envelope: cannot decode into %T", obj)
+		}
+
+		if err := m.UnmarshalBinary(payload); err != nil {
+			return fmt.Errorf("envelope: invalid %q: %w", id[:], err)
+		}
+	}
+	return nil
+}
+
+// ReadEnvelope reads a single envelope from r, and returns the registered
+// suite it was written under along with the Point or Scalar it holds.
+func ReadEnvelope(r io.Reader) (Suite, kyber.Marshaling, error) {
+	name, id, payload, err := readEnvelope(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := Find(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("envelope: suite %q: %w", name, err)
+	}
+
+	e := NewEnvelope(s)
+	m := e.newObject(id)
+	if m == nil {
+		return nil, nil, fmt.Errorf("envelope: %w: suite %q has no %q", ErrEnvelopeKind, name, id[:])
+	}
+	if err := m.UnmarshalBinary(payload); err != nil {
+		return nil, nil, fmt.Errorf("envelope: invalid %q: %w", id[:], err)
+	}
+	return s, m, nil
+}
+
+// readEnvelope reads the fields of an envelope from r.
+func readEnvelope(r io.Reader) (string, [8]byte, []byte, error) {
+	var id [8]byte
+	var hdr [2]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return "", id, nil, err
+	}
+	if hdr[0] != EnvelopeVersion {
+		return "", id, nil, fmt.Errorf("envelope: %w: %d", ErrEnvelopeVersion, hdr[0])
+	}
+
+	buf := make([]byte, int(hdr[1])+len(id)+2)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", id, nil, err
+	}
+	name := string(buf[:hdr[1]])
+	copy(id[:], buf[hdr[1]:])
+
+	payload := make([]byte, binary.BigEndian.Uint16(buf[int(hdr[1])+len(id):]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return "", id, nil, err
+	}
+	return name, id, payload, nil
+}
+
+// objects returns a new Scalar and a new Point of each kind of the suite
+// of the Envelope.
+func (e *Envelope) objects() []kyber.Marshaling {
+	if ps, ok := e.suite.(pairing.Suite); ok {
+		return []kyber.Marshaling{ps.G1().Scalar(), ps.G1().Point(), ps.G2().Point(), ps.GT().Point()}
+	}
+	return []kyber.Marshaling{e.suite.Scalar(), e.suite.Point()}
+}
+
+// idOf returns the MarshalID of m, which must be a Scalar or a Point of the
+// suite of the Envelope.
+func (e *Envelope) idOf(m kyber.Marshaling) ([8]byte, error) {
+	for _, o := range e.objects() {
+		if reflect.TypeOf(m) != reflect.TypeOf(o) {
+			continue
+		}
+		// Scalars of different suites may share the same type, such as
+		// mod.Int, but not the same modulus.
+		s, isScalar := m.(kyber.Scalar)
+		expected, _ := o.(kyber.Scalar)
+		if isScalar && s.GroupOrder().Cmp(expected.GroupOrder()) != 0 {
+			break
+		}
+		if id, ok := o.(marshalIDer); ok {
+			return id.MarshalID(), nil
+		}
+	}
+	return [8]byte{}, fmt.Errorf("envelope: %w: %T is not an object of suite %q", ErrEnvelopeKind, m, e.name)
+}
+
+// newObject returns a new object of the suite of the Envelope with the
+// given MarshalID, or nil if the suite has none.
+func (e *Envelope) newObject(id [8]byte) kyber.Marshaling {
+	for _, o := range e.objects() {
+		if oid, ok := o.(marshalIDer); ok && oid.MarshalID() == id {
+			return o
+		}
+	}
+	return nil
+}
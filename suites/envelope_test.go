@@ -0,0 +1,139 @@
+package suites
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/pairing"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var _ kyber.Encoding = (*Envelope)(nil)
+
+// objects returns a random scalar and random points of each kind of s.
+func objects(s Suite) []kyber.Marshaling {
+	rng := random.New()
+	if ps, ok := s.(pairing.Suite); ok {
+		return []kyber.Marshaling{
+			ps.G1().Scalar().Pick(rng),
+			ps.G1().Point().Pick(rng),
+			ps.G2().Point().Pick(rng),
+			ps.Pair(ps.G1().Point().Base(), ps.G2().Point().Base()),
+		}
+	}
+	return []kyber.Marshaling{s.Scalar().Pick(rng), s.Point().Pick(rng)}
+}
+
+func marshal(t *testing.T, m kyber.Marshaling) []byte {
+	b, err := m.MarshalBinary()
+	require.NoError(t, err)
+	return b
+}
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	for name, s := range suites {
+		e := NewEnvelope(s)
+		objs := objects(s)
+
+		var buf bytes.Buffer
+		for _, o := range objs {
+			require.NoError(t, e.Write(&buf, o), name)
+		}
+		data := buf.Bytes()
+
+		// Decoding into allocated values and into interface variables.
+		r := bytes.NewReader(data)
+		sc2 := objs[0].(kyber.Scalar).Clone().Zero()
+		require.NoError(t, e.Read(r, sc2), name)
+		require.True(t, sc2.Equal(objs[0].(kyber.Scalar)), name)
+
+		r.Reset(data)
+		var sc kyber.Scalar
+		var p kyber.Point
+		require.NoError(t, e.Read(r, &sc, &p), name)
+		require.True(t, sc.Equal(objs[0].(kyber.Scalar)), name)
+		require.True(t, p.Equal(objs[1].(kyber.Point)), name)
+
+		// Self-describing decoding.
+		r.Reset(data)
+		for _, o := range objs {
+			s2, m, err := ReadEnvelope(r)
+			require.NoError(t, err, name)
+			require.Equal(t, s.String(), s2.String(), name)
+			require.Equal(t, marshal(t, o), marshal(t, m), name)
+		}
+	}
+}
+
+func TestEnvelope_Mismatch(t *testing.T) {
+	p256, bn254 := MustFind("P256"), MustFind("bn254")
+	p := p256.Point().Pick(random.New())
+
+	var buf bytes.Buffer
+	require.NoError(t, NewEnvelope(p256).Write(&buf, p))
+
+	// A P-256 point read in a BN254 context.
+	var q kyber.Point
+	err := NewEnvelope(bn254).Read(bytes.NewReader(buf.Bytes()), &q)
+	require.ErrorIs(t, err, ErrEnvelopeSuite)
+	require.Nil(t, q)
+
+	// A P-256 point written in a BN254 context.
+	require.ErrorIs(t, NewEnvelope(bn254).Write(&buf, p), ErrEnvelopeKind)
+	// A P-256 scalar has the same type as a BN254 one but another modulus.
+	require.ErrorIs(t, NewEnvelope(bn254).Write(&buf, p256.Scalar()), ErrEnvelopeKind)
+
+	// A point read as a scalar.
+	var sc kyber.Scalar
+	err = NewEnvelope(p256).Read(bytes.NewReader(buf.Bytes()), &sc)
+	require.ErrorIs(t, err, ErrEnvelopeKind)
+	err = NewEnvelope(p256).Read(bytes.NewReader(buf.Bytes()), p256.Scalar())
+	require.ErrorIs(t, err, ErrEnvelopeKind)
+
+	// A G1 point read as a G2 point.
+	bn := bn254.(pairing.Suite)
+	buf.Reset()
+	require.NoError(t, NewEnvelope(bn254).Write(&buf, bn.G1().Point().Base()))
+	err = NewEnvelope(bn254).Read(bytes.NewReader(buf.Bytes()), bn.G2().Point())
+	require.ErrorIs(t, err, ErrEnvelopeKind)
+
+	// Unknown version, truncated and corrupted envelopes.
+	data := buf.Bytes()
+	bad := append([]byte{EnvelopeVersion + 1}, data[1:]...)
+	_, _, err = ReadEnvelope(bytes.NewReader(bad))
+	require.ErrorIs(t, err, ErrEnvelopeVersion)
+	_, _, err = ReadEnvelope(bytes.NewReader(data[:len(data)-1]))
+	require.Error(t, err)
+	bad = append([]byte{}, data...)
+	bad[len(bad)-1] ^= 1
+	_, _, err = ReadEnvelope(bytes.NewReader(bad))
+	require.Error(t, err)
+}
+
+func TestEnvelope_MarshalID(t *testing.T) {
+	for name, s := range suites {
+		e := NewEnvelope(s)
+		ids := make(map[[8]byte]bool)
+		for _, o := range objects(s) {
+			m, ok := o.(marshalIDer)
+			require.True(t, ok, "%s: %T has no MarshalID", name, o)
+			id := m.MarshalID()
+			require.False(t, ids[id], "%s: duplicate id %q", name, id[:])
+			ids[id] = true
+
+			// The tag follows the version and the suite name.
+			var buf bytes.Buffer
+			require.NoError(t, e.Write(&buf, o), name)
+			require.Equal(t, id[:], buf.Bytes()[2+len(e.name):10+len(e.name)], name)
+		}
+	}
+
+	// Structs and slices are not walked through.
+	s := MustFind("ed25519")
+	var buf bytes.Buffer
+	objs := []kyber.Point{s.Point().Base()}
+	require.Error(t, NewEnvelope(s).Write(&buf, objs))
+	require.Error(t, NewEnvelope(s).Write(&buf, struct{ P kyber.Point }{s.Point()}))
+}
@@ -1,7 +1,12 @@
 // Package suites allows callers to look up Kyber suites by name.
 //
-// Currently, only the "ed25519" suite is available with a constant
-// time implementation and the other ones use variable time algorithms.
+// Currently, only the "ed25519", "ristretto255", "P256-CT", "ed448" and
+// "decaf448" suites are available with a constant time implementation and
+// the other ones use variable time algorithms.
+//
+// The Envelope encoding writes Points and Scalars prefixed with the name of
+// their suite and their kind, so that they can be decoded without knowing
+// their suite in advance, and are rejected when read under another suite.
 package suites
 
 import (
@@ -22,6 +27,10 @@ type Suite interface {
 
 var suites = map[string]Suite{}
 
+// constTimeSuites holds the names of the suites implemented with constant
+// time algorithms.
+var constTimeSuites = map[string]bool{}
+
 var requireConstTime = false
 
 // register is called by suites to make themselves known to Kyber.
@@ -29,6 +38,13 @@ func register(s Suite) {
 	suites[strings.ToLower(s.String())] = s
 }
 
+// registerConstantTime is called by suites implemented with constant time
+// algorithms, which remain available after RequireConstantTime.
+func registerConstantTime(s Suite) {
+	register(s)
+	constTimeSuites[strings.ToLower(s.String())] = true
+}
+
 // ErrUnknownSuite indicates that the suite was not one of the
 // registered suites.
 var ErrUnknownSuite = errors.New("unknown suite")
@@ -36,7 +52,7 @@ var ErrUnknownSuite = errors.New("unknown suite")
 // Find looks up a suite by name.
 func Find(name string) (Suite, error) {
 	if s, ok := suites[strings.ToLower(name)]; ok {
-		if requireConstTime && strings.ToLower(s.String()) != "ed25519" {
+		if requireConstTime && !constTimeSuites[strings.ToLower(s.String())] {
 			return nil, errors.New(
 				"requested suite exists but is not implemented " +
 					"with constant time algorithms as required by " +
@@ -9,11 +9,15 @@ import (
 func TestSuites_Find(t *testing.T) {
 	ss := []string{
 		"ed25519",
+		"Ristretto255",
 		"bn256.G1",
 		"bn256.G2",
 		"bn256.GT",
 		"P256",
+		"P256-CT",
 		"Residue512",
+		"Ed448",
+		"Decaf448",
 	}
 
 	for _, name := range ss {
@@ -37,4 +41,16 @@ func TestSuites_ConstTime(t *testing.T) {
 	s, err = Find("ed25519")
 	require.NoError(t, err)
 	require.NotNil(t, s)
+
+	s, err = Find("ristretto255")
+	require.NoError(t, err)
+	require.NotNil(t, s)
+
+	s, err = Find("P256")
+	require.Error(t, err)
+	require.Nil(t, s)
+
+	s, err = Find("P256-CT")
+	require.NoError(t, err)
+	require.NotNil(t, s)
 }
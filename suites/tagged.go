@@ -0,0 +1,41 @@
+package suites
+
+import (
+	"errors"
+	"strings"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/util/encoding"
+)
+
+// FindTaggedPoint reads a point in the tagged form of package
+// go.dedis.ch/kyber/v4/util/encoding, looking up its group among the
+// registered suites.
+func FindTaggedPoint(s string) (Suite, kyber.Point, error) {
+	suite, err := findTag(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	point, err := encoding.StringTaggedToPoint(suite, s)
+	return suite, point, err
+}
+
+// FindTaggedScalar reads a scalar in the tagged form of package
+// go.dedis.ch/kyber/v4/util/encoding, looking up its group among the
+// registered suites.
+func FindTaggedScalar(s string) (Suite, kyber.Scalar, error) {
+	suite, err := findTag(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	scalar, err := encoding.StringTaggedToScalar(suite, s)
+	return suite, scalar, err
+}
+
+func findTag(s string) (Suite, error) {
+	name, _, found := strings.Cut(s, encoding.TagSeparator)
+	if !found {
+		return nil, errors.New("suites: missing group tag")
+	}
+	return Find(name)
+}
@@ -0,0 +1,31 @@
+package suites
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/util/encoding"
+)
+
+func TestFindTagged(t *testing.T) {
+	s := MustFind("Ed25519")
+	p := s.Point().Pick(s.RandomStream())
+	sc := s.Scalar().Pick(s.RandomStream())
+	pstr, err := encoding.PointToStringTagged(s, p)
+	require.NoError(t, err)
+	scstr, err := encoding.ScalarToStringTagged(s, sc)
+	require.NoError(t, err)
+
+	suite, p2, err := FindTaggedPoint(pstr)
+	require.NoError(t, err)
+	require.Equal(t, s.String(), suite.String())
+	require.True(t, p.Equal(p2))
+	_, s2, err := FindTaggedScalar(scstr)
+	require.NoError(t, err)
+	require.True(t, sc.Equal(s2))
+
+	_, _, err = FindTaggedPoint("unknown:abcd")
+	require.Error(t, err)
+	_, _, err = FindTaggedPoint("abcd")
+	require.Error(t, err)
+}
@@ -1,16 +1,28 @@
 // Package encoding package provides helper functions to encode/decode a Point/Scalar in
-// hexadecimal.
+// hexadecimal, base64 and base64url, and in a tagged form carrying the name of
+// its group. It also decodes JSON documents holding Points and Scalars, which
+// are encoded as strings by their MarshalText method.
 package encoding
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 
 	"go.dedis.ch/kyber/v4"
 )
 
+// TagSeparator separates the group name from the hexadecimal encoding in
+// the tagged form of Points and Scalars.
+const TagSeparator = ":"
+
+// ErrGroupMismatch is returned when decoding a tagged Point or Scalar of
+// another group than the expected one.
+var ErrGroupMismatch = errors.New("encoding: group mismatch")
+
 // ReadHexPoint reads a point from r in hex representation.
 func ReadHexPoint(group kyber.Group, r io.Reader) (kyber.Point, error) {
 	point := group.Point()
@@ -83,6 +95,120 @@ func StringHexToScalar(group kyber.Group, str string) (kyber.Scalar, error) {
 	return ReadHexScalar(group, strings.NewReader(str))
 }
 
+// PointToStringBase64 encodes a point in standard base64, with padding.
+func PointToStringBase64(_ kyber.Group, point kyber.Point) (string, error) {
+	return toString(point, base64.StdEncoding.EncodeToString)
+}
+
+// StringBase64ToPoint reads a point in standard base64 from a string.
+func StringBase64ToPoint(group kyber.Group, s string) (kyber.Point, error) {
+	point := group.Point()
+	return point, fromString(point, s, base64.StdEncoding.DecodeString)
+}
+
+// ScalarToStringBase64 encodes a scalar in standard base64, with padding.
+func ScalarToStringBase64(_ kyber.Group, scalar kyber.Scalar) (string, error) {
+	return toString(scalar, base64.StdEncoding.EncodeToString)
+}
+
+// StringBase64ToScalar reads a scalar in standard base64 from a string.
+func StringBase64ToScalar(group kyber.Group, s string) (kyber.Scalar, error) {
+	scalar := group.Scalar()
+	return scalar, fromString(scalar, s, base64.StdEncoding.DecodeString)
+}
+
+// PointToStringBase64URL encodes a point in base64url without padding, as
+// in JSON Web Keys, so that it can be used in URLs and file names.
+func PointToStringBase64URL(_ kyber.Group, point kyber.Point) (string, error) {
+	return toString(point, base64.RawURLEncoding.EncodeToString)
+}
+
+// StringBase64URLToPoint reads a point in base64url without padding from a
+// string.
+func StringBase64URLToPoint(group kyber.Group, s string) (kyber.Point, error) {
+	point := group.Point()
+	return point, fromString(point, s, base64.RawURLEncoding.DecodeString)
+}
+
+// ScalarToStringBase64URL encodes a scalar in base64url without padding.
+func ScalarToStringBase64URL(_ kyber.Group, scalar kyber.Scalar) (string, error) {
+	return toString(scalar, base64.RawURLEncoding.EncodeToString)
+}
+
+// StringBase64URLToScalar reads a scalar in base64url without padding from
+// a string.
+func StringBase64URLToScalar(group kyber.Group, s string) (kyber.Scalar, error) {
+	scalar := group.Scalar()
+	return scalar, fromString(scalar, s, base64.RawURLEncoding.DecodeString)
+}
+
+// PointToStringTagged encodes a point as the name of its group followed by
+// TagSeparator and its hexadecimal encoding, e.g. "Ed25519:5866...6666".
+func PointToStringTagged(group kyber.Group, point kyber.Point) (string, error) {
+	return toString(point, func(b []byte) string {
+		return group.String() + TagSeparator + hex.EncodeToString(b)
+	})
+}
+
+// StringTaggedToPoint reads a tagged point from a string. It returns
+// ErrGroupMismatch if the point is tagged with another group than group.
+func StringTaggedToPoint(group kyber.Group, s string) (kyber.Point, error) {
+	enc, err := untag(group, s)
+	if err != nil {
+		return nil, err
+	}
+	point := group.Point()
+	return point, fromString(point, enc, hex.DecodeString)
+}
+
+// ScalarToStringTagged encodes a scalar as the name of its group followed
+// by TagSeparator and its hexadecimal encoding.
+func ScalarToStringTagged(group kyber.Group, scalar kyber.Scalar) (string, error) {
+	return toString(scalar, func(b []byte) string {
+		return group.String() + TagSeparator + hex.EncodeToString(b)
+	})
+}
+
+// StringTaggedToScalar reads a tagged scalar from a string. It returns
+// ErrGroupMismatch if the scalar is tagged with another group than group.
+func StringTaggedToScalar(group kyber.Group, s string) (kyber.Scalar, error) {
+	enc, err := untag(group, s)
+	if err != nil {
+		return nil, err
+	}
+	scalar := group.Scalar()
+	return scalar, fromString(scalar, enc, hex.DecodeString)
+}
+
+// untag checks the group tag of s and returns the encoding that follows.
+// Group names are compared case-insensitively, like in package suites.
+func untag(group kyber.Group, s string) (string, error) {
+	name, enc, found := strings.Cut(s, TagSeparator)
+	if !found {
+		return "", errors.New("encoding: missing group tag")
+	}
+	if !strings.EqualFold(name, group.String()) {
+		return "", fmt.Errorf("%w: got %q, expected %q", ErrGroupMismatch, name, group.String())
+	}
+	return enc, nil
+}
+
+func toString(m kyber.Marshaling, encode func([]byte) string) (string, error) {
+	buf, err := m.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return encode(buf), nil
+}
+
+func fromString(m kyber.Marshaling, s string, decode func(string) ([]byte, error)) error {
+	buf, err := decode(s)
+	if err != nil {
+		return err
+	}
+	return m.UnmarshalBinary(buf)
+}
+
 func getHex(r io.Reader, l int) ([]byte, error) {
 	bufHex := make([]byte, l*2)
 	bufByte := make([]byte, l)
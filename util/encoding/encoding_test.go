@@ -2,11 +2,17 @@ package encoding
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4"
 	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/pairing/bn254"
+	"go.dedis.ch/kyber/v4/share"
+	dkg "go.dedis.ch/kyber/v4/share/dkg/pedersen"
+	"go.dedis.ch/kyber/v4/share/pvss"
 )
 
 var s = edwards25519.NewBlakeSHA256Ed25519()
@@ -98,3 +104,123 @@ func TestReadHexPointErrorNotEnoughBytes(t *testing.T) {
 	require.Error(t, err, "Expected error when not enough bytes from stream, but got nil")
 	require.EqualError(t, err, "didn't get enough bytes from stream", "Expected error message: didn't get enough bytes from stream, but got %s", err.Error())
 }
+
+func TestBase64Strings(t *testing.T) {
+	p := s.Point().Pick(s.RandomStream())
+	sc := s.Scalar().Pick(s.RandomStream())
+
+	pstr, err := PointToStringBase64(s, p)
+	require.NoError(t, err)
+	p2, err := StringBase64ToPoint(s, pstr)
+	require.NoError(t, err)
+	require.True(t, p.Equal(p2))
+
+	scstr, err := ScalarToStringBase64(s, sc)
+	require.NoError(t, err)
+	s2, err := StringBase64ToScalar(s, scstr)
+	require.NoError(t, err)
+	require.True(t, sc.Equal(s2))
+
+	pstr, err = PointToStringBase64URL(s, p)
+	require.NoError(t, err)
+	require.NotContains(t, pstr, "=")
+	p2, err = StringBase64URLToPoint(s, pstr)
+	require.NoError(t, err)
+	require.True(t, p.Equal(p2))
+
+	scstr, err = ScalarToStringBase64URL(s, sc)
+	require.NoError(t, err)
+	s2, err = StringBase64URLToScalar(s, scstr)
+	require.NoError(t, err)
+	require.True(t, sc.Equal(s2))
+
+	_, err = StringBase64ToPoint(s, "not base64!")
+	require.Error(t, err)
+}
+
+func TestTaggedStrings(t *testing.T) {
+	p := s.Point().Pick(s.RandomStream())
+	sc := s.Scalar().Pick(s.RandomStream())
+
+	pstr, err := PointToStringTagged(s, p)
+	require.NoError(t, err)
+	require.Regexp(t, "^Ed25519:[0-9a-f]{64}$", pstr)
+	p2, err := StringTaggedToPoint(s, pstr)
+	require.NoError(t, err)
+	require.True(t, p.Equal(p2))
+
+	scstr, err := ScalarToStringTagged(s, sc)
+	require.NoError(t, err)
+	s2, err := StringTaggedToScalar(s, scstr)
+	require.NoError(t, err)
+	require.True(t, sc.Equal(s2))
+
+	// An Ed25519 point is rejected by another group.
+	bn := bn254.NewSuite()
+	_, err = StringTaggedToPoint(bn.G1(), pstr)
+	require.ErrorIs(t, err, ErrGroupMismatch)
+	_, err = StringTaggedToPoint(s, "abcd")
+	require.Error(t, err)
+}
+
+func TestUnmarshalJSONDistKeyShare(t *testing.T) {
+	dks := &dkg.DistKeyShare{
+		Commits: []kyber.Point{
+			s.Point().Pick(s.RandomStream()),
+			s.Point().Pick(s.RandomStream()),
+		},
+		Share: &share.PriShare{I: 3, V: s.Scalar().Pick(s.RandomStream())},
+	}
+	data, err := json.Marshal(dks)
+	require.NoError(t, err)
+
+	pstr, err := PointToStringHex(s, dks.Commits[0])
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"`+pstr+`"`)
+
+	var dks2 dkg.DistKeyShare
+	require.NoError(t, UnmarshalJSON(s, data, &dks2))
+	require.Len(t, dks2.Commits, 2)
+	for i := range dks.Commits {
+		require.True(t, dks.Commits[i].Equal(dks2.Commits[i]))
+	}
+	require.Equal(t, dks.Share.I, dks2.Share.I)
+	require.True(t, dks.Share.V.Equal(dks2.Share.V))
+
+	// json.Unmarshal can decode into already allocated values.
+	dks3 := &dkg.DistKeyShare{
+		Commits: []kyber.Point{s.Point(), s.Point()},
+		Share:   &share.PriShare{V: s.Scalar()},
+	}
+	require.NoError(t, json.Unmarshal(data, dks3))
+	require.True(t, dks.Public().Equal(dks3.Public()))
+
+	require.Error(t, UnmarshalJSON(s, data, dks2))
+	require.Error(t, UnmarshalJSON(s, []byte(`{"Commits": ["zz"]}`), &dks2))
+}
+
+func TestUnmarshalJSONPVSS(t *testing.T) {
+	n, th := 5, 3
+	H := s.Point().Pick(s.XOF([]byte("H")))
+	X := make([]kyber.Point, n)
+	for i := range X {
+		X[i] = s.Point().Mul(s.Scalar().Pick(s.RandomStream()), nil)
+	}
+	shares, _, err := pvss.EncShares(s, H, X, s.Scalar().Pick(s.RandomStream()), th)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(shares)
+	require.NoError(t, err)
+
+	var shares2 []*pvss.PubVerShare
+	require.NoError(t, UnmarshalJSON(s, data, &shares2))
+	require.Len(t, shares2, n)
+	for i, sh := range shares2 {
+		require.Equal(t, shares[i].S.I, sh.S.I)
+		require.True(t, shares[i].S.V.Equal(sh.S.V))
+		require.True(t, shares[i].P.C.Equal(sh.P.C))
+		require.True(t, shares[i].P.R.Equal(sh.P.R))
+		require.True(t, shares[i].P.VG.Equal(sh.P.VG))
+		require.True(t, shares[i].P.VH.Equal(sh.P.VH))
+	}
+}
@@ -0,0 +1,182 @@
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+// Points and Scalars implement encoding.TextMarshaler, so json.Marshal
+// encodes them as hexadecimal strings without any help. json.Unmarshal
+// however cannot create the values of kyber.Point and kyber.Scalar
+// interfaces, which UnmarshalJSON does.
+
+var tPoint = reflect.TypeOf((*kyber.Point)(nil)).Elem()
+var tScalar = reflect.TypeOf((*kyber.Scalar)(nil)).Elem()
+var tUnmarshaler = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// UnmarshalJSON parses the JSON-encoded data and stores the result in the
+// value pointed to by v, like json.Unmarshal, creating the kyber.Point and
+// kyber.Scalar values found in v from group. Points and Scalars are read
+// from the strings written by their MarshalText method, so that e.g. a
+// dkg.DistKeyShare encoded with json.Marshal decodes with
+//
+//	var dks dkg.DistKeyShare
+//	err := encoding.UnmarshalJSON(suite, data, &dks)
+func UnmarshalJSON(group kyber.Group, data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("encoding: UnmarshalJSON needs a non-nil pointer")
+	}
+	return decodeJSON(group, data, rv.Elem())
+}
+
+func decodeJSON(group kyber.Group, data json.RawMessage, v reflect.Value) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+
+	switch v.Type() {
+	case tPoint:
+		p := group.Point()
+		if err := json.Unmarshal(data, p); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(p))
+		return nil
+	case tScalar:
+		s := group.Scalar()
+		if err := json.Unmarshal(data, s); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(s))
+		return nil
+	}
+	if reflect.PointerTo(v.Type()).Implements(tUnmarshaler) {
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeJSON(group, data, v.Elem())
+	case reflect.Struct:
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		return decodeStruct(group, fields, v)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break // base64 encoded []byte
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+		for i, e := range elems {
+			if err := decodeJSON(group, e, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Array:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		for i := 0; i < len(elems) && i < v.Len(); i++ {
+			if err := decodeJSON(group, elems[i], v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(data, &elems); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(v.Type(), len(elems)))
+		}
+		for k, e := range elems {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := decodeJSON(group, e, elem); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), elem)
+		}
+		return nil
+	}
+	return json.Unmarshal(data, v.Addr().Interface())
+}
+
+// decodeStruct decodes the fields of a JSON object into the struct v,
+// following the field naming rules of package encoding/json.
+func decodeStruct(group kyber.Group, fields map[string]json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+
+		// Fields of embedded structs are promoted to the outer object.
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fv := v.Field(i)
+				if fv.Kind() == reflect.Ptr {
+					if fv.IsNil() {
+						if !fv.CanSet() {
+							continue
+						}
+						fv.Set(reflect.New(ft))
+					}
+					fv = fv.Elem()
+				}
+				if err := decodeStruct(group, fields, fv); err != nil {
+					return err
+				}
+				continue
+			}
+			if !f.IsExported() {
+				continue
+			}
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		data, ok := fields[name]
+		if !ok {
+			for k, d := range fields {
+				if strings.EqualFold(k, name) {
+					data, ok = d, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := decodeJSON(group, data, v.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,10 +3,13 @@ package test
 import (
 	"bytes"
 	"crypto/cipher"
+	"encoding"
 	"github.com/stretchr/testify/require"
 	"testing"
 
 	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/fixedbase"
+	"go.dedis.ch/kyber/v4/group/msm"
 	"go.dedis.ch/kyber/v4/util/key"
 	"go.dedis.ch/kyber/v4/util/random"
 )
@@ -291,6 +294,74 @@ func testRandomlyPickedPoint(
 	return points
 }
 
+// testMultiScalarMul checks msm.MultiScalarMul and msm.Generic against a
+// naive sum of products, for sums on both sides of msm.StrausThreshold.
+func testMultiScalarMul(t *testing.T, g kyber.Group, rand cipher.Stream) {
+	for _, n := range []int{0, 1, 2, 7, msm.StrausThreshold + 6} {
+		scalars := make([]kyber.Scalar, n)
+		points := make([]kyber.Point, n)
+		for i := 0; i < n; i++ {
+			scalars[i] = g.Scalar().Pick(rand)
+			// nil points stand for the base point, as in Point.Mul
+			if i%5 != 1 {
+				points[i] = g.Point().Pick(rand)
+			}
+		}
+		if n > 2 {
+			// small and zero scalars must be handled as well
+			scalars[0].SetInt64(3)
+			scalars[2].Zero()
+		}
+
+		expected := g.Point().Null()
+		for i := range points {
+			expected.Add(expected, g.Point().Mul(scalars[i], points[i]))
+		}
+		if p := msm.MultiScalarMul(g, scalars, points); !p.Equal(expected) {
+			t.Errorf("MultiScalarMul of %d terms doesn't work: %v != %v", n, p, expected)
+		}
+		if p := msm.Generic(g, scalars, points); !p.Equal(expected) {
+			t.Errorf("generic MultiScalarMul of %d terms doesn't work: %v != %v", n, p, expected)
+		}
+	}
+}
+
+func testFixedBase(t *testing.T, g kyber.Group, rand cipher.Stream) {
+	P := g.Point().Pick(rand)
+	scalars := []kyber.Scalar{
+		g.Scalar().Zero(),
+		g.Scalar().One(),
+		g.Scalar().SetInt64(-1),
+		g.Scalar().Pick(rand),
+		g.Scalar().Pick(rand),
+	}
+	tables := map[string]kyber.FixedBase{
+		"precomputed": fixedbase.Precompute(g, P),
+		"generic":     fixedbase.Generic(g, P),
+	}
+	base := fixedbase.Precompute(g, nil)
+	for _, s := range scalars {
+		expected := g.Point().Mul(s, P)
+		for name, fb := range tables {
+			if p := fb.Mul(s); !p.Equal(expected) {
+				t.Errorf("%s fixed-base Mul doesn't work: %v != %v", name, p, expected)
+			}
+		}
+		if p := base.Mul(s); !p.Equal(g.Point().Mul(s, nil)) {
+			t.Errorf("fixed-base Mul of the base point doesn't work: %v", p)
+		}
+	}
+
+	// the table must not depend on the point once built
+	fb := fixedbase.Precompute(g, P)
+	s := scalars[3]
+	expected := g.Point().Mul(s, P)
+	P.Add(P, P)
+	if p := fb.Mul(s); !p.Equal(expected) {
+		t.Errorf("fixed-base table changed with its point: %v != %v", p, expected)
+	}
+}
+
 func testEncodingDecoding(t *testing.T, g kyber.Group, ptmp kyber.Point, stmp kyber.Scalar, rand cipher.Stream) {
 	buf := new(bytes.Buffer)
 	for i := 0; i < 5; i++ {
@@ -318,6 +389,42 @@ func testEncodingDecoding(t *testing.T, g kyber.Group, ptmp kyber.Point, stmp ky
 			t.Errorf("decoding produces different point than encoded")
 		}
 	}
+
+	testTextEncoding(t, g, g.Scalar().Pick(rand), g.Scalar())
+	testTextEncoding(t, g, g.Point().Pick(rand), g.Point())
+}
+
+// testTextEncoding checks that a Point or Scalar implements the
+// encoding.TextMarshaler and encoding.TextUnmarshaler interfaces, and
+// that its text encoding round-trips.
+func testTextEncoding(t *testing.T, g kyber.Group, m, tmp kyber.Marshaling) {
+	tm, ok := m.(encoding.TextMarshaler)
+	if !ok {
+		t.Errorf("%s: %T doesn't implement encoding.TextMarshaler", g, m)
+		return
+	}
+	tu, ok := tmp.(encoding.TextUnmarshaler)
+	if !ok {
+		t.Errorf("%s: %T doesn't implement encoding.TextUnmarshaler", g, tmp)
+		return
+	}
+	text, err := tm.MarshalText()
+	if err != nil {
+		t.Errorf("text encoding fails: %s", err.Error())
+		return
+	}
+	if err := tu.UnmarshalText(text); err != nil {
+		t.Errorf("text decoding fails: %s", err.Error())
+		return
+	}
+	b1, _ := m.MarshalBinary()
+	b2, _ := tmp.MarshalBinary()
+	if !bytes.Equal(b1, b2) {
+		t.Errorf("text decoding produces a different object than encoded")
+	}
+	if err := tu.UnmarshalText([]byte("not hex")); err == nil {
+		t.Errorf("text decoding of invalid text succeeds")
+	}
 }
 
 // Apply a generic set of validation tests to a cryptographic Group,
@@ -375,6 +482,12 @@ func testGroup(t *testing.T, g kyber.Group, rand cipher.Stream) []kyber.Point {
 	// homomorphic identities
 	testHomomorphicIdentities(t, primeOrder, g, gen, ptmp, p1, p2, dh1, stmp, s1, s2)
 
+	// Multi-scalar multiplication
+	testMultiScalarMul(t, g, rand)
+
+	// Fixed-base precomputed multiplication
+	testFixedBase(t, g, rand)
+
 	// Test randomly picked points
 	points = testRandomlyPickedPoint(t, primeOrder, points, g, gen, ptmp, stmp, rand)
 
@@ -403,6 +516,18 @@ func testGroup(t *testing.T, g kyber.Group, rand cipher.Stream) []kyber.Point {
 	return points
 }
 
+// MultiScalarMulTest checks the multi-scalar multiplication of a Group
+// against a naive sum of products.
+func MultiScalarMulTest(t *testing.T, g kyber.Group) {
+	testMultiScalarMul(t, g, random.New())
+}
+
+// FixedBaseTest checks the precomputed tables of a Group against the
+// variable base multiplication.
+func FixedBaseTest(t *testing.T, g kyber.Group) {
+	testFixedBase(t, g, random.New())
+}
+
 // GroupTest applies a generic set of validation tests to a cryptographic Group.
 func GroupTest(t *testing.T, g kyber.Group) {
 	testGroup(t, g, random.New())
@@ -0,0 +1,198 @@
+package vrf
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"math/big"
+
+	"go.dedis.ch/kyber/v4"
+	"go.dedis.ch/kyber/v4/group/edwards25519"
+	"go.dedis.ch/kyber/v4/group/p256"
+)
+
+// Suite is an ECVRF ciphersuite of RFC 9381.
+type Suite struct {
+	group kyber.Group
+	// suite_string of the RFC
+	id       byte
+	newHash  func() hash.Hash
+	ptLen    int
+	cofactor int64
+
+	encodePoint   func(P kyber.Point) ([]byte, error)
+	decodePoint   func(buf []byte) (kyber.Point, error)
+	encodeToCurve func(salt, alpha []byte) (kyber.Point, error)
+	// nonce returns the nonce of the proofs of the key for the hashed
+	// point h.
+	nonce func(key *PrivateKey, h []byte) kyber.Scalar
+	// newKey returns the scalar and the nonce prefix of the secret key.
+	newKey func(sk []byte) (kyber.Scalar, []byte, error)
+}
+
+// the length in bytes of the challenges of both suites
+const cLen = 16
+
+// Edwards25519SHA512ELL2 returns the ECVRF-EDWARDS25519-SHA512-ELL2 suite,
+// whose secret keys are the 32-byte seeds of Ed25519, and whose points are
+// hashed with the Elligator 2 map of the edwards25519 group.
+func Edwards25519SHA512ELL2() *Suite {
+	group := new(edwards25519.Curve)
+	dst := "ECVRF_edwards25519_XMD:SHA-512_ELL2_NU_\x04"
+	return &Suite{
+		group:    group,
+		id:       0x04,
+		newHash:  sha512.New,
+		ptLen:    32,
+		cofactor: 8,
+		encodePoint: func(P kyber.Point) ([]byte, error) {
+			return P.MarshalBinary()
+		},
+		decodePoint: func(buf []byte) (kyber.Point, error) {
+			P := group.Point()
+			if err := P.UnmarshalBinary(buf); err != nil {
+				return nil, ErrInvalidPoint
+			}
+			if c, ok := P.(interface{ IsCanonical([]byte) bool }); ok && !c.IsCanonical(buf) {
+				return nil, ErrInvalidPoint
+			}
+			return P, nil
+		},
+		encodeToCurve: func(salt, alpha []byte) (kyber.Point, error) {
+			encoder, ok := group.Point().(interface {
+				EncodeToCurve(m []byte, dst string) kyber.Point
+			})
+			if !ok {
+				return nil, errors.New("vrf: point needs to implement EncodeToCurve")
+			}
+			return encoder.EncodeToCurve(append(append([]byte{}, salt...), alpha...), dst), nil
+		},
+		nonce: func(key *PrivateKey, h []byte) kyber.Scalar {
+			// k = SHA-512(truncated_hashed_sk_string || h_string)
+			digest := sha512.New()
+			_, _ = digest.Write(key.prefix)
+			_, _ = digest.Write(h)
+			return group.Scalar().SetBytes(digest.Sum(nil))
+		},
+		newKey: func(sk []byte) (kyber.Scalar, []byte, error) {
+			if len(sk) != 32 {
+				return nil, nil, ErrInvalidKey
+			}
+			x, _, prefix := group.NewKeyAndSeedWithInput(sk)
+			return x, prefix, nil
+		},
+	}
+}
+
+// P256SHA256TAI returns the ECVRF-P256-SHA256-TAI suite on the group of
+// p256.NewBlakeSHA256P256, whose secret keys are 32-byte big-endian scalars
+// and whose points are hashed by try-and-increment.
+func P256SHA256TAI() *Suite {
+	group := p256.NewBlakeSHA256P256()
+	s := &Suite{
+		group:    group,
+		id:       0x01,
+		newHash:  sha256.New,
+		ptLen:    33,
+		cofactor: 1,
+		encodePoint: func(P kyber.Point) ([]byte, error) {
+			buf, err := P.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			x, y := elliptic.Unmarshal(elliptic.P256(), buf)
+			if x == nil {
+				return nil, ErrInvalidPoint
+			}
+			return elliptic.MarshalCompressed(elliptic.P256(), x, y), nil
+		},
+		decodePoint: func(buf []byte) (kyber.Point, error) {
+			x, y := elliptic.UnmarshalCompressed(elliptic.P256(), buf)
+			if x == nil {
+				return nil, ErrInvalidPoint
+			}
+			P := group.Point()
+			//nolint:staticcheck // the uncompressed encoding of the points
+			if err := P.UnmarshalBinary(elliptic.Marshal(elliptic.P256(), x, y)); err != nil {
+				return nil, ErrInvalidPoint
+			}
+			return P, nil
+		},
+		newKey: func(sk []byte) (kyber.Scalar, []byte, error) {
+			x := group.Scalar()
+			if len(sk) != 32 || x.UnmarshalBinary(sk) != nil || x.Equal(group.Scalar().Zero()) {
+				return nil, nil, ErrInvalidKey
+			}
+			return x, nil, nil
+		},
+	}
+	s.encodeToCurve = s.tryAndIncrement
+	s.nonce = s.rfc6979
+	return s
+}
+
+// tryAndIncrement implements ECVRF_encode_to_curve_try_and_increment of
+// RFC 9381, section 5.4.1.1, for a curve of cofactor 1.
+func (s *Suite) tryAndIncrement(salt, alpha []byte) (kyber.Point, error) {
+	for ctr := 0; ctr < 256; ctr++ {
+		h := s.newHash()
+		_, _ = h.Write([]byte{s.id, 0x01})
+		_, _ = h.Write(salt)
+		_, _ = h.Write(alpha)
+		_, _ = h.Write([]byte{byte(ctr), 0x00})
+		if H, err := s.decodePoint(h.Sum([]byte{0x02})); err == nil {
+			return H, nil
+		}
+	}
+	return nil, errors.New("vrf: no valid point found")
+}
+
+// rfc6979 implements the deterministic nonce generation of RFC 6979,
+// section 3.2, for the curves whose order has the size of the hash, as
+// required by RFC 9381, section 5.4.2.1.
+func (s *Suite) rfc6979(key *PrivateKey, h []byte) kyber.Scalar {
+	q := s.group.(interface{ Order() *big.Int }).Order()
+	size := (q.BitLen() + 7) / 8
+	int2octets := func(v *big.Int) []byte {
+		return v.FillBytes(make([]byte, size))
+	}
+	digest := s.newHash()
+	_, _ = digest.Write(h)
+	h1 := new(big.Int).SetBytes(digest.Sum(nil))
+	x, _ := key.x.MarshalBinary()
+	bx := append(x, int2octets(h1.Mod(h1, q))...)
+
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(s.newHash, key)
+		for _, d := range data {
+			_, _ = m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+	hlen := s.newHash().Size()
+	V := make([]byte, hlen)
+	for i := range V {
+		V[i] = 0x01
+	}
+	K := make([]byte, hlen)
+	K = mac(K, V, []byte{0x00}, bx)
+	V = mac(K, V)
+	K = mac(K, V, []byte{0x01}, bx)
+	V = mac(K, V)
+	for {
+		var T []byte
+		for len(T) < size {
+			V = mac(K, V)
+			T = append(T, V...)
+		}
+		k := new(big.Int).SetBytes(T[:size])
+		if k.Sign() > 0 && k.Cmp(q) < 0 {
+			return s.group.Scalar().SetBytes(int2octets(k))
+		}
+		K = mac(K, V, []byte{0x00})
+		V = mac(K, V)
+	}
+}
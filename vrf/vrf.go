@@ -0,0 +1,200 @@
+// Package vrf implements the elliptic curve verifiable random functions
+// (ECVRF) of RFC 9381, with the ECVRF-EDWARDS25519-SHA512-ELL2 and
+// ECVRF-P256-SHA256-TAI ciphersuites.
+//
+// The holder of a secret key computes with Prove a proof for an input alpha,
+// from which anyone computes with ProofToHash the output beta of the VRF.
+// Verify checks the proof against the public key, and returns the same
+// output. The output is unique for a key and an input, and is
+// indistinguishable from random without the proof, e.g. for leader election.
+//
+// The proof is a non-interactive proof of the equality of the discrete
+// logarithms of the public key and of Gamma = x*H, where H is the hash of the
+// input, as the proofs of the proof/dleq package. It is however encoded as
+// the challenge and the response only, with a deterministic nonce, so that
+// the proofs are those of the RFC.
+package vrf
+
+import (
+	"bytes"
+	"errors"
+
+	"go.dedis.ch/kyber/v4"
+)
+
+var (
+	// ErrInvalidProof is returned by Verify for the invalid proofs.
+	ErrInvalidProof = errors.New("vrf: invalid proof")
+	// ErrInvalidPoint is returned for the invalid encodings of points.
+	ErrInvalidPoint = errors.New("vrf: invalid point")
+	// ErrInvalidKey is returned for the invalid keys.
+	ErrInvalidKey = errors.New("vrf: invalid key")
+)
+
+// PrivateKey is a secret key of a suite.
+type PrivateKey struct {
+	suite  *Suite
+	x      kyber.Scalar
+	prefix []byte
+	public kyber.Point
+}
+
+// NewPrivateKey returns the private key of the secret key sk, e.g. the
+// seed of an Ed25519 key, as returned by the first 32 bytes of
+// eddsa.EdDSA.MarshalBinary, for Edwards25519SHA512ELL2.
+func (s *Suite) NewPrivateKey(sk []byte) (*PrivateKey, error) {
+	x, prefix, err := s.newKey(sk)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKey{suite: s, x: x, prefix: prefix, public: s.group.Point().Mul(x, nil)}, nil
+}
+
+// Public returns the public key.
+func (k *PrivateKey) Public() kyber.Point {
+	return k.public
+}
+
+// Group returns the group of the keys.
+func (s *Suite) Group() kyber.Group {
+	return s.group
+}
+
+// ProofSize returns the size in bytes of the proofs.
+func (s *Suite) ProofSize() int {
+	return s.ptLen + cLen + s.group.ScalarLen()
+}
+
+// Prove returns the proof pi of the VRF output for the input alpha.
+func Prove(key *PrivateKey, alpha []byte) ([]byte, error) {
+	s := key.suite
+	Y, err := s.encodePoint(key.public)
+	if err != nil {
+		return nil, err
+	}
+	H, err := s.encodeToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+	h, err := s.encodePoint(H)
+	if err != nil {
+		return nil, err
+	}
+	Gamma := s.group.Point().Mul(key.x, H)
+	k := key.suite.nonce(key, h)
+	c, err := s.challenge(key.public, H, Gamma, s.group.Point().Mul(k, nil), s.group.Point().Mul(k, H))
+	if err != nil {
+		return nil, err
+	}
+
+	// s = k + c*x
+	S := s.group.Scalar().SetBytes(c)
+	S.Mul(S, key.x).Add(S, k)
+
+	gamma, err := s.encodePoint(Gamma)
+	if err != nil {
+		return nil, err
+	}
+	sBuf, err := S.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(append(gamma, c...), sBuf...), nil
+}
+
+// Verify checks the proof pi of the output of the public key for the input
+// alpha, rejecting the public keys of small order, and returns the output
+// beta of a valid proof.
+func Verify(s *Suite, public kyber.Point, alpha, pi []byte) ([]byte, error) {
+	Y, err := s.encodePoint(public)
+	if err != nil {
+		return nil, err
+	}
+	if s.group.Point().Mul(s.group.Scalar().SetInt64(s.cofactor), public).Equal(s.group.Point().Null()) {
+		return nil, ErrInvalidKey
+	}
+	Gamma, c, S, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	H, err := s.encodeToCurve(Y, alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	// U = s*B - c*Y, V = s*H - c*Gamma
+	cs := s.group.Scalar().SetBytes(c)
+	U := s.group.Point().Mul(S, nil)
+	U.Sub(U, s.group.Point().Mul(cs, public))
+	V := s.group.Point().Mul(S, H)
+	V.Sub(V, s.group.Point().Mul(cs, Gamma))
+	expected, err := s.challenge(public, H, Gamma, U, V)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(c, expected) {
+		return nil, ErrInvalidProof
+	}
+	return s.proofToHash(Gamma)
+}
+
+// ProofToHash returns the output beta of the proof pi, without checking it:
+// the output must not be used before the proof is verified with Verify.
+func ProofToHash(s *Suite, pi []byte) ([]byte, error) {
+	Gamma, _, _, err := s.decodeProof(pi)
+	if err != nil {
+		return nil, err
+	}
+	return s.proofToHash(Gamma)
+}
+
+// proofToHash returns Hash(suite_string || 0x03 || cofactor*Gamma || 0x00).
+func (s *Suite) proofToHash(Gamma kyber.Point) ([]byte, error) {
+	P, err := s.encodePoint(s.group.Point().Mul(s.group.Scalar().SetInt64(s.cofactor), Gamma))
+	if err != nil {
+		return nil, err
+	}
+	h := s.newHash()
+	_, _ = h.Write([]byte{s.id, 0x03})
+	_, _ = h.Write(P)
+	_, _ = h.Write([]byte{0x00})
+	return h.Sum(nil), nil
+}
+
+// challenge returns the encoding of the challenge, the truncated
+// Hash(suite_string || 0x02 || points || 0x00). The scalars of the groups
+// are set from bytes in the byte order of the suites.
+func (s *Suite) challenge(points ...kyber.Point) ([]byte, error) {
+	h := s.newHash()
+	_, _ = h.Write([]byte{s.id, 0x02})
+	for _, P := range points {
+		buf, err := s.encodePoint(P)
+		if err != nil {
+			return nil, err
+		}
+		_, _ = h.Write(buf)
+	}
+	_, _ = h.Write([]byte{0x00})
+	return h.Sum(nil)[:cLen], nil
+}
+
+// decodeProof returns Gamma, the encoding of the challenge and the response
+// of the proof.
+func (s *Suite) decodeProof(pi []byte) (kyber.Point, []byte, kyber.Scalar, error) {
+	if len(pi) != s.ProofSize() {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	Gamma, err := s.decodePoint(pi[:s.ptLen])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sBuf := pi[s.ptLen+cLen:]
+	if c, ok := s.group.Scalar().(interface{ IsCanonical([]byte) bool }); ok && !c.IsCanonical(sBuf) {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	S := s.group.Scalar()
+	if err := S.UnmarshalBinary(sBuf); err != nil {
+		return nil, nil, nil, ErrInvalidProof
+	}
+	return Gamma, pi[s.ptLen : s.ptLen+cLen], S, nil
+}
@@ -0,0 +1,154 @@
+package vrf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v4/sign/eddsa"
+	"go.dedis.ch/kyber/v4/util/random"
+)
+
+var suites = map[string]*Suite{
+	"edwards25519": Edwards25519SHA512ELL2(),
+	"p256":         P256SHA256TAI(),
+}
+
+// Test vectors of RFC 9381, appendix B.
+var vectors = []struct {
+	suite                      *Suite
+	sk, pk, alpha, h, pi, beta string
+}{
+	// ECVRF-EDWARDS25519-SHA512-ELL2, example 19
+	{
+		suite: Edwards25519SHA512ELL2(),
+		sk:    "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60",
+		pk:    "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+		alpha: "",
+		h:     "b8066ebbb706c72b64390324e4a3276f129569eab100c26b9f05011200c1bad9",
+		pi: "7d9c633ffeee27349264cf5c667579fc583b4bda63ab71d001f89c10003ab46f14adf9a3cd8b8412d9038531e865c3" +
+			"41cafa73589b023d14311c331a9ad15ff2fb37831e00f0acaa6d73bc9997b06501",
+		beta: "9d574bf9b8302ec0fc1e21c3ec5368269527b87b462ce36dab2d14ccf80c53cccf6758f058c5b1c856b116388152bb" +
+			"e509ee3b9ecfe63d93c3b4346c1fbc6c54",
+	},
+	// ECVRF-P256-SHA256-TAI, example 10
+	{
+		suite: P256SHA256TAI(),
+		sk:    "c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+		pk:    "0360fed4ba255a9d31c961eb74c6356d68c049b8923b61fa6ce669622e60f29fb6",
+		alpha: "73616d706c65",
+		h:     "0272a877532e9ac193aff4401234266f59900a4a9e3fc3cfc6a4b7e467a15d06d4",
+		pi: "035b5c726e8c0e2c488a107c600578ee75cb702343c153cb1eb8dec77f4b5071b4a53f0a46f018bc2c56e58d383f2305" +
+			"e0975972c26feea0eb122fe7893c15af376b33edf7de17c6ea056d4d82de6bc02f",
+		beta: "a3ad7b0ef73d8fc6655053ea22f9bede8c743f08bbed3d38821f0e16474b505e",
+	},
+}
+
+func decode(t *testing.T, s string) []byte {
+	buf, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return buf
+}
+
+func TestVectors(t *testing.T) {
+	for i, v := range vectors {
+		key, err := v.suite.NewPrivateKey(decode(t, v.sk))
+		require.NoError(t, err, i)
+		pk, err := v.suite.encodePoint(key.Public())
+		require.NoError(t, err, i)
+		require.Equal(t, v.pk, hex.EncodeToString(pk), i)
+
+		alpha := decode(t, v.alpha)
+		H, err := v.suite.encodeToCurve(pk, alpha)
+		require.NoError(t, err, i)
+		h, err := v.suite.encodePoint(H)
+		require.NoError(t, err, i)
+		require.Equal(t, v.h, hex.EncodeToString(h), i)
+
+		pi, err := Prove(key, alpha)
+		require.NoError(t, err, i)
+		require.Equal(t, v.pi, hex.EncodeToString(pi), i)
+
+		beta, err := Verify(v.suite, key.Public(), alpha, pi)
+		require.NoError(t, err, i)
+		require.Equal(t, v.beta, hex.EncodeToString(beta), i)
+		beta, err = ProofToHash(v.suite, pi)
+		require.NoError(t, err, i)
+		require.Equal(t, v.beta, hex.EncodeToString(beta), i)
+	}
+}
+
+func TestProve(t *testing.T) {
+	for name, suite := range suites {
+		sk := make([]byte, 32)
+		random.Bytes(sk, random.New())
+		sk[0] &= 0x7f
+		key, err := suite.NewPrivateKey(sk)
+		require.NoError(t, err, name)
+		other, err := suite.NewPrivateKey(append([]byte{0}, sk[1:]...))
+		require.NoError(t, err, name)
+
+		alpha := []byte("leader election round 1")
+		pi, err := Prove(key, alpha)
+		require.NoError(t, err, name)
+		require.Len(t, pi, suite.ProofSize(), name)
+		beta, err := Verify(suite, key.Public(), alpha, pi)
+		require.NoError(t, err, name)
+
+		// the output is unique and the proofs are deterministic
+		again, err := Prove(key, alpha)
+		require.NoError(t, err, name)
+		require.Equal(t, pi, again, name)
+
+		// but differs for other inputs and keys
+		pi2, err := Prove(key, []byte("leader election round 2"))
+		require.NoError(t, err, name)
+		beta2, err := ProofToHash(suite, pi2)
+		require.NoError(t, err, name)
+		require.NotEqual(t, beta, beta2, name)
+
+		_, err = Verify(suite, key.Public(), []byte("leader election round 2"), pi)
+		require.ErrorIs(t, err, ErrInvalidProof, name)
+		_, err = Verify(suite, other.Public(), alpha, pi)
+		require.ErrorIs(t, err, ErrInvalidProof, name)
+
+		bad := append([]byte{}, pi...)
+		bad[suite.ptLen] ^= 1
+		_, err = Verify(suite, key.Public(), alpha, bad)
+		require.ErrorIs(t, err, ErrInvalidProof, name)
+		_, err = Verify(suite, key.Public(), alpha, pi[1:])
+		require.ErrorIs(t, err, ErrInvalidProof, name)
+	}
+}
+
+func TestEdDSAKeys(t *testing.T) {
+	suite := Edwards25519SHA512ELL2()
+	signer := eddsa.NewEdDSA(random.New())
+	buf, err := signer.MarshalBinary()
+	require.NoError(t, err)
+	key, err := suite.NewPrivateKey(buf[:32])
+	require.NoError(t, err)
+	require.True(t, key.Public().Equal(signer.Public))
+
+	pi, err := Prove(key, []byte("alpha"))
+	require.NoError(t, err)
+	_, err = Verify(suite, signer.Public, []byte("alpha"), pi)
+	require.NoError(t, err)
+}
+
+func TestInvalidKeys(t *testing.T) {
+	suite := Edwards25519SHA512ELL2()
+	key, err := suite.NewPrivateKey(make([]byte, 32))
+	require.NoError(t, err)
+	pi, err := Prove(key, nil)
+	require.NoError(t, err)
+
+	// a public key of small order
+	_, err = Verify(suite, suite.Group().Point().Null(), nil, pi)
+	require.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = suite.NewPrivateKey(make([]byte, 31))
+	require.ErrorIs(t, err, ErrInvalidKey)
+	_, err = P256SHA256TAI().NewPrivateKey(make([]byte, 32))
+	require.ErrorIs(t, err, ErrInvalidKey)
+}